@@ -2,9 +2,12 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"cuelang.org/go/cue/cuecontext"
 	"github.com/grafana/codejen"
@@ -13,6 +16,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/grafana/grafana-app-sdk/codegen"
+	"github.com/grafana/grafana-app-sdk/k8s"
 	"github.com/grafana/grafana-app-sdk/kindsys"
 )
 
@@ -37,6 +41,12 @@ Currently only allowed value is 'kubernetes', which will generate Custom Resourc
 files. Allowed values are 'json' and 'yaml'. Only applicable if type=kubernetes.`)
 	generateCmd.Flags().String("crdpath", "definitions", `Path where Custom Resource 
 Definitions will be created. Only applicable if type=kubernetes`)
+	generateCmd.Flags().String("crdconversionservice", "default/operator", `Kubernetes service, as <namespace>/<name>, 
+which serves the conversion webhook at /convert for kinds with multiple versions. Only applicable if type=kubernetes`)
+	generateCmd.Flags().String("openapipath", "", `Path where an OpenAPI document describing the 
+ResourceGroupRouter routes of all resource kinds will be created. If empty, no OpenAPI document is generated.`)
+	generateCmd.Flags().String("openapiencoding", "json", `Encoding for the OpenAPI document. 
+Allowed values are 'json' and 'yaml'. Only applicable if openapipath is set.`)
 
 	// Don't show "usage" information when an error is returned form the command,
 	// because our errors are not command-usage-based
@@ -100,6 +110,13 @@ func generateCmdFunc(cmd *cobra.Command, _ []string) error {
 	}
 	allFiles = append(allFiles, files...)
 
+	// Plugin settings codegen, if the plugin declares its settings
+	files, err = generatePluginSettings(parser, tsGenPath)
+	if err != nil {
+		return err
+	}
+	allFiles = append(allFiles, files...)
+
 	// Schema definition generation (CRD-only currently)
 	switch storageType {
 	case "kubernetes":
@@ -111,7 +128,15 @@ func generateCmdFunc(cmd *cobra.Command, _ []string) error {
 		if err != nil {
 			return err
 		}
-		files, err = generateCRDs(parser, crdPath, encType, selectors)
+		conversionService, err := cmd.Flags().GetString("crdconversionservice")
+		if err != nil {
+			return err
+		}
+		conversionWebhook, err := conversionWebhookForService(conversionService)
+		if err != nil {
+			return err
+		}
+		files, err = generateCRDs(parser, crdPath, encType, conversionWebhook, selectors)
 		if err != nil {
 			return err
 		}
@@ -120,6 +145,23 @@ func generateCmdFunc(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("unknown storage type '%s'", storageType)
 	}
 
+	// OpenAPI document generation
+	openAPIPath, err := cmd.Flags().GetString("openapipath")
+	if err != nil {
+		return err
+	}
+	if openAPIPath != "" {
+		encType, err := cmd.Flags().GetString("openapiencoding")
+		if err != nil {
+			return err
+		}
+		files, err = generateOpenAPI(parser, openAPIPath, encType, selectors)
+		if err != nil {
+			return err
+		}
+		allFiles = append(allFiles, files...)
+	}
+
 	for _, f := range allFiles {
 		err = writeFile(f.RelativePath, f.Data)
 		if err != nil {
@@ -175,13 +217,120 @@ func generateFrontendModels(parser *codegen.CustomKindParser, genPath string, se
 	return files, nil
 }
 
-func generateCRDs(parser *codegen.CustomKindParser, genPath string, encoding string, selectors []string) (codejen.Files, error) {
+// generatePluginSettings generates TypeScript types for the plugin's settings,
+// and re-generates the settings.go file of the backend plugin's `secure` package if the project has a backend plugin component,
+// so that the frontend and backend always agree on the settings.
+// The rest of the `secure` package is boilerplate written by `project component add backend`, and is never overwritten.
+// It generates nothing if the plugin doesn't declare its settings (see codegen.PluginSettingsSelector).
+func generatePluginSettings(parser *codegen.CustomKindParser, tsGenPath string) (codejen.Files, error) {
+	settings, err := parser.PluginSettings()
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, nil
+	}
+
+	// The settings generators don't use any kinds, so filter all of them out
+	noKinds := func(kindsys.Custom) bool {
+		return false
+	}
+	files, err := parser.FilteredGenerate(codegen.Filter(codegen.PluginSettingsTypeScriptGenerator(settings), noKinds))
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range files {
+		files[i].RelativePath = filepath.Join(tsGenPath, f.RelativePath)
+	}
+
+	// Same path as the backend plugin component (see projectAddPluginAPI)
+	securePath := filepath.Join("pkg", "plugin", "secure")
+	if _, err := os.Stat(securePath); err != nil {
+		return files, nil
+	}
+	goFiles, err := parser.FilteredGenerate(codegen.Filter(codegen.PluginSettingsGoGenerator(settings), noKinds))
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range goFiles {
+		goFiles[i].RelativePath = filepath.Join("pkg", f.RelativePath)
+		if err := checkGeneratedFile(goFiles[i].RelativePath); err != nil {
+			return nil, err
+		}
+	}
+	// Projects created before the settings were generated declare Data in a data.go file which can be edited
+	if _, err := os.Stat(filepath.Join(securePath, "data.go")); err == nil {
+		return nil, fmt.Errorf("%s declares Data, which is generated in settings.go from the plugin's settings: "+
+			"remove it, or move any changes to it to another file", filepath.Join(securePath, "data.go"))
+	}
+	return append(files, goFiles...), nil
+}
+
+// generatedCodeRegex matches the comment which marks a go file as generated (see https://go.dev/s/generatedcode)
+var generatedCodeRegex = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// checkGeneratedFile returns an error if the file at path exists, and was not generated,
+// so that files which can be edited are never overwritten.
+func checkGeneratedFile(path string) error {
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !generatedCodeRegex.Match(contents) {
+		return fmt.Errorf("refusing to overwrite %s, which is not marked as generated code: "+
+			"remove it, or move any changes to it to another file", path)
+	}
+	return nil
+}
+
+// conversionWebhookForService returns the client config of the conversion webhook served at /convert
+// by the service, provided as <namespace>/<name>
+func conversionWebhookForService(service string) (k8s.CustomResourceDefinitionWebhookClientConfig, error) {
+	namespace, name, ok := strings.Cut(service, "/")
+	if !ok || namespace == "" || name == "" {
+		return k8s.CustomResourceDefinitionWebhookClientConfig{},
+			fmt.Errorf("conversion service '%s' is not of the format <namespace>/<name>", service)
+	}
+	return k8s.CustomResourceDefinitionWebhookClientConfig{
+		Service: &k8s.CustomResourceDefinitionServiceReference{
+			Namespace: namespace,
+			Name:      name,
+			Path:      "/convert",
+		},
+	}, nil
+}
+
+func generateCRDs(parser *codegen.CustomKindParser, genPath string, encoding string,
+	conversionWebhook k8s.CustomResourceDefinitionWebhookClientConfig, selectors []string) (codejen.Files, error) {
+	var ms codegen.Generator
+	if encoding == "yaml" {
+		ms = codegen.CRDGeneratorWithConversionWebhook(yaml.Marshal, "yaml", conversionWebhook)
+	} else {
+		// Assume JSON
+		ms = codegen.CRDGeneratorWithConversionWebhook(json.Marshal, "json", conversionWebhook)
+	}
+	files, err := parser.FilteredGenerate(codegen.Filter(ms, func(c kindsys.Custom) bool {
+		return c.Def().Properties.IsCRD
+	}), selectors...)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range files {
+		files[i].RelativePath = filepath.Join(genPath, f.RelativePath)
+	}
+	return files, nil
+}
+
+func generateOpenAPI(parser *codegen.CustomKindParser, genPath string, encoding string, selectors []string) (codejen.Files, error) {
 	var ms codegen.Generator
 	if encoding == "yaml" {
-		ms = codegen.CRDGenerator(yaml.Marshal, "yaml")
+		ms = codegen.OpenAPIGenerator(yaml.Marshal, "yaml")
 	} else {
 		// Assume JSON
-		ms = codegen.CRDGenerator(json.Marshal, "json")
+		ms = codegen.OpenAPIGenerator(json.Marshal, "json")
 	}
 	files, err := parser.FilteredGenerate(codegen.Filter(ms, func(c kindsys.Custom) bool {
 		return c.Def().Properties.IsCRD
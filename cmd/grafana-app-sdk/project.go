@@ -517,7 +517,11 @@ func addComponentBackend(projectRootPath string, generator *codegen.CustomKindPa
 
 //nolint:revive
 func projectAddPluginAPI(generator *codegen.CustomKindParser, repo, generatedAPIModelsPath string, selectors []string) error {
-	goFiles, err := generator.FilteredGenerate(codegen.Filter(codegen.BackendPluginGenerator(repo, generatedAPIModelsPath), func(c kindsys.Custom) bool {
+	settings, err := generator.PluginSettings()
+	if err != nil {
+		return err
+	}
+	goFiles, err := generator.FilteredGenerate(codegen.Filter(codegen.BackendPluginGenerator(repo, generatedAPIModelsPath, settings), func(c kindsys.Custom) bool {
 		return c.Def().Properties.Codegen.Frontend
 	}), selectors...)
 	if err != nil {
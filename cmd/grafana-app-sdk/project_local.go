@@ -28,6 +28,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/grafana/grafana-app-sdk/codegen"
+	"github.com/grafana/grafana-app-sdk/k8s"
 )
 
 //go:embed templates/local/* templates/local/scripts/* templates/local/generated/datasources/*
@@ -302,6 +303,9 @@ type crdYAML struct {
 			Name   string `yaml:"name"`
 			Served bool   `yaml:"served"`
 		} `yaml:"versions"`
+		Conversion struct {
+			Strategy string `yaml:"strategy"`
+		} `yaml:"conversion"`
 	} `yaml:"spec"`
 }
 
@@ -337,30 +341,24 @@ func generateKubernetesYAML(parser *codegen.CustomKindParser, pluginID string, c
 		props.OperatorImage = fmt.Sprintf("localhost/%s", props.OperatorImage)
 	}
 
-	if props.WebhookProperties.Enabled {
-		if config.Webhooks.Port > 0 {
-			props.WebhookProperties.Port = config.Webhooks.Port
-		} else {
-			props.WebhookProperties.Port = 8443
-		}
-		if config.Webhooks.Mutating {
-			props.WebhookProperties.Mutating = "/mutate"
-		}
-		if config.Webhooks.Validating {
-			props.WebhookProperties.Validating = "/validate"
-		}
-		// Generate cert bundle
-		bundle, err := generateCerts(fmt.Sprintf("%s-operator.default.svc", props.PluginID))
-		if err != nil {
-			return nil, err
-		}
-		props.WebhookProperties.Base64Cert = base64.StdEncoding.EncodeToString(bundle.cert)
-		props.WebhookProperties.Base64Key = base64.StdEncoding.EncodeToString(bundle.key)
-		props.WebhookProperties.Base64CA = base64.StdEncoding.EncodeToString(bundle.ca)
+	// Generate cert bundle for the operator's webhooks, which is also used by the conversion webhook of CRDs
+	bundle, err := generateCerts(fmt.Sprintf("%s-operator.default.svc", props.PluginID))
+	if err != nil {
+		return nil, err
 	}
+	props.WebhookProperties.Base64Cert = base64.StdEncoding.EncodeToString(bundle.cert)
+	props.WebhookProperties.Base64Key = base64.StdEncoding.EncodeToString(bundle.key)
+	props.WebhookProperties.Base64CA = base64.StdEncoding.EncodeToString(bundle.ca)
 
 	// Generate CRD YAML files, add the CRD metadata to the props
-	crdFiles, err := generateCRDs(parser, "", "yaml", []string{})
+	crdFiles, err := generateCRDs(parser, "", "yaml", k8s.CustomResourceDefinitionWebhookClientConfig{
+		Service: &k8s.CustomResourceDefinitionServiceReference{
+			Namespace: "default",
+			Name:      fmt.Sprintf("%s-operator", props.PluginID),
+			Path:      "/convert",
+		},
+		CABundle: props.WebhookProperties.Base64CA,
+	}, []string{})
 	if err != nil {
 		return nil, err
 	}
@@ -371,6 +369,10 @@ func generateKubernetesYAML(parser *codegen.CustomKindParser, pluginID string, c
 		if err != nil {
 			return nil, err
 		}
+		// The operator must serve the conversion webhook of CRDs with multiple versions
+		if yml.Spec.Conversion.Strategy == k8s.CustomResourceDefinitionConversionStrategyWebhook && props.OperatorImage != "" {
+			props.WebhookProperties.Enabled = true
+		}
 		versions := make([]string, 0)
 		for _, v := range yml.Spec.Versions {
 			if v.Served {
@@ -385,6 +387,20 @@ func generateKubernetesYAML(parser *codegen.CustomKindParser, pluginID string, c
 		})
 	}
 
+	if props.WebhookProperties.Enabled {
+		if config.Webhooks.Port > 0 {
+			props.WebhookProperties.Port = config.Webhooks.Port
+		} else {
+			props.WebhookProperties.Port = 8443
+		}
+		if config.Webhooks.Mutating {
+			props.WebhookProperties.Mutating = "/mutate"
+		}
+		if config.Webhooks.Validating {
+			props.WebhookProperties.Validating = "/validate"
+		}
+	}
+
 	// RBAC for CRDs
 	tmplRoles, err := template.ParseFS(localEnvFiles, "templates/local/generated/crd_roles.yaml")
 	if err != nil {
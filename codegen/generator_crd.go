@@ -19,8 +19,9 @@ import (
 type CRDOutputEncoder func(any) ([]byte, error)
 
 type crdGenerator struct {
-	outputEncoder   CRDOutputEncoder
-	outputExtension string
+	outputEncoder     CRDOutputEncoder
+	outputExtension   string
+	conversionWebhook k8s.CustomResourceDefinitionWebhookClientConfig
 }
 
 func (*crdGenerator) JennyName() string {
@@ -50,7 +51,8 @@ func (c *crdGenerator) Generate(decl kindsys.Custom) (*codejen.File, error) {
 				Kind:   meta.Name,
 				Plural: meta.PluralMachineName,
 			},
-			Versions: make([]k8s.CustomResourceDefinitionSpecVersion, 0),
+			Versions:   make([]k8s.CustomResourceDefinitionSpecVersion, 0),
+			Conversion: c.conversion(lin),
 		},
 	}
 	latest := lin.Latest().Version()
@@ -71,6 +73,22 @@ func (c *crdGenerator) Generate(decl kindsys.Custom) (*codejen.File, error) {
 	return codejen.NewFile(fmt.Sprintf("%s.%s.%s", meta.MachineName, decl.Def().Properties.CRD.Group, c.outputExtension), contents, c), nil
 }
 
+// conversion returns the conversion section of the CRD for the lineage, which is nil if the lineage has only one version.
+// Otherwise, the API server must call the conversion webhook to translate objects between versions with the lineage's lenses,
+// as the default "None" strategy would only change the apiVersion of objects.
+func (c *crdGenerator) conversion(lin thema.Lineage) *k8s.CustomResourceDefinitionConversion {
+	if lin.First().Successor() == nil {
+		return nil
+	}
+	return &k8s.CustomResourceDefinitionConversion{
+		Strategy: k8s.CustomResourceDefinitionConversionStrategyWebhook,
+		Webhook: &k8s.CustomResourceDefinitionConversionWebhook{
+			ConversionReviewVersions: []string{"v1"},
+			ClientConfig:             c.conversionWebhook,
+		},
+	}
+}
+
 func schemaToCRDSpecVersion(sch thema.Schema, name string, stored bool) (k8s.CustomResourceDefinitionSpecVersion,
 	error) {
 	props, err := schemaToOpenAPIProperties(sch)
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana-app-sdk/k8s"
 )
 
 func TestCrdGenerator_Generate_JSON(t *testing.T) {
@@ -38,6 +40,42 @@ func TestCrdGenerator_Generate_YAML(t *testing.T) {
 	compareToGolden(t, files, "")
 }
 
+func TestCrdGenerator_conversion(t *testing.T) {
+	webhook := k8s.CustomResourceDefinitionWebhookClientConfig{
+		Service: &k8s.CustomResourceDefinitionServiceReference{
+			Namespace: "ns",
+			Name:      "svc",
+			Path:      "/convert",
+		},
+	}
+	g := &crdGenerator{
+		conversionWebhook: webhook,
+	}
+	bind := func(schemas string) thema.Lineage {
+		ctx := cuecontext.New()
+		lin, err := thema.BindLineage(ctx.CompileString(`name: "conversion"
+schemas: [`+schemas+`]`), thema.NewRuntime(ctx))
+		require.Nil(t, err)
+		return lin
+	}
+
+	t.Run("single version", func(t *testing.T) {
+		assert.Nil(t, g.conversion(bind(`{version: [0, 0], schema: {spec: {foo: string}}}`)))
+	})
+
+	t.Run("multiple versions", func(t *testing.T) {
+		conversion := g.conversion(bind(`{version: [0, 0], schema: {spec: {foo: string}}},
+{version: [0, 1], schema: {spec: {foo: string, bar?: string}}}`))
+		assert.Equal(t, &k8s.CustomResourceDefinitionConversion{
+			Strategy: k8s.CustomResourceDefinitionConversionStrategyWebhook,
+			Webhook: &k8s.CustomResourceDefinitionConversionWebhook{
+				ConversionReviewVersions: []string{"v1"},
+				ClientConfig:             webhook,
+			},
+		}, conversion)
+	})
+}
+
 func TestCrdGenerator_JennyName(t *testing.T) {
 	g := &crdGenerator{}
 	assert.Equal(t, "CRD Generator", g.JennyName())
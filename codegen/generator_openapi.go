@@ -0,0 +1,91 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grafana/codejen"
+
+	"github.com/grafana/grafana-app-sdk/kindsys"
+	"github.com/grafana/grafana-app-sdk/plugin/router"
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// OpenAPIOutputEncoder is a function which marshals an OpenAPI document into a desired output format
+type OpenAPIOutputEncoder func(any) ([]byte, error)
+
+type openAPIGenerator struct {
+	outputEncoder   OpenAPIOutputEncoder
+	outputExtension string
+}
+
+func (*openAPIGenerator) JennyName() string {
+	return "OpenAPI Generator"
+}
+
+// Generate creates a single OpenAPI document describing the routes that a router.ResourceGroupRouter
+// exposes for all the provided kinds, using the current version of each kind's schema for request and response bodies.
+func (o *openAPIGenerator) Generate(decls ...kindsys.Custom) (*codejen.File, error) {
+	schemas := make(openAPISchemaGroup, 0, len(decls))
+	groups := make(map[string]struct{})
+	for _, decl := range decls {
+		meta := decl.Def().Properties
+		sch, err := decl.Lineage().Schema(meta.CurrentVersion)
+		if err != nil {
+			return nil, err
+		}
+		props, err := schemaToOpenAPIProperties(sch)
+		if err != nil {
+			return nil, err
+		}
+
+		// schemaToOpenAPIProperties removes metadata, as it can't be extended in a CRD
+		props["metadata"] = map[string]any{
+			"type": "object",
+		}
+
+		// The router adds subresource routes for every property other than spec and metadata, as in the CRD
+		schemas = append(schemas, resource.NewSimpleSchema(meta.CRD.Group, versionString(meta.CurrentVersion),
+			&resource.SimpleObject[any]{},
+			resource.WithKind(meta.Name), resource.WithPlural(meta.PluralMachineName),
+			resource.WithScope(resource.SchemaScope(meta.CRD.Scope)), resource.WithOpenAPISchema(map[string]any{
+				"type":       "object",
+				"properties": props,
+				"required":   []string{"spec"},
+			})))
+		groups[meta.CRD.Group] = struct{}{}
+	}
+
+	// The router is only used to describe its routes, so it never needs to resolve a store
+	rgr, err := router.NewResourceGroupRouterWithStoreResolver(schemas,
+		func(context.Context) (string, router.Store, error) {
+			return "", nil, fmt.Errorf("no store available")
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	groupNames := make([]string, 0, len(groups))
+	for g := range groups {
+		groupNames = append(groupNames, g)
+	}
+	sort.Strings(groupNames)
+	contents, err := o.outputEncoder(rgr.OpenAPI(router.OpenAPIInfo{
+		Title:   fmt.Sprintf("%s API", strings.Join(groupNames, ", ")),
+		Version: "1.0.0",
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return codejen.NewFile(fmt.Sprintf("openapi.%s", o.outputExtension), contents, o), nil
+}
+
+// openAPISchemaGroup is a resource.SchemaGroup of schemas which may have different groups and versions
+type openAPISchemaGroup []resource.Schema
+
+func (g openAPISchemaGroup) Schemas() []resource.Schema {
+	return g
+}
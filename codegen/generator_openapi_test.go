@@ -0,0 +1,31 @@
+package codegen
+
+import (
+	"os"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/grafana/thema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestOpenAPIGenerator_Generate(t *testing.T) {
+	parser, err := NewCustomKindParser(thema.NewRuntime(cuecontext.New()), os.DirFS(testCueDir))
+	require.Nil(t, err)
+	files, err := parser.Generate(wrapJenny(&openAPIGenerator{
+		outputExtension: "yaml",
+		outputEncoder:   yaml.Marshal,
+	}), "customKind")
+	require.Nil(t, err)
+	// Check number of files generated
+	assert.Len(t, files, 1)
+	// Check content against the golden files
+	compareToGolden(t, files, "")
+}
+
+func TestOpenAPIGenerator_JennyName(t *testing.T) {
+	g := &openAPIGenerator{}
+	assert.Equal(t, "OpenAPI Generator", g.JennyName())
+}
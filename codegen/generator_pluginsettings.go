@@ -0,0 +1,209 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/grafana/codejen"
+
+	"github.com/grafana/grafana-app-sdk/codegen/templates"
+	"github.com/grafana/grafana-app-sdk/kindsys"
+)
+
+// pluginSecureGenerator generates the `secure` package of a backend plugin,
+// with go types, parsing, and validation for the plugin's settings, and a router middleware.
+type pluginSecureGenerator struct {
+	settings *PluginSettings
+}
+
+func (g *pluginSecureGenerator) Generate(...kindsys.Custom) (codejen.Files, error) {
+	md, err := pluginSettingsMetadata(g.settings)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := templates.GetBackendPluginSecurePackageFiles(md)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort file names for stable output
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make(codejen.Files, 0, len(names))
+	for _, name := range names {
+		formatted, err := format.Source(contents[name])
+		if err != nil {
+			return nil, fmt.Errorf("error formatting %s: %w", name, err)
+		}
+		files = append(files, codejen.File{
+			RelativePath: "plugin/secure/" + name,
+			Data:         formatted,
+			From:         []codejen.NamedJenny{g},
+		})
+	}
+	return files, nil
+}
+
+func (*pluginSecureGenerator) JennyName() string {
+	return "pluginSecureGenerator"
+}
+
+// pluginSettingsGoGenerator generates only the settings.go file of the `secure` package of a backend plugin,
+// which contains the go types, parsing, and validation for the plugin's settings.
+type pluginSettingsGoGenerator struct {
+	settings *PluginSettings
+}
+
+func (g *pluginSettingsGoGenerator) Generate(...kindsys.Custom) (*codejen.File, error) {
+	md, err := pluginSettingsMetadata(g.settings)
+	if err != nil {
+		return nil, err
+	}
+	b := bytes.Buffer{}
+	if err := templates.WritePluginSettingsGo(md, &b); err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting settings.go: %w", err)
+	}
+	return codejen.NewFile("plugin/secure/settings.go", formatted, g), nil
+}
+
+func (*pluginSettingsGoGenerator) JennyName() string {
+	return "pluginSettingsGoGenerator"
+}
+
+// pluginSettingsTSGenerator generates TypeScript types for the plugin's settings, for use in the plugin's config page.
+type pluginSettingsTSGenerator struct {
+	settings *PluginSettings
+}
+
+func (g *pluginSettingsTSGenerator) Generate(...kindsys.Custom) (*codejen.File, error) {
+	md, err := pluginSettingsMetadata(g.settings)
+	if err != nil {
+		return nil, err
+	}
+	b := bytes.Buffer{}
+	if err := templates.WritePluginSettingsTypeScript(md, &b); err != nil {
+		return nil, err
+	}
+	return codejen.NewFile("plugin_settings_types.gen.ts", b.Bytes(), g), nil
+}
+
+func (*pluginSettingsTSGenerator) JennyName() string {
+	return "pluginSettingsTSGenerator"
+}
+
+func pluginSettingsMetadata(settings *PluginSettings) (templates.BackendPluginSettingsMetadata, error) {
+	if settings == nil {
+		settings = DefaultPluginSettings()
+	}
+	md := templates.BackendPluginSettingsMetadata{
+		JSONData:       make([]templates.PluginSettingsField, 0, len(settings.JSONData)),
+		SecureJSONData: make([]templates.PluginSettingsField, 0, len(settings.SecureJSONData)),
+	}
+	for _, f := range settings.JSONData {
+		field, err := pluginSettingsFieldMetadata(f)
+		if err != nil {
+			return md, fmt.Errorf("jsonData field %s: %w", f.Name, err)
+		}
+		md.JSONData = append(md.JSONData, field)
+	}
+	for _, f := range settings.SecureJSONData {
+		field, err := pluginSettingsFieldMetadata(f)
+		if err != nil {
+			return md, fmt.Errorf("secureJsonData field %s: %w", f.Name, err)
+		}
+		md.SecureJSONData = append(md.SecureJSONData, field)
+	}
+	return md, nil
+}
+
+var tsIdentifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+func pluginSettingsFieldMetadata(f PluginSettingsField) (templates.PluginSettingsField, error) {
+	field := templates.PluginSettingsField{
+		JSONName: f.Name,
+		Comment:  f.Comment,
+		Required: f.Required,
+		GoName:   goFieldName(f.Name),
+		TSName:   f.Name,
+	}
+	if !tsIdentifierRegex.MatchString(f.Name) {
+		field.TSName = tsLiteral(f.Name)
+	}
+
+	switch f.Type {
+	case PluginSettingsFieldTypeString:
+		field.GoType, field.GoZero, field.TSType = "string", `""`, "string"
+	case PluginSettingsFieldTypeInt:
+		field.GoType, field.GoZero, field.TSType = "int64", "0", "number"
+	case PluginSettingsFieldTypeNumber:
+		field.GoType, field.GoZero, field.TSType = "float64", "0", "number"
+	case PluginSettingsFieldTypeBool:
+		field.GoType, field.GoZero, field.TSType = "bool", "false", "boolean"
+	default:
+		return field, fmt.Errorf("unsupported type %s", f.Type)
+	}
+
+	if f.Default != nil {
+		field.GoDefault = goLiteral(f.Default)
+		field.TSDefault = tsLiteral(f.Default)
+	}
+
+	if len(f.Enum) > 0 {
+		tsTypes := make([]string, 0, len(f.Enum))
+		for _, v := range f.Enum {
+			field.GoEnum = append(field.GoEnum, goLiteral(v))
+			tsTypes = append(tsTypes, tsLiteral(v))
+		}
+		field.TSType = strings.Join(tsTypes, " | ")
+		field.EnumDescription = strings.Join(tsTypes, ", ")
+	}
+
+	return field, nil
+}
+
+// goFieldName converts a settings key such as `api_url` or `apiUrl` to an exported go field name such as `ApiUrl`
+func goFieldName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	for i, p := range parts {
+		parts[i] = typeNameFromKey(p)
+	}
+	goName := strings.Join(parts, "")
+	if goName == "" || (goName[0] >= '0' && goName[0] <= '9') {
+		goName = "Field" + goName
+	}
+	return goName
+}
+
+func goLiteral(v any) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// tsLiteral returns the TypeScript literal of v, using single quotes for strings (like cuetsy)
+func tsLiteral(v any) string {
+	if s, ok := v.(string); ok {
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
@@ -0,0 +1,103 @@
+package codegen
+
+import (
+	"os"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/grafana/thema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomKindParser_PluginSettings(t *testing.T) {
+	parser, err := NewCustomKindParser(thema.NewRuntime(cuecontext.New()), os.DirFS(testCueDir))
+	require.Nil(t, err)
+	settings, err := parser.PluginSettings()
+	require.Nil(t, err)
+	assert.Equal(t, &PluginSettings{
+		JSONData: []PluginSettingsField{{
+			Name:     "apiUrl",
+			Comment:  "URL of the external API",
+			Type:     PluginSettingsFieldTypeString,
+			Required: true,
+		}, {
+			Name:    "timeoutSeconds",
+			Comment: "Request timeout, in seconds",
+			Type:    PluginSettingsFieldTypeInt,
+			Default: 30,
+		}, {
+			Name:    "mode",
+			Type:    PluginSettingsFieldTypeString,
+			Default: "safe",
+			Enum:    []any{"fast", "safe"},
+		}, {
+			Name: "ratio",
+			Type: PluginSettingsFieldTypeNumber,
+		}, {
+			Name:    "debug",
+			Type:    PluginSettingsFieldTypeBool,
+			Default: false,
+		}, {
+			Name: "log-level",
+			Type: PluginSettingsFieldTypeString,
+			Enum: []any{"debug", "info"},
+		}},
+		SecureJSONData: []PluginSettingsField{{
+			Name:     "apiKey",
+			Type:     PluginSettingsFieldTypeString,
+			Required: true,
+		}, {
+			Name:    "password",
+			Comment: "Password for basic authentication",
+			Type:    PluginSettingsFieldTypeString,
+		}, {
+			Name:    "region",
+			Type:    PluginSettingsFieldTypeString,
+			Default: "us",
+			Enum:    []any{"us", "eu"},
+		}},
+	}, settings)
+
+	// Settings are not kinds
+	selectors, err := parser.ListAllMainSelectors()
+	require.Nil(t, err)
+	assert.NotContains(t, selectors, PluginSettingsSelector)
+}
+
+func TestPluginSecureGenerator_Generate(t *testing.T) {
+	parser, err := NewCustomKindParser(thema.NewRuntime(cuecontext.New()), os.DirFS(testCueDir))
+	require.Nil(t, err)
+	settings, err := parser.PluginSettings()
+	require.Nil(t, err)
+	files, err := parser.Generate(PluginSecureGenerator(settings), "customKind")
+	require.Nil(t, err)
+	// Check number of files generated (middleware, retriever, and settings)
+	assert.Len(t, files, 3)
+	// Check content against the golden files
+	compareToGolden(t, files, "")
+}
+
+func TestPluginSettingsGoGenerator_Generate(t *testing.T) {
+	parser, err := NewCustomKindParser(thema.NewRuntime(cuecontext.New()), os.DirFS(testCueDir))
+	require.Nil(t, err)
+	settings, err := parser.PluginSettings()
+	require.Nil(t, err)
+	files, err := parser.Generate(PluginSettingsGoGenerator(settings), "customKind")
+	require.Nil(t, err)
+	// Only the generated settings.go, and not the boilerplate which can be edited
+	require.Len(t, files, 1)
+	assert.Equal(t, "plugin/secure/settings.go", files[0].RelativePath)
+	compareToGolden(t, files, "")
+}
+
+func TestPluginSettingsTSGenerator_Generate(t *testing.T) {
+	parser, err := NewCustomKindParser(thema.NewRuntime(cuecontext.New()), os.DirFS(testCueDir))
+	require.Nil(t, err)
+	settings, err := parser.PluginSettings()
+	require.Nil(t, err)
+	files, err := parser.Generate(PluginSettingsTypeScriptGenerator(settings), "customKind")
+	require.Nil(t, err)
+	assert.Len(t, files, 1)
+	compareToGolden(t, files, "")
+}
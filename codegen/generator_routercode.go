@@ -70,16 +70,3 @@ func (h *routerHandlerCodeGenerator) Generate(decl kindsys.Custom) (*codejen.Fil
 func (*routerHandlerCodeGenerator) JennyName() string {
 	return "routerHandlerCodeGenerator"
 }
-
-type simpleFileReturnGenerator struct {
-	file codejen.File
-}
-
-func (s *simpleFileReturnGenerator) Generate(...kindsys.Custom) (*codejen.File, error) {
-	s.file.From = []codejen.NamedJenny{s}
-	return &s.file, nil
-}
-
-func (s *simpleFileReturnGenerator) JennyName() string {
-	return fmt.Sprintf("%s_generator", s.file.RelativePath)
-}
@@ -2,8 +2,11 @@ package codegen
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"go/format"
+	"strconv"
+	"strings"
 
 	"github.com/grafana/codejen"
 
@@ -26,14 +29,29 @@ func (s *schemaGenerator) Generate(decl kindsys.Custom) (*codejen.File, error) {
 			decl.Def().Properties.CRD.Scope, resource.ClusterScope, resource.NamespacedScope)
 	}
 
+	// The OpenAPI schema of the objects is the openAPIV3Schema of the current version in the CRD
+	sch, err := decl.Lineage().Schema(meta.CurrentVersion)
+	if err != nil {
+		return nil, err
+	}
+	crdVersion, err := schemaToCRDSpecVersion(sch, versionString(meta.CurrentVersion), true)
+	if err != nil {
+		return nil, err
+	}
+	openAPI, err := json.MarshalIndent(crdVersion.Schema["openAPIV3Schema"], "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
 	b := bytes.Buffer{}
-	err := templates.WriteSchema(templates.SchemaMetadata{
-		Package: meta.MachineName,
-		Group:   decl.Def().Properties.CRD.Group,
-		Version: versionString(meta.CurrentVersion),
-		Kind:    meta.Name,
-		Plural:  meta.PluralMachineName,
-		Scope:   decl.Def().Properties.CRD.Scope,
+	err = templates.WriteSchema(templates.SchemaMetadata{
+		Package:       meta.MachineName,
+		Group:         decl.Def().Properties.CRD.Group,
+		Version:       versionString(meta.CurrentVersion),
+		Kind:          meta.Name,
+		Plural:        meta.PluralMachineName,
+		Scope:         decl.Def().Properties.CRD.Scope,
+		OpenAPISchema: goStringLiteral(string(openAPI)),
 	}, &b)
 	if err != nil {
 		return nil, err
@@ -44,3 +62,11 @@ func (s *schemaGenerator) Generate(decl kindsys.Custom) (*codejen.File, error) {
 	}
 	return codejen.NewFile(fmt.Sprintf("%s/%s_schema_gen.go", meta.MachineName, meta.MachineName), formatted, s), nil
 }
+
+// goStringLiteral returns s as a Go string literal, using a raw string literal unless s contains a backtick
+func goStringLiteral(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
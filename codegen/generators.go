@@ -3,7 +3,7 @@ package codegen
 import (
 	"github.com/grafana/codejen"
 
-	"github.com/grafana/grafana-app-sdk/codegen/templates"
+	"github.com/grafana/grafana-app-sdk/k8s"
 	"github.com/grafana/grafana-app-sdk/kindsys"
 )
 
@@ -12,10 +12,38 @@ const (
 	CustomTargetModel    = "model"
 )
 
-// CRDGenerator returns a Generator which will create a CRD file
+// CRDGenerator returns a Generator which will create a CRD file.
+// CRDs of kinds with more than one version use the conversion webhook served at /convert
+// by the "operator" service in the "default" namespace (see CRDGeneratorWithConversionWebhook).
 func CRDGenerator(outputEncoder CRDOutputEncoder, outputExtension string) Generator {
+	return CRDGeneratorWithConversionWebhook(outputEncoder, outputExtension, k8s.CustomResourceDefinitionWebhookClientConfig{
+		Service: &k8s.CustomResourceDefinitionServiceReference{
+			Namespace: "default",
+			Name:      "operator",
+			Path:      "/convert",
+		},
+	})
+}
+
+// CRDGeneratorWithConversionWebhook returns a Generator which will create a CRD file.
+// CRDs of kinds with more than one version use the Webhook conversion strategy with the provided conversionWebhook,
+// which should be a k8s.WebhookServer with a k8s.ThemaConverter for the kind (as in the generated operator).
+func CRDGeneratorWithConversionWebhook(outputEncoder CRDOutputEncoder, outputExtension string,
+	conversionWebhook k8s.CustomResourceDefinitionWebhookClientConfig) Generator {
 	g := codejen.JennyListWithNamer(namerFunc)
 	g.Append(&crdGenerator{
+		outputExtension:   outputExtension,
+		outputEncoder:     outputEncoder,
+		conversionWebhook: conversionWebhook,
+	})
+	return g
+}
+
+// OpenAPIGenerator returns a Generator which will create a single OpenAPI document describing the routes
+// that a router.ResourceGroupRouter exposes for all the provided kinds
+func OpenAPIGenerator(outputEncoder OpenAPIOutputEncoder, outputExtension string) Generator {
+	g := codejen.JennyListWithNamer(namerFunc)
+	g.Append(&openAPIGenerator{
 		outputExtension: outputExtension,
 		outputEncoder:   outputEncoder,
 	})
@@ -42,32 +70,17 @@ func ModelsGenerator() Generator {
 	return g
 }
 
-// BackendPluginGenerator returns a Generator which will produce boilerplate backend plugin code
-func BackendPluginGenerator(projectRepo, generatedAPIPath string) Generator {
-	pluginSecurePkgFiles, _ := templates.GetBackendPluginSecurePackageFiles()
-
+// BackendPluginGenerator returns a Generator which will produce boilerplate backend plugin code.
+// The plugin's `secure` package is generated from settings (see PluginSettingsSelector),
+// or from DefaultPluginSettings if settings is nil.
+func BackendPluginGenerator(projectRepo, generatedAPIPath string, settings *PluginSettings) Generator {
 	g := codejen.JennyListWithNamer(namerFunc)
 	g.Append(&routerHandlerCodeGenerator{
 		projectRepo:    projectRepo,
 		apiCodegenPath: generatedAPIPath,
 	},
-		&simpleFileReturnGenerator{
-			file: codejen.File{
-				RelativePath: "plugin/secure/data.go",
-				Data:         pluginSecurePkgFiles["data.go"],
-			},
-		},
-		&simpleFileReturnGenerator{
-			file: codejen.File{
-				RelativePath: "plugin/secure/middleware.go",
-				Data:         pluginSecurePkgFiles["middleware.go"],
-			},
-		},
-		&simpleFileReturnGenerator{
-			file: codejen.File{
-				RelativePath: "plugin/secure/retriever.go",
-				Data:         pluginSecurePkgFiles["retriever.go"],
-			},
+		&pluginSecureGenerator{
+			settings: settings,
 		},
 		&routerCodeGenerator{
 			projectRepo: projectRepo,
@@ -79,6 +92,42 @@ func BackendPluginGenerator(projectRepo, generatedAPIPath string) Generator {
 	return g
 }
 
+// PluginSecureGenerator returns a Generator which will produce only the `secure` package of a backend plugin
+// from settings (see PluginSettingsSelector), with typed and validated jsonData and secureJsonData,
+// and a router middleware which parses them into the request context.
+// Only settings.go is generated code, the other files are boilerplate which can be edited
+// (use PluginSettingsGoGenerator to re-generate settings.go alone).
+// It ignores the kinds passed to Generate.
+func PluginSecureGenerator(settings *PluginSettings) Generator {
+	g := codejen.JennyListWithNamer(namerFunc)
+	g.Append(&pluginSecureGenerator{
+		settings: settings,
+	})
+	return g
+}
+
+// PluginSettingsGoGenerator returns a Generator which will produce only the settings.go file of the `secure` package
+// of a backend plugin from settings (see PluginSettingsSelector), with the Data and Settings types for secureJsonData
+// and jsonData, and their parsing and validation. It ignores the kinds passed to Generate.
+func PluginSettingsGoGenerator(settings *PluginSettings) Generator {
+	g := codejen.JennyListWithNamer(namerFunc)
+	g.Append(&pluginSettingsGoGenerator{
+		settings: settings,
+	})
+	return g
+}
+
+// PluginSettingsTypeScriptGenerator returns a Generator which will produce TypeScript types for the jsonData
+// and secureJsonData of a plugin from settings (see PluginSettingsSelector), for use in the plugin's config page.
+// It ignores the kinds passed to Generate.
+func PluginSettingsTypeScriptGenerator(settings *PluginSettings) Generator {
+	g := codejen.JennyListWithNamer(namerFunc)
+	g.Append(&pluginSettingsTSGenerator{
+		settings: settings,
+	})
+	return g
+}
+
 // TypeScriptModelsGenerator returns a Generator which generates TypeScript model code
 func TypeScriptModelsGenerator() Generator {
 	g := codejen.JennyListWithNamer(namerFunc)
@@ -0,0 +1,182 @@
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cuelang.org/go/cue"
+)
+
+// PluginSettingsSelector is the selector of the CUE definition which declares the settings of a plugin.
+// It is a definition (rather than a regular field) so that it is not treated as a kind by the CustomKindParser.
+//
+// The definition has a `jsonData` and a `secureJsonData` struct, each of which declares the fields of the plugin's
+// jsonData and secureJsonData, respectively. Fields may be strings, ints, numbers, or bools (secureJsonData fields
+// must be strings), may have a default, and may be restricted to a set of values with a disjunction, for example:
+//
+//	#PluginSettings: {
+//		jsonData: {
+//			// URL of the external API
+//			apiUrl: string
+//			timeoutSeconds?: int | *30
+//			mode: "fast" | *"safe"
+//		}
+//		secureJsonData: {
+//			apiKey: string
+//		}
+//	}
+//
+// Fields which are neither optional nor have a default are required.
+const PluginSettingsSelector = "#PluginSettings"
+
+// Types of PluginSettingsField.Type
+const (
+	PluginSettingsFieldTypeString = "string"
+	PluginSettingsFieldTypeInt    = "int"
+	PluginSettingsFieldTypeNumber = "number"
+	PluginSettingsFieldTypeBool   = "bool"
+)
+
+var pluginSettingsFieldNameRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// PluginSettings are the settings of a plugin, declared in CUE with the PluginSettingsSelector definition.
+type PluginSettings struct {
+	// JSONData are the fields of the plugin's jsonData
+	JSONData []PluginSettingsField
+	// SecureJSONData are the fields of the plugin's secureJsonData
+	SecureJSONData []PluginSettingsField
+}
+
+// PluginSettingsField is a field of the jsonData or secureJsonData of a plugin
+type PluginSettingsField struct {
+	// Name is the key of the field in jsonData or secureJsonData
+	Name string
+	// Comment is the documentation comment of the field
+	Comment string
+	// Type is the type of the field, one of the PluginSettingsFieldType constants
+	Type string
+	// Required is true if the field must be present in the settings
+	Required bool
+	// Default is the default value of the field, or nil if it has no default
+	Default any
+	// Enum contains the allowed values of the field, or is empty if any value of the field's type is allowed
+	Enum []any
+}
+
+// DefaultPluginSettings returns the PluginSettings used by generators when a plugin declares no settings,
+// which contain a single optional `auth_header_content` secureJsonData field.
+func DefaultPluginSettings() *PluginSettings {
+	return &PluginSettings{
+		JSONData: []PluginSettingsField{},
+		SecureJSONData: []PluginSettingsField{{
+			Name: "auth_header_content",
+			Type: PluginSettingsFieldTypeString,
+		}},
+	}
+}
+
+// PluginSettings parses the settings declared with the PluginSettingsSelector definition.
+// If the definition is not present, it returns nil.
+func (g *CustomKindParser) PluginSettings() (*PluginSettings, error) {
+	v := g.root.LookupPath(cue.ParsePath(PluginSettingsSelector))
+	if !v.Exists() {
+		return nil, nil
+	}
+	if v.Err() != nil {
+		return nil, fmt.Errorf("%s: %w", PluginSettingsSelector, v.Err())
+	}
+
+	jsonData, err := parsePluginSettingsFields(v, "jsonData", false)
+	if err != nil {
+		return nil, err
+	}
+	secureJSONData, err := parsePluginSettingsFields(v, "secureJsonData", true)
+	if err != nil {
+		return nil, err
+	}
+	return &PluginSettings{
+		JSONData:       jsonData,
+		SecureJSONData: secureJSONData,
+	}, nil
+}
+
+func parsePluginSettingsFields(settings cue.Value, name string, secure bool) ([]PluginSettingsField, error) {
+	fields := make([]PluginSettingsField, 0)
+	v := settings.LookupPath(cue.MakePath(cue.Str(name)))
+	if !v.Exists() {
+		return fields, nil
+	}
+	if v.IncompleteKind() != cue.StructKind {
+		return nil, fmt.Errorf("%s.%s: must be a struct", PluginSettingsSelector, name)
+	}
+
+	i, err := v.Fields(cue.Optional(true), cue.Docs(true))
+	if err != nil {
+		return nil, fmt.Errorf("%s.%s: %w", PluginSettingsSelector, name, err)
+	}
+	for i.Next() {
+		path := fmt.Sprintf("%s.%s.%s", PluginSettingsSelector, name, i.Label())
+		field, err := parsePluginSettingsField(i.Label(), i.Value(), i.IsOptional())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if secure && field.Type != PluginSettingsFieldTypeString {
+			return nil, fmt.Errorf("%s: secureJsonData fields must be strings", path)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func parsePluginSettingsField(name string, v cue.Value, optional bool) (PluginSettingsField, error) {
+	field := PluginSettingsField{
+		Name: name,
+	}
+	if !pluginSettingsFieldNameRegex.MatchString(name) {
+		return field, fmt.Errorf("invalid name, must only contain letters, digits, '_', '-', and '.'")
+	}
+
+	switch v.IncompleteKind() {
+	case cue.StringKind:
+		field.Type = PluginSettingsFieldTypeString
+	case cue.IntKind:
+		field.Type = PluginSettingsFieldTypeInt
+	case cue.FloatKind, cue.NumberKind:
+		field.Type = PluginSettingsFieldTypeNumber
+	case cue.BoolKind:
+		field.Type = PluginSettingsFieldTypeBool
+	default:
+		return field, fmt.Errorf("unsupported type %s, must be a string, int, number, or bool", v.IncompleteKind())
+	}
+
+	comments := make([]string, 0)
+	for _, doc := range v.Doc() {
+		comments = append(comments, strings.TrimSpace(doc.Text()))
+	}
+	field.Comment = strings.Join(comments, "\n")
+
+	if def, ok := v.Default(); ok {
+		if err := def.Decode(&field.Default); err != nil {
+			return field, fmt.Errorf("invalid default: %w", err)
+		}
+	}
+	field.Required = !optional && field.Default == nil
+
+	// A disjunction of concrete values (such as `"a" | "b"`) restricts the field to those values
+	if op, args := v.Expr(); op == cue.OrOp {
+		for _, arg := range args {
+			if !arg.IsConcrete() {
+				field.Enum = nil
+				break
+			}
+			var val any
+			if err := arg.Decode(&val); err != nil {
+				return field, fmt.Errorf("invalid value: %w", err)
+			}
+			field.Enum = append(field.Enum, val)
+		}
+	}
+
+	return field, nil
+}
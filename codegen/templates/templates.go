@@ -1,8 +1,10 @@
 package templates
 
 import (
+	"bytes"
 	"embed"
 	"io"
+	"strings"
 	"text/template"
 
 	"github.com/grafana/grafana-app-sdk/kindsys"
@@ -22,6 +24,11 @@ var (
 	templateBackendPluginModelsHandler, _   = template.ParseFS(templates, "plugin/handler_models.tmpl")
 	templateBackendMain, _                  = template.ParseFS(templates, "plugin/main.tmpl")
 
+	templateSecureSettings, _   = template.New("settings.tmpl").Funcs(secureFuncs).ParseFS(templates, "secure/settings.tmpl")
+	templateSecureMiddleware, _ = template.ParseFS(templates, "secure/middleware.tmpl")
+	templateSecureRetriever, _  = template.ParseFS(templates, "secure/retriever.tmpl")
+	templateSettingsTS, _       = template.ParseFS(templates, "secure/settings.ts.tmpl")
+
 	templateWatcher, _            = template.ParseFS(templates, "operator/watcher.tmpl")
 	templateOperatorKubeconfig, _ = template.ParseFS(templates, "operator/kubeconfig.tmpl")
 	templateOperatorMain, _       = template.ParseFS(templates, "operator/main.tmpl")
@@ -60,6 +67,8 @@ type SchemaMetadata struct {
 	Kind    string
 	Plural  string
 	Scope   string
+	// OpenAPISchema is a Go string literal of the JSON OpenAPI schema of the objects
+	OpenAPISchema string
 }
 
 // WriteSchema executes the Resource Schema template, and writes out the generated go code to out
@@ -137,26 +146,94 @@ func WriteBackendPluginMain(metadata BackendPluginRouterTemplateMetadata, out io
 	return templateBackendMain.Execute(out, metadata)
 }
 
-// GetBackendPluginSecurePackageFiles returns go files for the `secure` package in the backend plugin, as a map of
-// <filename> (without "secure" in path) => contents
-func GetBackendPluginSecurePackageFiles() (map[string][]byte, error) {
-	dataF, err := templates.ReadFile("secure/data.tmpl")
-	if err != nil {
-		return nil, err
-	}
-	middlewareF, err := templates.ReadFile("secure/middleware.tmpl")
-	if err != nil {
-		return nil, err
+// BackendPluginSettingsMetadata is the metadata required by the backend plugin `secure` package templates
+// and the plugin settings TypeScript template
+type BackendPluginSettingsMetadata struct {
+	JSONData       []PluginSettingsField
+	SecureJSONData []PluginSettingsField
+}
+
+// PluginSettingsField is a jsonData or secureJsonData field used in templates
+type PluginSettingsField struct {
+	// JSONName is the key of the field in jsonData or secureJsonData
+	JSONName string
+	// Comment is the documentation comment of the field, which may have multiple lines
+	Comment  string
+	Required bool
+	// GoName is the name of the field in the generated go struct
+	GoName string
+	// GoType is the go type of the field
+	GoType string
+	// GoZero is the go literal of the zero value of GoType
+	GoZero string
+	// GoDefault is the go literal of the default value of the field, or empty if it has no default
+	GoDefault string
+	// GoEnum are the go literals of the allowed values of the field, or empty if any value is allowed
+	GoEnum []string
+	// EnumDescription is a human-readable list of the allowed values of the field, used in error messages
+	EnumDescription string
+	// TSName is the name of the field in the generated TypeScript interface
+	TSName string
+	// TSType is the TypeScript type of the field
+	TSType string
+	// TSDefault is the TypeScript literal of the default value of the field, or empty if it has no default
+	TSDefault string
+}
+
+// CommentLines returns the lines of the field's Comment
+func (f PluginSettingsField) CommentLines() []string {
+	if f.Comment == "" {
+		return nil
 	}
-	retrieverF, err := templates.ReadFile("secure/retriever.tmpl")
-	if err != nil {
-		return nil, err
+	return strings.Split(f.Comment, "\n")
+}
+
+// AllowZero returns true if the field can have its zero value regardless of the allowed values,
+// which is the case if it is optional and has no default.
+func (f PluginSettingsField) AllowZero() bool {
+	return !f.Required && f.GoDefault == ""
+}
+
+var secureFuncs = template.FuncMap{
+	"join": strings.Join,
+	"enumContext": func(source string, field PluginSettingsField) map[string]any {
+		return map[string]any{
+			"Source": source,
+			"Field":  field,
+		}
+	},
+}
+
+// GetBackendPluginSecurePackageFiles executes the templates for the `secure` package in the backend plugin,
+// and returns the generated go files as a map of <filename> (without "secure" in path) => contents.
+// Only settings.go is generated from the plugin's settings (see WritePluginSettingsGo),
+// the other files are boilerplate which can be edited.
+func GetBackendPluginSecurePackageFiles(metadata BackendPluginSettingsMetadata) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for name, tmpl := range map[string]*template.Template{
+		"settings.go":   templateSecureSettings,
+		"middleware.go": templateSecureMiddleware,
+		"retriever.go":  templateSecureRetriever,
+	} {
+		b := bytes.Buffer{}
+		if err := tmpl.Execute(&b, metadata); err != nil {
+			return nil, err
+		}
+		files[name] = b.Bytes()
 	}
-	return map[string][]byte{
-		"data.go":       dataF,
-		"middleware.go": middlewareF,
-		"retriever.go":  retrieverF,
-	}, nil
+	return files, nil
+}
+
+// WritePluginSettingsGo executes the `secure` package settings template, and writes out the generated go code
+// with the Data and Settings types of the plugin's secureJsonData and jsonData to out
+func WritePluginSettingsGo(metadata BackendPluginSettingsMetadata, out io.Writer) error {
+	return templateSecureSettings.Execute(out, metadata)
+}
+
+// WritePluginSettingsTypeScript executes the plugin settings TypeScript template,
+// and writes out the generated TypeScript code to out
+func WritePluginSettingsTypeScript(metadata BackendPluginSettingsMetadata, out io.Writer) error {
+	return templateSettingsTS.Execute(out, metadata)
 }
 
 type WatcherMetadata struct {
@@ -0,0 +1,20 @@
+package cue
+
+#PluginSettings: {
+	jsonData: {
+		// URL of the external API
+		apiUrl: string
+		// Request timeout, in seconds
+		timeoutSeconds?: int | *30
+		mode: "fast" | *"safe"
+		ratio?: number
+		debug: bool | *false
+		"log-level"?: "debug" | "info"
+	}
+	secureJsonData: {
+		apiKey: string
+		// Password for basic authentication
+		password?: string
+		region?: "us" | "eu" | *"us"
+	}
+}
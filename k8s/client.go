@@ -183,7 +183,7 @@ func (c *Client) UpdateInto(ctx context.Context, identifier resource.Identifier,
 	return c.client.update(ctx, c.schema.Plural(), obj, into, options)
 }
 
-// Patch performs a JSON Patch on the provided resource, and returns the updated object
+// Patch performs a JSON Patch or JSON Merge Patch on the provided resource, and returns the updated object
 func (c *Client) Patch(ctx context.Context, identifier resource.Identifier, patch resource.PatchRequest,
 	options resource.PatchOptions) (resource.Object, error) {
 	into := c.schema.ZeroValue()
@@ -194,12 +194,47 @@ func (c *Client) Patch(ctx context.Context, identifier resource.Identifier, patc
 	return into, nil
 }
 
-// PatchInto performs a JSON Patch on the provided resource, and marshals the updated version into the `into` field
+// PatchInto performs a JSON Patch or JSON Merge Patch on the provided resource, and marshals the updated version into the `into` field
 func (c *Client) PatchInto(ctx context.Context, identifier resource.Identifier, patch resource.PatchRequest,
 	options resource.PatchOptions, into resource.Object) error {
 	return c.client.patch(ctx, identifier, c.schema.Plural(), patch, into, options)
 }
 
+// Apply performs a server-side apply of the provided resource, and returns the resulting object.
+// Conflicts with fields owned by other field managers are returned as a *resource.ApplyConflictError.
+func (c *Client) Apply(ctx context.Context, identifier resource.Identifier, obj resource.Object,
+	options resource.ApplyOptions) (resource.Object, error) {
+	into := c.schema.ZeroValue()
+	err := c.ApplyInto(ctx, identifier, obj, options, into)
+	if err != nil {
+		return nil, err
+	}
+	return into, nil
+}
+
+// ApplyInto performs a server-side apply of the provided resource, and marshals the resulting object into `into`.
+// Conflicts with fields owned by other field managers are returned as a *resource.ApplyConflictError.
+func (c *Client) ApplyInto(ctx context.Context, identifier resource.Identifier, obj resource.Object,
+	options resource.ApplyOptions, into resource.Object) error {
+	if obj == nil {
+		return fmt.Errorf("obj cannot be nil")
+	}
+	if into == nil {
+		return fmt.Errorf("into cannot be nil")
+	}
+	if options.FieldManager == "" {
+		return fmt.Errorf("options.FieldManager is required")
+	}
+	obj.SetStaticMetadata(resource.StaticMetadata{
+		Namespace: identifier.Namespace,
+		Name:      identifier.Name,
+		Group:     c.schema.Group(),
+		Version:   c.schema.Version(),
+		Kind:      c.schema.Kind(),
+	})
+	return c.client.apply(ctx, identifier, c.schema.Plural(), obj, into, options)
+}
+
 // Delete deletes the specified resource
 func (c *Client) Delete(ctx context.Context, identifier resource.Identifier) error {
 	return c.client.delete(ctx, identifier, c.schema.Plural())
@@ -493,6 +493,107 @@ func TestClient_UpdateInto(t *testing.T) {
 	})
 }
 
+func TestClient_Apply(t *testing.T) {
+	client, server := getClientTestSetup(testSchema)
+	defer server.Close()
+	id := resource.Identifier{
+		Namespace: "ns",
+		Name:      "testo",
+	}
+	ctx := context.TODO()
+
+	t.Run("no field manager", func(t *testing.T) {
+		server.responseFunc = func(writer http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "HTTP request should not be made without a field manager")
+		}
+
+		resp, err := client.Apply(ctx, id, getTestObject(), resource.ApplyOptions{})
+		assert.Nil(t, resp)
+		assert.Equal(t, fmt.Errorf("options.FieldManager is required"), err)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		server.responseFunc = func(writer http.ResponseWriter, r *http.Request) {
+			writer.WriteHeader(http.StatusConflict)
+			writer.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","message":"Apply failed with 1 conflict: conflict with \"other\" using group/version: .spec.field","reason":"Conflict","details":{"causes":[{"reason":"FieldManagerConflict","message":"conflict with \"other\" using group/version","field":".spec.field"}]},"code":409}`))
+		}
+
+		resp, err := client.Apply(ctx, id, getTestObject(), resource.ApplyOptions{FieldManager: "test"})
+		assert.Nil(t, resp)
+		require.NotNil(t, err)
+		cast, ok := err.(*resource.ApplyConflictError)
+		require.True(t, ok)
+		assert.Equal(t, []resource.ApplyConflict{{Manager: "other", Field: ".spec.field"}}, cast.Conflicts)
+		assert.Equal(t, http.StatusConflict, cast.StatusCode())
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server.responseFunc = func(writer http.ResponseWriter, r *http.Request) {
+			writer.WriteHeader(http.StatusBadRequest)
+		}
+
+		resp, err := client.Apply(ctx, id, getTestObject(), resource.ApplyOptions{FieldManager: "test"})
+		assert.Nil(t, resp)
+		require.NotNil(t, err)
+		cast, ok := err.(*ServerResponseError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, cast.StatusCode())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		server.responseFunc = func(writer http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "test", r.URL.Query().Get("fieldManager"))
+			assert.Equal(t, "true", r.URL.Query().Get("force"))
+			body, err := io.ReadAll(r.Body)
+			require.Nil(t, err)
+			posted := submittedObj{}
+			require.Nil(t, json.Unmarshal(body, &posted))
+			assert.Equal(t, id.Namespace, posted.ObjectMetadata.Namespace)
+			assert.Equal(t, id.Name, posted.ObjectMetadata.Name)
+			// Server-managed metadata should not be submitted in an apply
+			assert.Empty(t, posted.ObjectMetadata.ResourceVersion)
+			assert.Equal(t, responseObj.Spec, posted.Spec)
+			// Neither should fields which aren't set, as the applier would own them
+			assert.Empty(t, posted.ObjectMetadata.Annotations)
+			fields := make(map[string]any)
+			require.Nil(t, json.Unmarshal(body, &fields))
+			assert.NotContains(t, fields, "status")
+			writer.Write(responseBytes)
+			writer.WriteHeader(http.StatusOK)
+			assert.Equal(t, fmt.Sprintf("/namespaces/%s/%s/%s", id.Namespace, testSchema.Plural(), id.Name), r.URL.Path)
+		}
+
+		resp, err := client.Apply(ctx, id, getTestObject(), resource.ApplyOptions{
+			FieldManager: "test",
+			Force:        true,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, responseObj.StaticMetadata(), resp.StaticMetadata())
+		assert.Equal(t, responseObj.CommonMetadata(), resp.CommonMetadata())
+		assert.Equal(t, responseObj.SpecObject(), resp.SpecObject())
+	})
+
+	t.Run("subresource", func(t *testing.T) {
+		obj := getTestObject()
+		obj.SubresourceMap["status"] = map[string]any{"state": "ok"}
+		server.responseFunc = func(writer http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, fmt.Sprintf("/namespaces/%s/%s/%s/status", id.Namespace, testSchema.Plural(), id.Name), r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			require.Nil(t, err)
+			// Only the identifying metadata and the status should be applied
+			assert.JSONEq(t, `{"apiVersion":"group/version","kind":"test","metadata":{"name":"testo","namespace":"ns"},"status":{"state":"ok"}}`, string(body))
+			writer.Write(responseBytes)
+		}
+
+		_, err := client.Apply(ctx, id, obj, resource.ApplyOptions{
+			FieldManager: "test",
+			Subresource:  "status",
+		})
+		assert.Nil(t, err)
+	})
+}
+
 func TestClient_Delete(t *testing.T) {
 	client, server := getClientTestSetup(testSchema)
 	defer server.Close()
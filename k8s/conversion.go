@@ -0,0 +1,246 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/thema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const errStringNoConverterDefined = "no converter defined for group '%s' and kind '%s'"
+
+// Converter describes a type which can convert a kubernetes kind from one API version to another.
+// Converters are registered with a WebhookServer for a group and kind,
+// and are used to respond to ConversionReview requests from the API server.
+type Converter interface {
+	// Convert converts the object in RawKind to targetAPIVersion (in the form of "<group>/<version>"),
+	// returning the kubernetes JSON bytes of the converted object.
+	Convert(obj RawKind, targetAPIVersion string) ([]byte, error)
+}
+
+// RawKind is a kubernetes object in its raw JSON bytes representation, along with its parsed type information.
+type RawKind struct {
+	Kind       string
+	APIVersion string
+	Group      string
+	Version    string
+	Raw        []byte
+}
+
+// ConversionReview describes a conversion request/response, as sent by the kubernetes API server to a conversion webhook.
+// It mirrors apiextensions.k8s.io/v1 ConversionReview.
+type ConversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	// Request describes the attributes for the conversion request.
+	Request *ConversionRequest `json:"request,omitempty"`
+	// Response describes the attributes for the conversion response.
+	Response *ConversionResponse `json:"response,omitempty"`
+}
+
+// ConversionRequest describes the conversion request parameters of a ConversionReview
+type ConversionRequest struct {
+	// UID is an identifier for the individual request/response, which must be copied to the ConversionResponse.
+	UID types.UID `json:"uid"`
+	// DesiredAPIVersion is the version to convert given objects to, in the form of "<group>/<version>".
+	DesiredAPIVersion string `json:"desiredAPIVersion"`
+	// Objects is the list of objects to convert.
+	Objects []runtime.RawExtension `json:"objects"`
+}
+
+// ConversionResponse describes a conversion response of a ConversionReview
+type ConversionResponse struct {
+	// UID is the UID of the ConversionRequest this is a response to.
+	UID types.UID `json:"uid"`
+	// ConvertedObjects is the list of converted objects, in the same order as the ConversionRequest's Objects.
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	// Result contains the result of conversion. A Status of "Success" indicates all objects were converted.
+	Result metav1.Status `json:"result"`
+}
+
+// ThemaConverter is a Converter which uses the lenses of a kind's thema lineage to convert between versions.
+// Versions are expected to be named as they are in CRDs generated by the SDK, "v<major>-<minor>".
+// Only the spec and status of an object are translated; all other fields are left as-is.
+type ThemaConverter struct {
+	lineage thema.Lineage
+	// The thema runtime's cue context is not safe for concurrent use
+	mux sync.Mutex
+}
+
+// NewThemaConverter creates a new ThemaConverter for the provided lineage
+func NewThemaConverter(lineage thema.Lineage) *ThemaConverter {
+	return &ThemaConverter{
+		lineage: lineage,
+	}
+}
+
+// Convert translates the object's spec and status from its current schema version to the version in targetAPIVersion
+// using the lineage's lenses, and returns the object with the translated spec and status and the new apiVersion.
+// If the translation emits lacunas for the object, it returns a LossyConversionError instead.
+func (t *ThemaConverter) Convert(obj RawKind, targetAPIVersion string) ([]byte, error) {
+	targetGV, err := schema.ParseGroupVersion(targetAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	from, err := parseThemaVersion(obj.Version)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseThemaVersion(targetGV.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	// Preserve all top-level fields, only replacing the ones that are translated
+	fields := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(obj.Raw, &fields); err != nil {
+		return nil, err
+	}
+	kubeObject := k8sObject{}
+	if err = json.Unmarshal(obj.Raw, &kubeObject); err != nil {
+		return nil, err
+	}
+	if from != to {
+		translated, err := t.translate(kubeObject, from, to)
+		if err != nil {
+			return nil, err
+		}
+		fields["spec"] = translated.Spec
+		if len(translated.Status) > 0 {
+			fields["status"] = translated.Status
+		} else {
+			delete(fields, "status")
+		}
+	}
+	fields["apiVersion"], err = json.Marshal(targetAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// LossyConversionError is returned by ThemaConverter.Convert when the lenses between the two versions emit lacunas
+// for the object, as the converted object would not preserve the semantics of the original.
+// Lossy conversions are refused rather than reported as successful.
+type LossyConversionError struct {
+	From    thema.SyntacticVersion
+	To      thema.SyntacticVersion
+	Lacunas []thema.Lacuna
+}
+
+// Error returns the messages of all the lacunas emitted by the translation
+func (l *LossyConversionError) Error() string {
+	messages := make([]string, 0, len(l.Lacunas))
+	for _, lacuna := range l.Lacunas {
+		messages = append(messages, lacuna.Message)
+	}
+	return fmt.Sprintf("translation from %s to %s is lossy: %s", l.From, l.To, strings.Join(messages, "; "))
+}
+
+type themaObjectBody struct {
+	Metadata map[string]any  `json:"metadata"`
+	Spec     json.RawMessage `json:"spec"`
+	Status   json.RawMessage `json:"status,omitempty"`
+}
+
+func (t *ThemaConverter) translate(kubeObject k8sObject, from, to thema.SyntacticVersion) (body themaObjectBody, err error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	// thema panics on invalid translations rather than returning an error
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unable to translate from %s to %s: %v", from, to, r)
+		}
+	}()
+
+	sch, err := t.lineage.Schema(from)
+	if err != nil {
+		return body, err
+	}
+	if _, err = t.lineage.Schema(to); err != nil {
+		return body, err
+	}
+	input, err := json.Marshal(themaObjectBody{
+		Metadata: themaMetadata(kubeObject.ObjectMetadata),
+		Spec:     kubeObject.Spec,
+		Status:   kubeObject.Status,
+	})
+	if err != nil {
+		return body, err
+	}
+	inst, err := sch.Validate(t.lineage.Runtime().Context().CompileBytes(input))
+	if err != nil {
+		return body, err
+	}
+	translated, lacunas := inst.Translate(to)
+	if lacunas != nil && len(lacunas.AsList()) > 0 {
+		return body, &LossyConversionError{
+			From:    from,
+			To:      to,
+			Lacunas: lacunas.AsList(),
+		}
+	}
+	output, err := translated.Underlying().MarshalJSON()
+	if err != nil {
+		return body, err
+	}
+	err = json.Unmarshal(output, &body)
+	return body, err
+}
+
+// themaMetadata converts kubernetes metadata into the metadata required by kind schemas
+func themaMetadata(meta metav1.ObjectMeta) map[string]any {
+	md := make(map[string]any)
+	for k, v := range meta.Annotations {
+		if len(k) > len(annotationPrefix) && k[:len(annotationPrefix)] == annotationPrefix {
+			md[k[len(annotationPrefix):]] = v
+		}
+	}
+	if _, ok := md["updateTimestamp"]; !ok {
+		md["updateTimestamp"] = meta.CreationTimestamp.Format(time.RFC3339Nano)
+	}
+	if _, ok := md["createdBy"]; !ok {
+		md["createdBy"] = ""
+	}
+	if _, ok := md["updatedBy"]; !ok {
+		md["updatedBy"] = ""
+	}
+	md["uid"] = string(meta.UID)
+	md["creationTimestamp"] = meta.CreationTimestamp.Format(time.RFC3339Nano)
+	md["resourceVersion"] = meta.ResourceVersion
+	md["generation"] = meta.Generation
+	md["finalizers"] = make([]string, 0)
+	if meta.Finalizers != nil {
+		md["finalizers"] = meta.Finalizers
+	}
+	md["labels"] = make(map[string]string)
+	if meta.Labels != nil {
+		md["labels"] = meta.Labels
+	}
+	md["extraFields"] = make(map[string]any)
+	return md
+}
+
+// parseThemaVersion parses a kubernetes version string of the format "v<major>-<minor>" into a thema.SyntacticVersion
+func parseThemaVersion(version string) (thema.SyntacticVersion, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), "-")
+	if !strings.HasPrefix(version, "v") || len(parts) != 2 {
+		return thema.SyntacticVersion{}, fmt.Errorf("version '%s' is not of the format v<major>-<minor>", version)
+	}
+	major, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return thema.SyntacticVersion{}, fmt.Errorf("version '%s' is not of the format v<major>-<minor>", version)
+	}
+	minor, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return thema.SyntacticVersion{}, fmt.Errorf("version '%s' is not of the format v<major>-<minor>", version)
+	}
+	return thema.SV(uint(major), uint(minor)), nil
+}
@@ -0,0 +1,222 @@
+package k8s
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/grafana/thema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testConversionLineage = `
+name: "conversiontest"
+schemas: [{
+	version: [0, 0]
+	schema: {
+		metadata: {...}
+		spec: {
+			before: string
+			unchanged: string
+		}
+		status?: {...}
+	}
+}, {
+	version: [1, 0]
+	schema: {
+		metadata: {...}
+		spec: {
+			after: string
+			unchanged: string
+		}
+		status?: {...}
+	}
+}]
+lenses: [{
+	to: [0, 0]
+	from: [1, 0]
+	input: _
+	result: {
+		metadata: input.metadata
+		spec: {
+			before: input.spec.after
+			unchanged: input.spec.unchanged
+		}
+		if input.status != _|_ {
+			status: input.status
+		}
+	}
+	lacunas: []
+}, {
+	to: [1, 0]
+	from: [0, 0]
+	input: _
+	result: {
+		metadata: input.metadata
+		spec: {
+			after: input.spec.before
+			unchanged: input.spec.unchanged
+		}
+		if input.status != _|_ {
+			status: input.status
+		}
+	}
+	lacunas: [{
+		condition: input.spec.before == "lossy"
+		sourceFields: [{path: "spec.before", value: input.spec.before}]
+		targetFields: []
+		message: "before is not representable"
+		type: {name: "LossyFieldMapping", id: 3}
+	}]
+}]
+`
+
+func testLineage(t *testing.T) thema.Lineage {
+	ctx := cuecontext.New()
+	lin, err := thema.BindLineage(ctx.CompileString(testConversionLineage), thema.NewRuntime(ctx))
+	require.Nil(t, err)
+	return lin
+}
+
+func TestThemaConverter_Convert(t *testing.T) {
+	converter := NewThemaConverter(testLineage(t))
+	obj := []byte(`{"apiVersion":"foo/v0-0","kind":"Bar","metadata":{"name":"test","namespace":"default","resourceVersion":"12345","creationTimestamp":"2023-07-06T20:49:10Z"},"spec":{"before":"foo","unchanged":"bar"},"status":{"baz":"qux"}}`)
+
+	t.Run("different version", func(t *testing.T) {
+		converted, err := converter.Convert(RawKind{
+			Kind:       "Bar",
+			APIVersion: "foo/v0-0",
+			Group:      "foo",
+			Version:    "v0-0",
+			Raw:        obj,
+		}, "foo/v1-0")
+		require.Nil(t, err)
+		assert.JSONEq(t, `{"apiVersion":"foo/v1-0","kind":"Bar","metadata":{"name":"test","namespace":"default","resourceVersion":"12345","creationTimestamp":"2023-07-06T20:49:10Z"},"spec":{"after":"foo","unchanged":"bar"},"status":{"baz":"qux"}}`, string(converted))
+	})
+
+	t.Run("same version", func(t *testing.T) {
+		converted, err := converter.Convert(RawKind{
+			Kind:       "Bar",
+			APIVersion: "foo/v0-0",
+			Group:      "foo",
+			Version:    "v0-0",
+			Raw:        obj,
+		}, "foo/v0-0")
+		require.Nil(t, err)
+		assert.JSONEq(t, string(obj), string(converted))
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := converter.Convert(RawKind{
+			Kind:       "Bar",
+			APIVersion: "foo/v0-0",
+			Group:      "foo",
+			Version:    "v0-0",
+			Raw:        obj,
+		}, "foo/v2-0")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("lossy translation", func(t *testing.T) {
+		lossy := bytes.Replace(obj, []byte(`"before":"foo"`), []byte(`"before":"lossy"`), 1)
+		_, err := converter.Convert(RawKind{
+			Kind:       "Bar",
+			APIVersion: "foo/v0-0",
+			Group:      "foo",
+			Version:    "v0-0",
+			Raw:        lossy,
+		}, "foo/v1-0")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("bad version format", func(t *testing.T) {
+		_, err := converter.Convert(RawKind{
+			Kind:       "Bar",
+			APIVersion: "foo/v0-0",
+			Group:      "foo",
+			Version:    "v0-0",
+			Raw:        obj,
+		}, "foo/v1")
+		assert.Equal(t, "version 'v1' is not of the format v<major>-<minor>", err.Error())
+	})
+}
+
+func TestLossyConversionError_Error(t *testing.T) {
+	err := &LossyConversionError{
+		From:    thema.SV(0, 0),
+		To:      thema.SV(1, 0),
+		Lacunas: []thema.Lacuna{{Message: "foo was dropped"}, {Message: "bar is a placeholder"}},
+	}
+	assert.Equal(t, "translation from 0.0 to 1.0 is lossy: foo was dropped; bar is a placeholder", err.Error())
+}
+
+func TestWebhookServer_HandleConvertHTTP(t *testing.T) {
+	conversionRequestBytes := []byte(`{"apiVersion":"apiextensions.k8s.io/v1","kind":"ConversionReview","request":{"uid":"foo","desiredAPIVersion":"foo/v1-0","objects":[{"apiVersion":"foo/v0-0","kind":"Bar","metadata":{"name":"test"},"spec":{"before":"foo","unchanged":"bar"}},{"apiVersion":"foo/v1-0","kind":"Bar","metadata":{"name":"test2"},"spec":{"after":"foo","unchanged":"bar"}}]}}`)
+
+	tests := []struct {
+		name               string
+		serverConfig       WebhookServerConfig
+		reqMethod          string
+		payload            []byte
+		expectedResponse   []byte
+		expectedStatusCode int
+	}{
+		{
+			name:               "HTTP GET",
+			reqMethod:          http.MethodGet,
+			expectedStatusCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:               "no request",
+			reqMethod:          http.MethodPost,
+			payload:            []byte(`{"apiVersion":"apiextensions.k8s.io/v1","kind":"ConversionReview"}`),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "no converter",
+			reqMethod:          http.MethodPost,
+			payload:            conversionRequestBytes,
+			expectedResponse:   []byte(`{"apiVersion":"apiextensions.k8s.io/v1","kind":"ConversionReview","response":{"uid":"foo","convertedObjects":null,"result":{"metadata":{},"status":"Failure","message":"no converter defined for group 'foo' and kind 'Bar'"}}}`),
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "converter",
+			serverConfig: WebhookServerConfig{
+				Converters: map[metav1.GroupKind]Converter{
+					{Group: "foo", Kind: "Bar"}: NewThemaConverter(testLineage(t)),
+				},
+			},
+			reqMethod:          http.MethodPost,
+			payload:            conversionRequestBytes,
+			expectedResponse:   []byte(`{"apiVersion":"apiextensions.k8s.io/v1","kind":"ConversionReview","response":{"uid":"foo","convertedObjects":[{"apiVersion":"foo/v1-0","kind":"Bar","metadata":{"name":"test"},"spec":{"after":"foo","unchanged":"bar"}},{"apiVersion":"foo/v1-0","kind":"Bar","metadata":{"name":"test2"},"spec":{"after":"foo","unchanged":"bar"}}],"result":{"metadata":{},"status":"Success"}}}`),
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := test.serverConfig
+			cfg.TLSConfig = TLSConfig{
+				CertPath: "foo",
+				KeyPath:  "bar",
+			}
+			cfg.Port = 8443
+			srv, err := NewWebhookServer(cfg)
+			require.Nil(t, err)
+			req := httptest.NewRequest(test.reqMethod, "http://localhost/convert", bytes.NewBuffer(test.payload))
+			resp := httptest.NewRecorder()
+			srv.HandleConvertHTTP(resp, req)
+
+			if test.expectedStatusCode == http.StatusOK {
+				assert.JSONEq(t, string(test.expectedResponse), resp.Body.String())
+			} else {
+				assert.Equal(t, test.expectedResponse, resp.Body.Bytes())
+			}
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/rest"
@@ -265,22 +267,41 @@ func (g *groupVersionClient) updateSubresource(ctx context.Context, plural, subr
 
 //nolint:revive,unused
 func (g *groupVersionClient) patch(ctx context.Context, identifier resource.Identifier, plural string,
-	patch resource.PatchRequest, into resource.Object, _ resource.PatchOptions) error {
+	patch resource.PatchRequest, into resource.Object, options resource.PatchOptions) error {
 	ctx, span := GetTracer().Start(ctx, "kubernetes-patch")
 	defer span.End()
-	bytes, err := marshalJSONPatch(patch)
+	var (
+		patchType types.PatchType
+		bytes     []byte
+		err       error
+	)
+	switch patch.Type {
+	case "", resource.PatchTypeJSONPatch:
+		patchType = types.JSONPatchType
+		bytes, err = marshalJSONPatch(patch)
+	case resource.PatchTypeMergePatch:
+		patchType = types.MergePatchType
+		bytes, err = marshalMergePatch(patch, g.config)
+	default:
+		err = fmt.Errorf("unsupported patch type '%s'", patch.Type)
+	}
 	if err != nil {
 		return err
 	}
-	req := g.client.Patch(types.JSONPatchType).Resource(plural).
+	req := g.client.Patch(patchType).Resource(plural).
 		Name(identifier.Name).Body(bytes)
 	if strings.TrimSpace(identifier.Namespace) != "" {
 		req = req.Namespace(identifier.Namespace)
 	}
+	subresource := "spec"
+	if options.Subresource != "" {
+		req = req.SubResource(options.Subresource)
+		subresource = options.Subresource
+	}
 	sc := 0
 	start := time.Now()
 	raw, err := req.Do(ctx).StatusCode(&sc).Raw()
-	g.logRequestDuration(time.Since(start), sc, "PATCH", plural, "spec")
+	g.logRequestDuration(time.Since(start), sc, "PATCH", plural, subresource)
 	span.SetAttributes(
 		attribute.Int("http.response.status_code", sc),
 		attribute.String("http.request.method", http.MethodPatch),
@@ -288,7 +309,7 @@ func (g *groupVersionClient) patch(ctx context.Context, identifier resource.Iden
 		attribute.String("server.port", req.URL().Port()),
 		attribute.String("url.full", req.URL().String()),
 	)
-	g.incRequestCounter(sc, "PATCH", plural, "spec")
+	g.incRequestCounter(sc, "PATCH", plural, subresource)
 	if err != nil {
 		err = parseKubernetesError(bytes, sc, err)
 		span.SetStatus(codes.Error, err.Error())
@@ -302,6 +323,59 @@ func (g *groupVersionClient) patch(ctx context.Context, identifier resource.Iden
 	return nil
 }
 
+func (g *groupVersionClient) apply(ctx context.Context, identifier resource.Identifier, plural string,
+	obj resource.Object, into resource.Object, options resource.ApplyOptions) error {
+	ctx, span := GetTracer().Start(ctx, "kubernetes-apply")
+	defer span.End()
+	bytes, err := marshalApplyJSON(obj, options.Subresource, map[string]string{
+		versionLabel: g.version,
+	}, g.config)
+	if err != nil {
+		span.SetStatus(codes.Error, fmt.Sprintf("error marshaling kubernetes JSON: %s", err.Error()))
+		return err
+	}
+	req := g.client.Patch(types.ApplyPatchType).Resource(plural).
+		Name(identifier.Name).Param("fieldManager", options.FieldManager).Body(bytes)
+	if options.Force {
+		req = req.Param("force", "true")
+	}
+	if strings.TrimSpace(identifier.Namespace) != "" {
+		req = req.Namespace(identifier.Namespace)
+	}
+	subresource := "spec"
+	if options.Subresource != "" {
+		req = req.SubResource(options.Subresource)
+		subresource = options.Subresource
+	}
+	sc := 0
+	start := time.Now()
+	raw, err := req.Do(ctx).StatusCode(&sc).Raw()
+	g.logRequestDuration(time.Since(start), sc, "APPLY", plural, subresource)
+	span.SetAttributes(
+		attribute.Int("http.response.status_code", sc),
+		attribute.String("http.request.method", http.MethodPatch),
+		attribute.String("server.address", req.URL().Hostname()),
+		attribute.String("server.port", req.URL().Port()),
+		attribute.String("url.full", req.URL().String()),
+	)
+	g.incRequestCounter(sc, "APPLY", plural, subresource)
+	if err != nil {
+		if conflictErr := parseApplyConflictError(raw, sc); conflictErr != nil {
+			err = conflictErr
+		} else {
+			err = parseKubernetesError(raw, sc, err)
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	err = rawToObject(raw, into)
+	if err != nil {
+		span.SetStatus(codes.Error, fmt.Sprintf("unable to convert kubernetes response to resource: %s", err.Error()))
+		return err
+	}
+	return nil
+}
+
 func (g *groupVersionClient) delete(ctx context.Context, identifier resource.Identifier, plural string) error {
 	ctx, span := GetTracer().Start(ctx, "kubernetes-delete")
 	defer span.End()
@@ -524,3 +598,38 @@ func parseKubernetesError(responseBytes []byte, statusCode int, err error) error
 	}
 	return err
 }
+
+// applyConflictManagerRegex extracts the manager name from a kubernetes FieldManagerConflict cause message,
+// which is of the format `conflict with "<manager>"[ using <version>]: <field>`
+var applyConflictManagerRegex = regexp.MustCompile(`^conflict with "([^"]*)"`)
+
+// parseApplyConflictError returns a *resource.ApplyConflictError if the response is a 409 Conflict status
+// which contains field manager conflicts, and nil otherwise.
+func parseApplyConflictError(responseBytes []byte, statusCode int) *resource.ApplyConflictError {
+	if statusCode != http.StatusConflict || len(responseBytes) == 0 {
+		return nil
+	}
+	status := metav1.Status{}
+	if err := json.Unmarshal(responseBytes, &status); err != nil || status.Details == nil {
+		return nil
+	}
+	conflicts := make([]resource.ApplyConflict, 0)
+	for _, cause := range status.Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflict := resource.ApplyConflict{
+			Field: cause.Field,
+		}
+		if matches := applyConflictManagerRegex.FindStringSubmatch(cause.Message); len(matches) > 1 {
+			conflict.Manager = matches[1]
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return &resource.ApplyConflictError{
+		Conflicts: conflicts,
+	}
+}
@@ -0,0 +1,22 @@
+package k8s
+
+import (
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// NewLeaseLock returns a leader election lock backed by a coordination.k8s.io Lease with the provided namespace and name,
+// which can be used with operator.Operator.EnableLeaderElection.
+// Identity must be unique for each replica contending for leadership, such as the pod name.
+// The Lease is created if it does not exist, so the kubeConfig must have permissions to get, create, and update Leases.
+func NewLeaseLock(kubeConfig rest.Config, namespace, name, identity string) (resourcelock.Interface, error) {
+	client, err := kubernetes.NewForConfig(&kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	return resourcelock.New(resourcelock.LeasesResourceLock, namespace, name, client.CoreV1(), client.CoordinationV1(),
+		resourcelock.ResourceLockConfig{
+			Identity: identity,
+		})
+}
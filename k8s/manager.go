@@ -47,27 +47,40 @@ func NewManager(cfg rest.Config) (*ResourceManager, error) {
 // WaitForAvailability polls the kubernetes API server every second until it gets a successful response
 // for the Schema's CRD name
 func (m *ResourceManager) WaitForAvailability(ctx context.Context, schema resource.Schema) error {
-	name := fmt.Sprintf("%s.%s", schema.Plural(), schema.Group())
-	sc := 0
 	t := time.NewTicker(time.Second)
 	defer t.Stop()
 	for {
 		select {
 		case <-t.C:
-			err := m.client.Get().Resource("customresourcedefinitions").Name(name).
-				Do(ctx).StatusCode(&sc).Error()
-			if err == nil {
-				return nil
-			}
-			if err != nil && sc != http.StatusNotFound {
+			available, err := m.IsAvailable(ctx, schema)
+			if err != nil {
 				return err
 			}
+			if available {
+				return nil
+			}
 		case <-ctx.Done():
 			return ctx.Err()
 		}
 	}
 }
 
+// IsAvailable checks once whether the kubernetes API server has a CRD for the Schema.
+// It returns false and a nil error if the CRD doesn't exist, and an error if the check itself failed.
+func (m *ResourceManager) IsAvailable(ctx context.Context, schema resource.Schema) (bool, error) {
+	name := fmt.Sprintf("%s.%s", schema.Plural(), schema.Group())
+	sc := 0
+	err := m.client.Get().Resource("customresourcedefinitions").Name(name).
+		Do(ctx).StatusCode(&sc).Error()
+	if err == nil {
+		return true, nil
+	}
+	if sc == http.StatusNotFound {
+		return false, nil
+	}
+	return false, err
+}
+
 // RegisterSchema converts a Schema to a Custom Resource Definition, then attempts to create it in kubernetes.
 // If a CRD already exists for the name, it checks to see if this is a new version and attempts to update the CRD
 // with the new version.
@@ -328,6 +341,43 @@ type CustomResourceDefinitionSpec struct {
 	Versions []CustomResourceDefinitionSpecVersion `json:"versions" yaml:"versions"`
 	Names    CustomResourceDefinitionSpecNames     `json:"names" yaml:"names"`
 	Scope    string                                `json:"scope" yaml:"scope"`
+	// Conversion describes how the API server converts objects between versions.
+	// If nil, the API server uses the "None" strategy, which only changes the apiVersion.
+	Conversion *CustomResourceDefinitionConversion `json:"conversion,omitempty" yaml:"conversion,omitempty"`
+}
+
+// CustomResourceDefinitionConversionStrategyWebhook is the conversion strategy which calls a conversion webhook
+// (such as the /convert endpoint of a WebhookServer) to convert objects between versions
+const CustomResourceDefinitionConversionStrategyWebhook = "Webhook"
+
+// CustomResourceDefinitionConversion is the conversion section of a Custom Resource Definition's spec
+type CustomResourceDefinitionConversion struct {
+	Strategy string                                     `json:"strategy" yaml:"strategy"`
+	Webhook  *CustomResourceDefinitionConversionWebhook `json:"webhook,omitempty" yaml:"webhook,omitempty"`
+}
+
+// CustomResourceDefinitionConversionWebhook describes the webhook called by the API server
+// when the conversion strategy is CustomResourceDefinitionConversionStrategyWebhook
+type CustomResourceDefinitionConversionWebhook struct {
+	ConversionReviewVersions []string                                    `json:"conversionReviewVersions" yaml:"conversionReviewVersions"`
+	ClientConfig             CustomResourceDefinitionWebhookClientConfig `json:"clientConfig" yaml:"clientConfig"`
+}
+
+// CustomResourceDefinitionWebhookClientConfig describes how the API server connects to a webhook.
+// Exactly one of URL and Service should be set.
+type CustomResourceDefinitionWebhookClientConfig struct {
+	URL     string                                    `json:"url,omitempty" yaml:"url,omitempty"`
+	Service *CustomResourceDefinitionServiceReference `json:"service,omitempty" yaml:"service,omitempty"`
+	// CABundle is the base64-encoded PEM CA bundle used to verify the webhook's serving certificate
+	CABundle string `json:"caBundle,omitempty" yaml:"caBundle,omitempty"`
+}
+
+// CustomResourceDefinitionServiceReference is a reference to the kubernetes service which serves a webhook
+type CustomResourceDefinitionServiceReference struct {
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Name      string `json:"name" yaml:"name"`
+	Path      string `json:"path,omitempty" yaml:"path,omitempty"`
+	Port      int    `json:"port,omitempty" yaml:"port,omitempty"`
 }
 
 // CustomResourceDefinitionSpecVersion is the representation of a specific version of a CRD, as part of the overall spec
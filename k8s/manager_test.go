@@ -247,6 +247,40 @@ func TestResourceManager_WaitForAvailability(t *testing.T) {
 	})
 }
 
+func TestResourceManager_IsAvailable(t *testing.T) {
+	manager, server := getTestManagerAndServer()
+	defer server.Close()
+
+	t.Run("unknown error", func(t *testing.T) {
+		server.responseFunc = func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusBadRequest)
+		}
+		available, err := manager.IsAvailable(context.TODO(), testSchema)
+		assert.NotNil(t, err)
+		assert.False(t, available)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		server.responseFunc = func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusNotFound)
+		}
+		available, err := manager.IsAvailable(context.TODO(), testSchema)
+		assert.Nil(t, err)
+		assert.False(t, available)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		server.responseFunc = func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, fmt.Sprintf("/customresourcedefinitions/%s.%s", testSchema.Plural(), testSchema.Group()), request.URL.Path)
+			def, _ := json.Marshal(CustomResourceDefinition{})
+			writer.Write(def)
+		}
+		available, err := manager.IsAvailable(context.TODO(), testSchema)
+		assert.Nil(t, err)
+		assert.True(t, available)
+	})
+}
+
 func getTestManagerAndServer() (*ResourceManager, *testServer) {
 	s := testServer{}
 	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
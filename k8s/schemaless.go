@@ -150,7 +150,7 @@ func (s *SchemalessClient) Update(ctx context.Context, identifier resource.FullI
 	return client.update(ctx, s.getPlural(identifier), obj, into, options)
 }
 
-// Patch performs a JSON Patch on the provided resource, and marshals the updated version into the `into` field
+// Patch performs a JSON Patch or JSON Merge Patch on the provided resource, and marshals the updated version into the `into` field
 func (s *SchemalessClient) Patch(ctx context.Context, identifier resource.FullIdentifier, patch resource.PatchRequest,
 	options resource.PatchOptions, into resource.Object) error {
 	client, err := s.getClient(identifier)
@@ -164,6 +164,38 @@ func (s *SchemalessClient) Patch(ctx context.Context, identifier resource.FullId
 	}, s.getPlural(identifier), patch, into, options)
 }
 
+// Apply performs a server-side apply of the provided resource, and marshals the resulting object into the `into` field.
+// Conflicts with fields owned by other field managers are returned as a *resource.ApplyConflictError.
+func (s *SchemalessClient) Apply(ctx context.Context, identifier resource.FullIdentifier, obj resource.Object,
+	options resource.ApplyOptions, into resource.Object) error {
+	if obj == nil {
+		return fmt.Errorf("obj cannot be nil")
+	}
+	if into == nil {
+		return fmt.Errorf("into cannot be nil")
+	}
+	if options.FieldManager == "" {
+		return fmt.Errorf("options.FieldManager is required")
+	}
+	client, err := s.getClient(identifier)
+	if err != nil {
+		return err
+	}
+
+	obj.SetStaticMetadata(resource.StaticMetadata{
+		Namespace: identifier.Namespace,
+		Name:      identifier.Name,
+		Group:     identifier.Group,
+		Version:   identifier.Version,
+		Kind:      identifier.Kind,
+	})
+
+	return client.apply(ctx, resource.Identifier{
+		Namespace: identifier.Namespace,
+		Name:      identifier.Name,
+	}, s.getPlural(identifier), obj, into, options)
+}
+
 // Delete deletes a resource identified by identifier
 func (s *SchemalessClient) Delete(ctx context.Context, identifier resource.FullIdentifier) error {
 	client, err := s.getClient(identifier)
@@ -196,6 +196,69 @@ func marshalJSON(obj resource.Object, extraLabels map[string]string, cfg ClientC
 	return json.Marshal(co)
 }
 
+// marshalApplyJSON marshals the object into an apply configuration for a server-side apply request.
+// An apply configuration should only contain the fields the applier owns, as the applier takes ownership of every field in it,
+// so only the identifying metadata and the set labels, annotations, finalizers, and owner references are included with the spec.
+// If subresource is non-empty, only the identifying metadata and the subresource are included.
+func marshalApplyJSON(obj resource.Object, subresource string, extraLabels map[string]string, cfg ClientConfig) ([]byte, error) {
+	if obj == nil {
+		return nil, fmt.Errorf("obj cannot be nil")
+	}
+
+	md := map[string]any{
+		"name": obj.StaticMetadata().Name,
+	}
+	if obj.StaticMetadata().Namespace != "" {
+		md["namespace"] = obj.StaticMetadata().Namespace
+	}
+	applyObj := map[string]any{
+		"kind": obj.StaticMetadata().Kind,
+		"apiVersion": schema.GroupVersion{
+			Group:   obj.StaticMetadata().Group,
+			Version: obj.StaticMetadata().Version,
+		}.Identifier(),
+		"metadata": md,
+	}
+	if subresource != "" {
+		sr, ok := obj.Subresources()[subresource]
+		if !ok || sr == nil {
+			return nil, fmt.Errorf("object has no %s subresource to apply", subresource)
+		}
+		applyObj[subresource] = sr
+		return json.Marshal(applyObj)
+	}
+
+	meta := getV1ObjectMeta(obj, cfg)
+	labels := make(map[string]string)
+	for k, v := range meta.Labels {
+		labels[k] = v
+	}
+	for k, v := range extraLabels {
+		labels[k] = v
+	}
+	if len(labels) > 0 {
+		md["labels"] = labels
+	}
+	// Empty annotations (such as an unset createdBy) are left out, rather than claiming them with an empty value
+	annotations := make(map[string]string)
+	for k, v := range meta.Annotations {
+		if v != "" {
+			annotations[k] = v
+		}
+	}
+	if len(annotations) > 0 {
+		md["annotations"] = annotations
+	}
+	if len(meta.Finalizers) > 0 {
+		md["finalizers"] = meta.Finalizers
+	}
+	if len(meta.OwnerReferences) > 0 {
+		md["ownerReferences"] = meta.OwnerReferences
+	}
+	applyObj["spec"] = obj.SpecObject()
+	return json.Marshal(applyObj)
+}
+
 var metaV1Fields = getV1ObjectMetaFields()
 
 func marshalJSONPatch(patch resource.PatchRequest) ([]byte, error) {
@@ -243,6 +306,65 @@ func marshalJSONPatch(patch resource.PatchRequest) ([]byte, error) {
 	return json.Marshal(patch.Operations)
 }
 
+// marshalMergePatch translates the metadata in a resource.PatchRequest merge patch into kubernetes metadata,
+// in the same way marshalJSONPatch does for patch paths, and returns the marshaled merge patch.
+func marshalMergePatch(patch resource.PatchRequest, cfg ClientConfig) ([]byte, error) {
+	doc := make(map[string]any)
+	if err := json.Unmarshal(patch.MergePatch, &doc); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+	md, ok := doc["metadata"]
+	if !ok {
+		return json.Marshal(doc)
+	}
+	// We don't allow a patch on the metadata object as a whole
+	meta, ok := md.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("cannot patch entire metadata object")
+	}
+	kubeMeta := make(map[string]any)
+	annotations := make(map[string]any)
+	for k, v := range meta {
+		if _, ok := metaV1Fields[k]; ok {
+			// Normal kube metadata
+			kubeMeta[k] = v
+			continue
+		}
+		// extraFields holds implementation-specific extra fields, which are kubernetes metadata fields
+		if k == "extraFields" {
+			extra, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("cannot patch entire extraFields, please patch fields in extraFields instead")
+			}
+			for ek, ev := range extra {
+				kubeMeta[ek] = ev
+			}
+			continue
+		}
+		// Everything else is stored in annotations (null values remove the annotation)
+		switch cast := v.(type) {
+		case nil, string:
+			annotations[annotationPrefix+k] = cast
+		default:
+			if !cfg.CustomMetadataIsAnyType {
+				return nil, fmt.Errorf("metadata field '%s' must be a string", k)
+			}
+			annotations[annotationPrefix+k] = toString(v)
+		}
+	}
+	if len(annotations) > 0 {
+		// Merge with any annotations set directly via extraFields
+		if existing, ok := kubeMeta["annotations"].(map[string]any); ok {
+			for k, v := range existing {
+				annotations[k] = v
+			}
+		}
+		kubeMeta["annotations"] = annotations
+	}
+	doc["metadata"] = kubeMeta
+	return json.Marshal(doc)
+}
+
 func getV1ObjectMetaFields() map[string]struct{} {
 	fields := make(map[string]struct{})
 	typ := reflect.TypeOf(metav1.ObjectMeta{})
@@ -467,6 +467,86 @@ func TestMarshalJSONPatch(t *testing.T) {
 	}
 }
 
+func TestMarshalMergePatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		patch         resource.PatchRequest
+		config        ClientConfig
+		expectedJSON  []byte
+		expectedError error
+	}{
+		{
+			name: "no metadata",
+			patch: resource.PatchRequest{
+				Type:       resource.PatchTypeMergePatch,
+				MergePatch: []byte(`{"spec":{"foo":"bar","baz":null}}`),
+			},
+			expectedJSON:  []byte(`{"spec":{"foo":"bar","baz":null}}`),
+			expectedError: nil,
+		},
+		{
+			name: "try to replace entire metadata object",
+			patch: resource.PatchRequest{
+				Type:       resource.PatchTypeMergePatch,
+				MergePatch: []byte(`{"metadata":null}`),
+			},
+			expectedJSON:  nil,
+			expectedError: fmt.Errorf("cannot patch entire metadata object"),
+		},
+		{
+			name: "mixed metadata",
+			patch: resource.PatchRequest{
+				Type:       resource.PatchTypeMergePatch,
+				MergePatch: []byte(`{"metadata":{"createdBy":"foo","customKey":null,"labels":{"a":"b"},"extraFields":{"generation":2}},"spec":{"foo":"bar"}}`),
+			},
+			expectedJSON:  []byte(`{"metadata":{"annotations":{"grafana.com/createdBy":"foo","grafana.com/customKey":null},"labels":{"a":"b"},"generation":2},"spec":{"foo":"bar"}}`),
+			expectedError: nil,
+		},
+		{
+			name: "non-string custom metadata",
+			patch: resource.PatchRequest{
+				Type:       resource.PatchTypeMergePatch,
+				MergePatch: []byte(`{"metadata":{"customKey":10}}`),
+			},
+			expectedJSON:  nil,
+			expectedError: fmt.Errorf("metadata field 'customKey' must be a string"),
+		},
+		{
+			name: "non-string custom metadata with CustomMetadataIsAnyType",
+			patch: resource.PatchRequest{
+				Type:       resource.PatchTypeMergePatch,
+				MergePatch: []byte(`{"metadata":{"customKey":10}}`),
+			},
+			config: ClientConfig{
+				CustomMetadataIsAnyType: true,
+			},
+			expectedJSON:  []byte(`{"metadata":{"annotations":{"grafana.com/customKey":"10"}}}`),
+			expectedError: nil,
+		},
+		{
+			name: "try to replace entire metadata/extraFields object",
+			patch: resource.PatchRequest{
+				Type:       resource.PatchTypeMergePatch,
+				MergePatch: []byte(`{"metadata":{"extraFields":null}}`),
+			},
+			expectedJSON:  nil,
+			expectedError: fmt.Errorf("cannot patch entire extraFields, please patch fields in extraFields instead"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := marshalMergePatch(test.patch, test.config)
+			assert.Equal(t, test.expectedError, err)
+			if test.expectedJSON == nil {
+				assert.Nil(t, actual)
+			} else {
+				assert.JSONEq(t, string(test.expectedJSON), string(actual))
+			}
+		})
+	}
+}
+
 type TestResourceObject struct {
 	StaticMeta    resource.StaticMetadata
 	Metadata      TestResourceObjectMetadata
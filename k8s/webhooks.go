@@ -11,6 +11,7 @@ import (
 	"gomodules.xyz/jsonpatch/v2"
 	admission "k8s.io/api/admission/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/grafana/grafana-app-sdk/resource"
 )
@@ -31,6 +32,8 @@ type WebhookServerConfig struct {
 	// DefaultMutatingController is called for any /validate requests received which don't have an entry in MutatingControllers.
 	// If left nil, an error will be returned to the caller instead.
 	DefaultMutatingController resource.MutatingAdmissionController
+	// Converters is a map of GroupKind to the Converter used for /convert requests for objects of that group and kind.
+	Converters map[metav1.GroupKind]Converter
 }
 
 // TLSConfig describes a set of TLS files
@@ -41,7 +44,7 @@ type TLSConfig struct {
 	KeyPath string
 }
 
-// WebhookServer is a kubernetes webhook server, which exposes /validate and /mutate HTTPS endpoints.
+// WebhookServer is a kubernetes webhook server, which exposes /validate, /mutate, and /convert HTTPS endpoints.
 // It implements operator.Controller and can be run as a controller in an operator, or as a standalone process.
 type WebhookServer struct {
 	// DefaultValidatingController is the default ValidatingAdmissionController to use if one is not defined for the schema in the request.
@@ -52,6 +55,7 @@ type WebhookServer struct {
 	DefaultMutatingController resource.MutatingAdmissionController
 	validatingControllers     map[string]validatingAdmissionControllerTuple
 	mutatingControllers       map[string]mutatingAdmissionControllerTuple
+	converters                map[string]Converter
 	port                      int
 	tlsConfig                 TLSConfig
 }
@@ -75,6 +79,7 @@ func NewWebhookServer(config WebhookServerConfig) (*WebhookServer, error) {
 		DefaultMutatingController:   config.DefaultMutatingController,
 		validatingControllers:       make(map[string]validatingAdmissionControllerTuple),
 		mutatingControllers:         make(map[string]mutatingAdmissionControllerTuple),
+		converters:                  make(map[string]Converter),
 		port:                        config.Port,
 		tlsConfig:                   config.TLSConfig,
 	}
@@ -87,6 +92,10 @@ func NewWebhookServer(config WebhookServerConfig) (*WebhookServer, error) {
 		ws.AddMutatingAdmissionController(controller, sch)
 	}
 
+	for groupKind, converter := range config.Converters {
+		ws.AddConverter(converter, groupKind)
+	}
+
 	return &ws, nil
 }
 
@@ -116,13 +125,23 @@ func (w *WebhookServer) AddMutatingAdmissionController(controller resource.Mutat
 	}
 }
 
-// Run establishes an HTTPS server on the configured port and exposes `/validate` and `/mutate` paths for kubernetes
-// validating and mutating webhooks, respectively. It will block until either closeChan is closed (in which case it returns nil),
+// AddConverter adds a Converter to the WebhookServer, which is used for all /convert requests for objects of the provided group and kind.
+// If a Converter already exists for the group and kind, the one provided in this call will be used instead of the extant one.
+func (w *WebhookServer) AddConverter(converter Converter, groupKind metav1.GroupKind) {
+	if w.converters == nil {
+		w.converters = make(map[string]Converter)
+	}
+	w.converters[gk(groupKind.Group, groupKind.Kind)] = converter
+}
+
+// Run establishes an HTTPS server on the configured port and exposes `/validate`, `/mutate`, and `/convert` paths for kubernetes
+// validating webhooks, mutating webhooks, and conversion webhooks, respectively. It will block until either closeChan is closed (in which case it returns nil),
 // or the server encounters an unrecoverable error (in which case it returns the error).
 func (w *WebhookServer) Run(closeChan <-chan struct{}) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/validate", w.HandleValidateHTTP)
 	mux.HandleFunc("/mutate", w.HandleMutateHTTP)
+	mux.HandleFunc("/convert", w.HandleConvertHTTP)
 	server := &http.Server{
 		Addr:              fmt.Sprintf(":%d", w.port),
 		Handler:           mux,
@@ -299,6 +318,92 @@ func (w *WebhookServer) HandleMutateHTTP(writer http.ResponseWriter, req *http.R
 	writer.Write(bytes)
 }
 
+// HandleConvertHTTP is the HTTP HandlerFunc for a kubernetes conversion webhook call
+// nolint:errcheck,revive
+func (w *WebhookServer) HandleConvertHTTP(writer http.ResponseWriter, req *http.Request) {
+	// Only POST is allowed
+	if req.Method != http.MethodPost {
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Read the body
+	body, err := io.ReadAll(req.Body)
+	defer req.Body.Close()
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Unmarshal the conversion review
+	convRev := ConversionReview{}
+	err = json.Unmarshal(body, &convRev)
+	if err != nil || convRev.Request == nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Convert each object, failing the whole request if any object can't be converted
+	convResp := ConversionResponse{
+		UID:              convRev.Request.UID,
+		ConvertedObjects: make([]runtime.RawExtension, 0, len(convRev.Request.Objects)),
+		Result: metav1.Status{
+			Status: metav1.StatusSuccess,
+		},
+	}
+	for _, obj := range convRev.Request.Objects {
+		converted, err := w.convert(obj.Raw, convRev.Request.DesiredAPIVersion)
+		if err != nil {
+			convResp.ConvertedObjects = nil
+			convResp.Result = metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: err.Error(),
+			}
+			break
+		}
+		convResp.ConvertedObjects = append(convResp.ConvertedObjects, runtime.RawExtension{
+			Raw: converted,
+		})
+	}
+
+	bytes, err := json.Marshal(&ConversionReview{
+		TypeMeta: convRev.TypeMeta,
+		Response: &convResp,
+	})
+	if err != nil {
+		// Bad news
+		writer.WriteHeader(http.StatusInternalServerError)
+		writer.Write([]byte(err.Error())) // TODO: better
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+// convert converts a single raw object from a ConversionReview to the desiredAPIVersion using the Converter for its group and kind
+func (w *WebhookServer) convert(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	tm := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &tm); err != nil {
+		return nil, err
+	}
+	gvk := tm.GroupVersionKind()
+	// Objects already in the desired version don't need to be converted
+	if tm.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+	converter, ok := w.converters[gk(gvk.Group, gvk.Kind)]
+	if !ok {
+		return nil, fmt.Errorf(errStringNoConverterDefined, gvk.Group, gvk.Kind)
+	}
+	return converter.Convert(RawKind{
+		Kind:       gvk.Kind,
+		APIVersion: tm.APIVersion,
+		Group:      gvk.Group,
+		Version:    gvk.Version,
+		Raw:        raw,
+	}, desiredAPIVersion)
+}
+
 func (*WebhookServer) generatePatch(admRev *admission.AdmissionReview, alteredObject resource.Object) ([]byte, error) {
 	// We need to generate a list of JSONPatch operations for updating the existing object to the provided one.
 	// To start, we need to translate the provided object into its kubernetes bytes representation
@@ -0,0 +1,110 @@
+package operator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// ErrNotFoundInCache indicates that the requested object does not exist in the cache
+var ErrNotFoundInCache = errors.New("object not found in cache")
+
+// IndexFunc computes the set of indexed values for an object. It is used to define custom indexers for a cache,
+// such as an index on the value of a particular spec field.
+type IndexFunc func(obj resource.Object) ([]string, error)
+
+// ObjectCache is a read-only cache of resource.Objects, such as the one maintained by an informer.
+// Lookups are done against local data, and never make requests to the storage system.
+// Objects returned by an ObjectCache are shared with the cache and MUST NOT be modified;
+// use Copy() on an object before making changes to it.
+type ObjectCache interface {
+	// Get returns the object with the provided identifier, or ErrNotFoundInCache if it does not exist in the cache
+	Get(identifier resource.Identifier) (resource.Object, error)
+	// List returns all objects in the provided namespace which match all the provided label filters.
+	// Use resource.NamespaceAll to list objects in all namespaces.
+	List(namespace string, labelFilters ...string) ([]resource.Object, error)
+	// ByIndex returns all objects whose value(s) for the named index contain indexedValue
+	ByIndex(indexName, indexedValue string) ([]resource.Object, error)
+}
+
+// InformerCache is an ObjectCache backed by the store of a KubernetesBasedInformer.
+// It should be created with KubernetesBasedInformer.Cache().
+type InformerCache struct {
+	informer *KubernetesBasedInformer
+}
+
+// Get returns the object with the provided identifier, or ErrNotFoundInCache if it does not exist in the cache
+func (c *InformerCache) Get(identifier resource.Identifier) (resource.Object, error) {
+	key := identifier.Name
+	if identifier.Namespace != resource.NamespaceAll {
+		key = identifier.Namespace + "/" + identifier.Name
+	}
+	item, exists, err := c.informer.SharedIndexInformer.GetIndexer().GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFoundInCache
+	}
+	return c.informer.toResourceObject(item)
+}
+
+// List returns all objects in the provided namespace which match all the provided label filters.
+// Label filters use the kubernetes label selector syntax.
+// Use resource.NamespaceAll to list objects in all namespaces.
+func (c *InformerCache) List(namespace string, labelFilters ...string) ([]resource.Object, error) {
+	selector, err := labels.Parse(strings.Join(labelFilters, ","))
+	if err != nil {
+		return nil, fmt.Errorf("invalid label filters: %w", err)
+	}
+	var items []any
+	if namespace == resource.NamespaceAll {
+		items = c.informer.SharedIndexInformer.GetIndexer().List()
+	} else {
+		items, err = c.informer.SharedIndexInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	objects := make([]resource.Object, 0, len(items))
+	for _, item := range items {
+		obj, err := c.informer.toResourceObject(item)
+		if err != nil {
+			return nil, err
+		}
+		if selector.Matches(labels.Set(obj.CommonMetadata().Labels)) {
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+// ByIndex returns all objects whose value(s) for the named index contain indexedValue.
+// The index must have been added to the informer with KubernetesBasedInformer.AddIndexers.
+func (c *InformerCache) ByIndex(indexName, indexedValue string) ([]resource.Object, error) {
+	items, err := c.informer.SharedIndexInformer.GetIndexer().ByIndex(indexName, indexedValue)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]resource.Object, len(items))
+	for idx, item := range items {
+		objects[idx], err = c.informer.toResourceObject(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+// HasSynced returns true if the underlying informer has completed its initial list, and the cache is populated
+func (c *InformerCache) HasSynced() bool {
+	return c.informer.SharedIndexInformer.HasSynced()
+}
+
+// Interface compliance compile-time check
+var _ ObjectCache = &InformerCache{}
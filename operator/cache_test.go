@@ -0,0 +1,110 @@
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+	"github.com/grafana/grafana-app-sdk/resource/fake"
+)
+
+type cacheTestSpec struct {
+	Owner string `json:"owner"`
+}
+
+func TestInformerCache(t *testing.T) {
+	sch := resource.NewSimpleSchema("test.grafana.com", "v1", &resource.SimpleObject[cacheTestSpec]{}, resource.WithKind("Test"))
+	client, err := fake.NewClientGenerator().ClientFor(sch)
+	require.Nil(t, err)
+	ctx := context.Background()
+	for _, obj := range []struct {
+		id     resource.Identifier
+		owner  string
+		labels map[string]string
+	}{
+		{resource.Identifier{Namespace: "ns1", Name: "a"}, "alice", map[string]string{"tier": "frontend"}},
+		{resource.Identifier{Namespace: "ns1", Name: "b"}, "bob", map[string]string{"tier": "backend"}},
+		{resource.Identifier{Namespace: "ns2", Name: "c"}, "alice", map[string]string{"tier": "backend"}},
+	} {
+		o := &resource.SimpleObject[cacheTestSpec]{Spec: cacheTestSpec{Owner: obj.owner}}
+		o.CommonMeta.Labels = obj.labels
+		_, err = client.Create(ctx, obj.id, o, resource.CreateOptions{})
+		require.Nil(t, err)
+	}
+
+	informer, err := NewKubernetesBasedInformer(sch, client, resource.NamespaceAll)
+	require.Nil(t, err)
+	require.Nil(t, informer.AddIndexers(map[string]IndexFunc{
+		"owner": func(obj resource.Object) ([]string, error) {
+			return []string{obj.SpecObject().(cacheTestSpec).Owner}, nil
+		},
+	}))
+	c := informer.Cache()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go informer.Run(stopCh)
+	require.Eventually(t, c.HasSynced, 5*time.Second, 10*time.Millisecond)
+
+	t.Run("get", func(t *testing.T) {
+		obj, err := c.Get(resource.Identifier{Namespace: "ns1", Name: "b"})
+		require.Nil(t, err)
+		assert.Equal(t, cacheTestSpec{Owner: "bob"}, obj.SpecObject())
+	})
+
+	t.Run("get not found", func(t *testing.T) {
+		_, err := c.Get(resource.Identifier{Namespace: "ns2", Name: "b"})
+		assert.Equal(t, ErrNotFoundInCache, err)
+	})
+
+	t.Run("list namespace", func(t *testing.T) {
+		objs, err := c.List("ns1")
+		require.Nil(t, err)
+		assert.Len(t, objs, 2)
+	})
+
+	t.Run("list with labels", func(t *testing.T) {
+		objs, err := c.List(resource.NamespaceAll, "tier=backend")
+		require.Nil(t, err)
+		assert.Len(t, objs, 2)
+		objs, err = c.List("ns1", "tier=backend")
+		require.Nil(t, err)
+		require.Len(t, objs, 1)
+		assert.Equal(t, "b", objs[0].StaticMetadata().Name)
+	})
+
+	t.Run("invalid label filter", func(t *testing.T) {
+		_, err := c.List(resource.NamespaceAll, "tier in (")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("by index", func(t *testing.T) {
+		objs, err := c.ByIndex("owner", "alice")
+		require.Nil(t, err)
+		assert.Len(t, objs, 2)
+		_, err = c.ByIndex("nope", "alice")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("reflects watch events", func(t *testing.T) {
+		_, err := client.Create(ctx, resource.Identifier{Namespace: "ns2", Name: "d"},
+			&resource.SimpleObject[cacheTestSpec]{Spec: cacheTestSpec{Owner: "bob"}}, resource.CreateOptions{})
+		require.Nil(t, err)
+		assert.Eventually(t, func() bool {
+			objs, err := c.ByIndex("owner", "bob")
+			return err == nil && len(objs) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("add indexers after start", func(t *testing.T) {
+		assert.NotNil(t, informer.AddIndexers(map[string]IndexFunc{
+			"other": func(obj resource.Object) ([]string, error) {
+				return nil, nil
+			},
+		}))
+	})
+}
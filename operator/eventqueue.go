@@ -0,0 +1,124 @@
+package operator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// queuedEvent is an informer event waiting to be processed by an InformerController worker
+type queuedEvent struct {
+	ctx    context.Context
+	action ResourceAction
+	old    resource.Object
+	object resource.Object
+}
+
+// scheduledRetry is the time a key is scheduled to be re-added to the queue for a retry, and the time the retry is due.
+// The key is re-added after the retry is due if the retry was delayed by the queue's rate limiter.
+type scheduledRetry struct {
+	due time.Time
+	at  time.Time
+}
+
+// eventQueue is a work queue of informer events for a single resource kind, keyed by object.
+// Each object key is only present in the queue once, no matter how many events are pending for it,
+// and a key is never processed by more than one worker at a time, so events for an object are always processed in order.
+// New events are processed as soon as a worker is available, while retries are rate limited by the rateLimiter.
+type eventQueue struct {
+	queue       workqueue.DelayingInterface
+	rateLimiter workqueue.RateLimiter
+	mux         sync.Mutex
+	pending     map[string][]queuedEvent
+	retries     map[string]scheduledRetry
+}
+
+func newEventQueue(resourceKind string, rateLimiter workqueue.RateLimiter) *eventQueue {
+	return &eventQueue{
+		queue: workqueue.NewDelayingQueueWithConfig(workqueue.DelayingQueueConfig{
+			Name: resourceKind,
+		}),
+		rateLimiter: rateLimiter,
+		pending:     make(map[string][]queuedEvent),
+		retries:     make(map[string]scheduledRetry),
+	}
+}
+
+// push adds an event to the pending events for the key, and adds the key to the queue if it is not already present.
+// Consecutive pending update events for the same key are collapsed into a single update,
+// from the oldest previous state to the newest state.
+func (q *eventQueue) push(key string, event queuedEvent) {
+	q.mux.Lock()
+	events := q.pending[key]
+	if last := len(events) - 1; last >= 0 && event.action == ResourceActionUpdate &&
+		events[last].action == ResourceActionUpdate {
+		event.old = events[last].old
+		events[last] = event
+	} else {
+		events = append(events, event)
+	}
+	q.pending[key] = events
+	q.mux.Unlock()
+	q.queue.Add(key)
+}
+
+// pop removes and returns all pending events for the key, in the order they were pushed
+func (q *eventQueue) pop(key string) []queuedEvent {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	events := q.pending[key]
+	delete(q.pending, key)
+	return events
+}
+
+// scheduleRetry adds the key to the queue when its next retry is due, or later if required by the queue's rate limiter.
+// Each retry is only rate limited once: if the key is already scheduled to be re-added for a retry
+// which is due no later than due, it is not scheduled again, so processing the key for new events
+// while it has a pending retry doesn't use up the rate limit.
+func (q *eventQueue) scheduleRetry(key string, due time.Time) {
+	q.mux.Lock()
+	now := time.Now()
+	if scheduled, ok := q.retries[key]; ok && scheduled.at.After(now) && !scheduled.due.After(due) {
+		q.mux.Unlock()
+		return
+	}
+	at := due
+	if limited := now.Add(q.rateLimiter.When(key)); limited.After(at) {
+		at = limited
+	}
+	q.retries[key] = scheduledRetry{
+		due: due,
+		at:  at,
+	}
+	q.mux.Unlock()
+	q.queue.AddAfter(key, at.Sub(now))
+}
+
+// forgetRetries stops tracking the scheduled retry for the key, once the key has no pending retries
+func (q *eventQueue) forgetRetries(key string) {
+	q.mux.Lock()
+	delete(q.retries, key)
+	q.mux.Unlock()
+	q.rateLimiter.Forget(key)
+}
+
+// shutDown shuts down the queue, causing all workers to stop once their current key has been processed
+func (q *eventQueue) shutDown() {
+	q.queue.ShutDown()
+}
+
+// processNext blocks until a key is available in the queue, and calls process with it.
+// It returns false if the queue has been shut down.
+func (q *eventQueue) processNext(process func(key string)) bool {
+	item, shutdown := q.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer q.queue.Done(item)
+	process(item.(string))
+	return true
+}
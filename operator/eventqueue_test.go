@@ -0,0 +1,144 @@
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+func newTestEventQueue() *eventQueue {
+	return newEventQueue("test", &workqueue.BucketRateLimiter{
+		Limiter: rate.NewLimiter(rate.Inf, 0),
+	})
+}
+
+func TestEventQueue_push(t *testing.T) {
+	ctx := context.Background()
+	first := &resource.SimpleObject[string]{Spec: "first"}
+	second := &resource.SimpleObject[string]{Spec: "second"}
+	third := &resource.SimpleObject[string]{Spec: "third"}
+
+	t.Run("key is only queued once", func(t *testing.T) {
+		q := newTestEventQueue()
+		defer q.shutDown()
+		q.push("a", queuedEvent{ctx: ctx, action: ResourceActionCreate, object: first})
+		q.push("a", queuedEvent{ctx: ctx, action: ResourceActionDelete, object: first})
+		q.push("b", queuedEvent{ctx: ctx, action: ResourceActionCreate, object: second})
+		assert.Equal(t, 2, q.queue.Len())
+		events := q.pop("a")
+		require.Len(t, events, 2)
+		assert.Equal(t, ResourceActionCreate, events[0].action)
+		assert.Equal(t, ResourceActionDelete, events[1].action)
+		assert.Empty(t, q.pop("a"))
+	})
+
+	t.Run("consecutive updates are collapsed", func(t *testing.T) {
+		q := newTestEventQueue()
+		defer q.shutDown()
+		q.push("a", queuedEvent{ctx: ctx, action: ResourceActionCreate, object: first})
+		q.push("a", queuedEvent{ctx: ctx, action: ResourceActionUpdate, old: first, object: second})
+		q.push("a", queuedEvent{ctx: ctx, action: ResourceActionUpdate, old: second, object: third})
+		events := q.pop("a")
+		require.Len(t, events, 2)
+		assert.Equal(t, ResourceActionCreate, events[0].action)
+		assert.Equal(t, ResourceActionUpdate, events[1].action)
+		assert.Equal(t, first, events[1].old)
+		assert.Equal(t, third, events[1].object)
+	})
+}
+
+func TestEventQueue_processNext(t *testing.T) {
+	q := newTestEventQueue()
+	q.push("a", queuedEvent{action: ResourceActionCreate})
+	processed := make([]string, 0)
+	assert.True(t, q.processNext(func(key string) {
+		processed = append(processed, key)
+	}))
+	assert.Equal(t, []string{"a"}, processed)
+
+	q.scheduleRetry("b", time.Now().Add(10*time.Millisecond))
+	assert.True(t, q.processNext(func(key string) {
+		processed = append(processed, key)
+	}))
+	assert.Equal(t, []string{"a", "b"}, processed)
+
+	q.shutDown()
+	assert.False(t, q.processNext(func(key string) {
+		processed = append(processed, key)
+	}))
+	assert.Equal(t, []string{"a", "b"}, processed)
+}
+
+// countingRateLimiter is a workqueue.RateLimiter which delays every item by delay, and counts calls to When
+type countingRateLimiter struct {
+	delay time.Duration
+	whens int
+}
+
+func (r *countingRateLimiter) When(any) time.Duration {
+	r.whens++
+	return r.delay
+}
+
+func (*countingRateLimiter) Forget(any) {}
+
+func (*countingRateLimiter) NumRequeues(any) int {
+	return 0
+}
+
+func TestEventQueue_scheduleRetry(t *testing.T) {
+	t.Run("each retry is rate limited once", func(t *testing.T) {
+		limiter := &countingRateLimiter{}
+		q := newEventQueue("test", limiter)
+		defer q.shutDown()
+		q.scheduleRetry("a", time.Now().Add(time.Second))
+		assert.Equal(t, 1, limiter.whens)
+		// The key is already scheduled for an earlier retry
+		q.scheduleRetry("a", time.Now().Add(2*time.Second))
+		assert.Equal(t, 1, limiter.whens)
+		// A retry which is due earlier is scheduled again
+		q.scheduleRetry("a", time.Now().Add(500*time.Millisecond))
+		assert.Equal(t, 2, limiter.whens)
+		// Other keys are scheduled separately
+		q.scheduleRetry("b", time.Now().Add(time.Second))
+		assert.Equal(t, 3, limiter.whens)
+		// Once the key has no pending retries, the next retry is scheduled again
+		q.forgetRetries("a")
+		q.scheduleRetry("a", time.Now().Add(time.Second))
+		assert.Equal(t, 4, limiter.whens)
+	})
+
+	t.Run("retry is delayed by the rate limiter", func(t *testing.T) {
+		q := newEventQueue("test", &countingRateLimiter{
+			delay: 100 * time.Millisecond,
+		})
+		defer q.shutDown()
+		start := time.Now()
+		q.scheduleRetry("a", start)
+		assert.True(t, q.processNext(func(key string) {
+			assert.Equal(t, "a", key)
+		}))
+		assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	})
+
+	t.Run("new events are not rate limited", func(t *testing.T) {
+		limiter := &countingRateLimiter{
+			delay: time.Hour,
+		}
+		q := newEventQueue("test", limiter)
+		defer q.shutDown()
+		q.scheduleRetry("a", time.Now())
+		q.push("b", queuedEvent{action: ResourceActionCreate})
+		assert.True(t, q.processNext(func(key string) {
+			assert.Equal(t, "b", key)
+		}))
+		assert.Equal(t, 1, limiter.whens)
+	})
+}
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
 
 	"github.com/grafana/grafana-app-sdk/logging"
 	"github.com/grafana/grafana-app-sdk/metrics"
@@ -31,6 +34,15 @@ var ErrInformerAlreadyAdded = errors.New("informer for resource kind already add
 // DefaultRetryPolicy is an Exponential Backoff RetryPolicy with an initial 5-second delay and a max of 5 attempts
 var DefaultRetryPolicy = ExponentialBackoffRetryPolicy(5*time.Second, 5)
 
+const (
+	// DefaultWorkersPerKind is the default InformerControllerConfig.WorkersPerKind
+	DefaultWorkersPerKind = 1
+	// DefaultMaxRetriesPerSecond is the default InformerControllerConfig.MaxRetriesPerSecond
+	DefaultMaxRetriesPerSecond = 10
+	// DefaultMaxRetryBurst is the default InformerControllerConfig.MaxRetryBurst
+	DefaultMaxRetryBurst = 100
+)
+
 // DefaultErrorHandler is an error handler function which simply logs the error with the logger in the context
 var DefaultErrorHandler = func(ctx context.Context, err error) {
 	logging.FromContext(ctx).Error(err.Error(), "component", "InformerController", "error", err)
@@ -93,6 +105,12 @@ var OpinionatedRetryDequeuePolicy = func(newAction ResourceAction, newObject res
 // InformerController is an object that handles coordinating informers and observers.
 // Unlike adding a Watcher directly to an Informer with AddEventHandler, the InformerController
 // guarantees sequential execution of watchers, based on add order.
+//
+// Informer events are added to a work queue for their resource kind, keyed by object, and are processed by
+// a configurable number of workers per resource kind (see InformerControllerConfig.WorkersPerKind).
+// Events for the same object are always processed sequentially and in order, while events for different objects
+// may be processed concurrently. Failed calls are retried according to the RetryPolicy,
+// with the total rate of retries for each resource kind bounded by InformerControllerConfig.MaxRetriesPerSecond.
 type InformerController struct {
 	// ErrorHandler is a user-specified error handling function. This is typically for logging/metrics use,
 	// as retry logic is covered by the RetryPolicy.
@@ -101,21 +119,28 @@ type InformerController struct {
 	RetryPolicy RetryPolicy
 	// RetryDequeuePolicy is a user-specified retry dequeue logic function which will be used for new informer actions
 	// when one or more retries for the object are still pending. If not present, existing retries are always dequeued.
-	RetryDequeuePolicy  RetryDequeuePolicy
-	informers           *ListMap[string, Informer]
-	watchers            *ListMap[string, ResourceWatcher]
-	reconcilers         *ListMap[string, Reconciler]
-	toRetry             *ListMap[string, retryInfo]
-	retryTickerInterval time.Duration
-	totalEvents         *prometheus.CounterVec
-	reconcileLatency    *prometheus.HistogramVec
-	reconcilerLatency   *prometheus.HistogramVec
-	watcherLatency      *prometheus.HistogramVec
-	inflightActions     *prometheus.GaugeVec
-	inflightEvents      *prometheus.GaugeVec
+	RetryDequeuePolicy RetryDequeuePolicy
+	informers          *ListMap[string, Informer]
+	watchers           *ListMap[string, ResourceWatcher]
+	reconcilers        *ListMap[string, Reconciler]
+	toRetry            *ListMap[string, retryInfo]
+	queues             map[string]*eventQueue
+	queuesMux          sync.Mutex
+	informersStarted   sync.Once
+	workersPerKind     int
+	retryLimit         rate.Limit
+	retryBurst         int
+	totalEvents        *prometheus.CounterVec
+	reconcileLatency   *prometheus.HistogramVec
+	reconcilerLatency  *prometheus.HistogramVec
+	watcherLatency     *prometheus.HistogramVec
+	inflightActions    *prometheus.GaugeVec
+	inflightEvents     *prometheus.GaugeVec
 }
 
 type retryInfo struct {
+	// handlerKey is the key of the watcher or reconciler event this retry is for
+	handlerKey string
 	retryAfter time.Time
 	retryFunc  func() (*time.Duration, error)
 	attempt    int
@@ -127,25 +152,54 @@ type retryInfo struct {
 // InformerControllerConfig contains configuration options for an InformerController
 type InformerControllerConfig struct {
 	MetricsConfig metrics.Config
+	// WorkersPerKind is the number of workers which concurrently process events for each resource kind.
+	// Events for the same object are never processed concurrently.
+	// If WorkersPerKind is <= 0, DefaultWorkersPerKind is used.
+	WorkersPerKind int
+	// MaxRetriesPerSecond is the maximum sustained rate of retries for each resource kind.
+	// Retries beyond this rate are delayed past the time specified by the RetryPolicy or ReconcileResult.
+	// Only retries are rate limited, new events are processed as soon as a worker is available.
+	// If MaxRetriesPerSecond is <= 0, DefaultMaxRetriesPerSecond is used.
+	MaxRetriesPerSecond float64
+	// MaxRetryBurst is the number of retries for each resource kind which may exceed MaxRetriesPerSecond in a burst.
+	// If MaxRetryBurst is <= 0, DefaultMaxRetryBurst is used.
+	MaxRetryBurst int
 }
 
 // DefaultInformerControllerConfig returns an InformerControllerConfig with default values
 func DefaultInformerControllerConfig() InformerControllerConfig {
 	return InformerControllerConfig{
-		MetricsConfig: metrics.DefaultConfig(""),
+		MetricsConfig:       metrics.DefaultConfig(""),
+		WorkersPerKind:      DefaultWorkersPerKind,
+		MaxRetriesPerSecond: DefaultMaxRetriesPerSecond,
+		MaxRetryBurst:       DefaultMaxRetryBurst,
 	}
 }
 
 // NewInformerController creates a new controller
+//
+//nolint:funlen
 func NewInformerController(cfg InformerControllerConfig) *InformerController {
+	if cfg.WorkersPerKind <= 0 {
+		cfg.WorkersPerKind = DefaultWorkersPerKind
+	}
+	if cfg.MaxRetriesPerSecond <= 0 {
+		cfg.MaxRetriesPerSecond = DefaultMaxRetriesPerSecond
+	}
+	if cfg.MaxRetryBurst <= 0 {
+		cfg.MaxRetryBurst = DefaultMaxRetryBurst
+	}
 	return &InformerController{
-		RetryPolicy:         DefaultRetryPolicy,
-		ErrorHandler:        DefaultErrorHandler,
-		informers:           NewListMap[Informer](),
-		watchers:            NewListMap[ResourceWatcher](),
-		reconcilers:         NewListMap[Reconciler](),
-		toRetry:             NewListMap[retryInfo](),
-		retryTickerInterval: time.Second,
+		RetryPolicy:    DefaultRetryPolicy,
+		ErrorHandler:   DefaultErrorHandler,
+		informers:      NewListMap[Informer](),
+		watchers:       NewListMap[ResourceWatcher](),
+		reconcilers:    NewListMap[Reconciler](),
+		toRetry:        NewListMap[retryInfo](),
+		queues:         make(map[string]*eventQueue),
+		workersPerKind: cfg.WorkersPerKind,
+		retryLimit:     rate.Limit(cfg.MaxRetriesPerSecond),
+		retryBurst:     cfg.MaxRetryBurst,
 		reconcileLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace:                       cfg.MetricsConfig.Namespace,
 			Subsystem:                       "informer",
@@ -223,6 +277,7 @@ func (c *InformerController) AddInformer(informer Informer, resourceKind string)
 	}
 
 	c.informers.AddItem(resourceKind, informer)
+	c.queueFor(resourceKind)
 	return nil
 }
 
@@ -280,21 +335,51 @@ func (c *InformerController) RemoveAllReconcilersForResource(resourceKind string
 	c.reconcilers.RemoveKey(resourceKind)
 }
 
-// Run runs the controller, which starts all informers, until stopCh is closed
-//
-//nolint:errcheck
+// Run runs the controller, which starts all informers (unless they were already started by Warm)
+// and processes their events, until stopCh is closed
 func (c *InformerController) Run(stopCh <-chan struct{}) error {
-	c.informers.RangeAll(func(_ string, _ int, inf Informer) {
-		go inf.Run(stopCh)
-	})
+	c.startInformers(stopCh)
 
-	go c.retryTicker(stopCh)
+	c.queuesMux.Lock()
+	for kind, queue := range c.queues {
+		for i := 0; i < c.workersPerKind; i++ {
+			go c.runWorker(kind, queue)
+		}
+	}
+	c.queuesMux.Unlock()
 
 	<-stopCh
 
+	c.queuesMux.Lock()
+	for _, queue := range c.queues {
+		queue.shutDown()
+	}
+	c.queuesMux.Unlock()
+
 	return nil
 }
 
+// Warm starts the controller's informers without processing any of their events, so that their caches are filled
+// and their events are queued for the watchers and reconcilers until Run is called.
+// This allows a controller to be warmed up while the operator waits for leadership.
+// The informers run until stopCh is closed, and Warm does not block.
+// Informers are only started once, so Run does not start them again after Warm.
+func (c *InformerController) Warm(stopCh <-chan struct{}) error {
+	c.startInformers(stopCh)
+	return nil
+}
+
+// startInformers starts all informers, if they haven't already been started
+//
+//nolint:errcheck
+func (c *InformerController) startInformers(stopCh <-chan struct{}) {
+	c.informersStarted.Do(func() {
+		c.informers.RangeAll(func(_ string, _ int, inf Informer) {
+			go inf.Run(stopCh)
+		})
+	})
+}
+
 // PrometheusCollectors returns the prometheus metric collectors used by this informer to allow for registration
 func (c *InformerController) PrometheusCollectors() []prometheus.Collector {
 	return []prometheus.Collector{
@@ -302,187 +387,273 @@ func (c *InformerController) PrometheusCollectors() []prometheus.Collector {
 	}
 }
 
-// nolint:dupl
 func (c *InformerController) informerAddFunc(resourceKind string) func(context.Context, resource.Object) error {
 	return func(ctx context.Context, obj resource.Object) error {
 		if obj == nil {
 			return ErrNilObject
 		}
-
-		// Metrics for the whole reconcile process
-		eventStart := c.startEvent(string(ResourceActionCreate), obj.StaticMetadata().Kind)
-		defer c.completeEvent(string(ResourceActionCreate), obj.StaticMetadata().Kind, eventStart)
-
-		ctx, span := GetTracer().Start(ctx, "controller-event-add")
-		defer span.End()
-		// Handle all watchers for the add for this resource kind
-		c.watchers.Range(resourceKind, func(idx int, watcher ResourceWatcher) {
-			// Generate the unique key for this object
-			retryKey := c.keyForWatcherEvent(resourceKind, idx, obj)
-
-			// Dequeue retries according to the RetryDequeuePolicy
-			c.dequeueIfRequired(retryKey, obj, ResourceActionCreate)
-
-			// Do the watcher's Add, check for error
-			c.wrapWatcherCall(string(ResourceActionCreate), obj.StaticMetadata().Kind, func() {
-				err := watcher.Add(ctx, obj)
-				if err != nil && c.ErrorHandler != nil {
-					c.ErrorHandler(ctx, err) // TODO: improve ErrorHandler
-				}
-				if err != nil && c.RetryPolicy != nil {
-					c.queueRetry(retryKey, err, func() (*time.Duration, error) {
-						ctx, span := GetTracer().Start(ctx, "controller-retry")
-						defer span.End()
-						return nil, watcher.Add(ctx, obj)
-					}, ResourceActionCreate, obj)
-				}
-			})
-		})
-		// Handle all reconcilers for the add for this resource kind
-		c.reconcilers.Range(resourceKind, func(idx int, reconciler Reconciler) {
-			// Generate the unique key for this object
-			retryKey := c.keyForReconcilerEvent(resourceKind, idx, obj)
-
-			// Dequeue retries according to the RetryDequeuePolicy
-			c.dequeueIfRequired(retryKey, obj, ResourceActionCreate)
-
-			// Do the reconciler's add, check for error or a response with a specified RetryAfter
-			req := ReconcileRequest{
-				Action: ReconcileActionCreated,
-				Object: obj,
-			}
-			c.doReconcile(ctx, reconciler, req, retryKey)
+		c.queueFor(resourceKind).push(c.keyForObject(resourceKind, obj), queuedEvent{
+			ctx:    ctx,
+			action: ResourceActionCreate,
+			object: obj,
 		})
 		return nil
 	}
 }
 
-// nolint:dupl
 func (c *InformerController) informerUpdateFunc(resourceKind string) func(context.Context, resource.Object, resource.Object) error {
 	return func(ctx context.Context, oldObj resource.Object, newObj resource.Object) error {
 		if newObj == nil {
 			return ErrNilObject
 		}
-
-		// Metrics for the whole reconcile process
-		eventStart := c.startEvent(string(ResourceActionUpdate), newObj.StaticMetadata().Kind)
-		defer c.completeEvent(string(ResourceActionUpdate), newObj.StaticMetadata().Kind, eventStart)
-
-		ctx, span := GetTracer().Start(ctx, "controller-event-update")
-		defer span.End()
-		// Handle all watchers for the update for this resource kind
-		c.watchers.Range(resourceKind, func(idx int, watcher ResourceWatcher) {
-			// Generate the unique key for this object
-			retryKey := c.keyForWatcherEvent(resourceKind, idx, newObj)
-
-			// Dequeue retries according to the RetryDequeuePolicy
-			c.dequeueIfRequired(retryKey, newObj, ResourceActionUpdate)
-
-			// Do the watcher's Update, check for error
-			c.wrapWatcherCall(string(ResourceActionUpdate), newObj.StaticMetadata().Kind, func() {
-				err := watcher.Update(ctx, oldObj, newObj)
-				if err != nil && c.ErrorHandler != nil {
-					c.ErrorHandler(ctx, err)
-				}
-				if err != nil && c.RetryPolicy != nil {
-					c.queueRetry(retryKey, err, func() (*time.Duration, error) {
-						ctx, span := GetTracer().Start(ctx, "controller-retry")
-						defer span.End()
-						return nil, watcher.Update(ctx, oldObj, newObj)
-					}, ResourceActionUpdate, newObj)
-				}
-			})
-		})
-		// Handle all reconcilers for the update for this resource kind
-		c.reconcilers.Range(resourceKind, func(index int, reconciler Reconciler) {
-			// Generate the unique key for this object
-			retryKey := c.keyForReconcilerEvent(resourceKind, index, newObj)
-
-			// Dequeue retries according to the RetryDequeuePolicy
-			c.dequeueIfRequired(retryKey, newObj, ResourceActionUpdate)
-
-			// Do the reconciler's update, check for error or a response with a specified RetryAfter
-			req := ReconcileRequest{
-				Action: ReconcileActionUpdated,
-				Object: newObj,
-			}
-			c.doReconcile(ctx, reconciler, req, retryKey)
+		c.queueFor(resourceKind).push(c.keyForObject(resourceKind, newObj), queuedEvent{
+			ctx:    ctx,
+			action: ResourceActionUpdate,
+			old:    oldObj,
+			object: newObj,
 		})
 		return nil
 	}
 }
 
-// nolint:dupl
 func (c *InformerController) informerDeleteFunc(resourceKind string) func(context.Context, resource.Object) error {
 	return func(ctx context.Context, obj resource.Object) error {
 		if obj == nil {
 			return ErrNilObject
 		}
+		c.queueFor(resourceKind).push(c.keyForObject(resourceKind, obj), queuedEvent{
+			ctx:    ctx,
+			action: ResourceActionDelete,
+			object: obj,
+		})
+		return nil
+	}
+}
 
-		// Metrics for the whole reconcile process
-		eventStart := c.startEvent(string(ResourceActionDelete), obj.StaticMetadata().Kind)
-		defer c.completeEvent(string(ResourceActionDelete), obj.StaticMetadata().Kind, eventStart)
-
-		ctx, span := GetTracer().Start(ctx, "controller-event-delete")
-		defer span.End()
-		// Handle all watchers for the add for this resource kind
-		c.watchers.Range(resourceKind, func(idx int, watcher ResourceWatcher) {
-			// Generate the unique key for this object
-			retryKey := c.keyForWatcherEvent(resourceKind, idx, obj)
-
-			// Dequeue retries according to the RetryDequeuePolicy
-			c.dequeueIfRequired(retryKey, obj, ResourceActionDelete)
-
-			c.inflightActions.WithLabelValues(string(ResourceActionUpdate), obj.StaticMetadata().Kind).Inc()
-			defer c.inflightActions.WithLabelValues(string(ResourceActionUpdate), obj.StaticMetadata().Kind).Dec()
-
-			// Do the watcher's Delete, check for error
-			c.wrapWatcherCall(string(ResourceActionDelete), obj.StaticMetadata().Kind, func() {
-				err := watcher.Delete(ctx, obj)
-				if err != nil && c.ErrorHandler != nil {
-					c.ErrorHandler(ctx, err) // TODO: improve ErrorHandler
-				}
-				if err != nil && c.RetryPolicy != nil {
-					c.queueRetry(retryKey, err, func() (*time.Duration, error) {
-						ctx, span := GetTracer().Start(ctx, "controller-retry")
-						defer span.End()
-						return nil, watcher.Delete(ctx, obj)
-					}, ResourceActionDelete, obj)
-				}
-			})
+// queueFor returns the eventQueue for the resource kind, creating it if it does not exist
+func (c *InformerController) queueFor(resourceKind string) *eventQueue {
+	c.queuesMux.Lock()
+	defer c.queuesMux.Unlock()
+	queue, ok := c.queues[resourceKind]
+	if !ok {
+		queue = newEventQueue(resourceKind, &workqueue.BucketRateLimiter{
+			Limiter: rate.NewLimiter(c.retryLimit, c.retryBurst),
 		})
-		// Handle all reconcilers for the add for this resource kind
-		c.reconcilers.Range(resourceKind, func(idx int, reconciler Reconciler) {
-			// Generate the unique key for this object
-			retryKey := c.keyForReconcilerEvent(resourceKind, idx, obj)
-
-			// Dequeue retries according to the RetryDequeuePolicy
-			c.dequeueIfRequired(retryKey, obj, ResourceActionDelete)
-
-			// Do the reconciler's add, check for error or a response with a specified RetryAfter
-			req := ReconcileRequest{
-				Action: ReconcileActionDeleted,
-				Object: obj,
+		c.queues[resourceKind] = queue
+	}
+	return queue
+}
+
+// runWorker processes keys from the queue until the queue is shut down
+func (c *InformerController) runWorker(resourceKind string, queue *eventQueue) {
+	for queue.processNext(func(key string) {
+		c.processKey(resourceKind, queue, key)
+	}) {
+	}
+}
+
+// processKey handles all pending events for the object key, in order, then any retries for the key which are due.
+// If there are remaining retries for the key, the key is re-added to the queue when the next one is due.
+func (c *InformerController) processKey(resourceKind string, queue *eventQueue, key string) {
+	for _, event := range queue.pop(key) {
+		switch event.action {
+		case ResourceActionCreate:
+			c.handleAdd(event.ctx, resourceKind, event.object)
+		case ResourceActionUpdate:
+			c.handleUpdate(event.ctx, resourceKind, event.old, event.object)
+		case ResourceActionDelete:
+			c.handleDelete(event.ctx, resourceKind, event.object)
+		}
+	}
+
+	c.runRetries(key, time.Now())
+
+	// Find the next retry time for the key, if any
+	var next time.Time
+	c.toRetry.Range(key, func(_ int, info retryInfo) {
+		if next.IsZero() || info.retryAfter.Before(next) {
+			next = info.retryAfter
+		}
+	})
+	if next.IsZero() {
+		queue.forgetRetries(key)
+		return
+	}
+	queue.scheduleRetry(key, next)
+}
+
+// nolint:dupl
+// handleAdd calls all watchers and reconcilers for the resource kind for an add event
+func (c *InformerController) handleAdd(ctx context.Context, resourceKind string, obj resource.Object) {
+	objectKey := c.keyForObject(resourceKind, obj)
+
+	// Metrics for the whole reconcile process
+	eventStart := c.startEvent(string(ResourceActionCreate), obj.StaticMetadata().Kind)
+	defer c.completeEvent(string(ResourceActionCreate), obj.StaticMetadata().Kind, eventStart)
+
+	ctx, span := GetTracer().Start(ctx, "controller-event-add")
+	defer span.End()
+	// Handle all watchers for the add for this resource kind
+	c.watchers.Range(resourceKind, func(idx int, watcher ResourceWatcher) {
+		// Generate the unique key for this object
+		retryKey := c.keyForWatcherEvent(resourceKind, idx, obj)
+
+		// Dequeue retries according to the RetryDequeuePolicy
+		c.dequeueIfRequired(objectKey, retryKey, obj, ResourceActionCreate)
+
+		// Do the watcher's Add, check for error
+		c.wrapWatcherCall(string(ResourceActionCreate), obj.StaticMetadata().Kind, func() {
+			err := watcher.Add(ctx, obj)
+			if err != nil && c.ErrorHandler != nil {
+				c.ErrorHandler(ctx, err) // TODO: improve ErrorHandler
+			}
+			if err != nil && c.RetryPolicy != nil {
+				c.queueRetry(objectKey, retryKey, err, func() (*time.Duration, error) {
+					ctx, span := GetTracer().Start(ctx, "controller-retry")
+					defer span.End()
+					return nil, watcher.Add(ctx, obj)
+				}, ResourceActionCreate, obj)
 			}
+		})
+	})
+	// Handle all reconcilers for the add for this resource kind
+	c.reconcilers.Range(resourceKind, func(idx int, reconciler Reconciler) {
+		// Generate the unique key for this object
+		retryKey := c.keyForReconcilerEvent(resourceKind, idx, obj)
+
+		// Dequeue retries according to the RetryDequeuePolicy
+		c.dequeueIfRequired(objectKey, retryKey, obj, ResourceActionCreate)
+
+		// Do the reconciler's add, check for error or a response with a specified RetryAfter
+		req := ReconcileRequest{
+			Action: ReconcileActionCreated,
+			Object: obj,
+		}
+		c.doReconcile(ctx, reconciler, req, objectKey, retryKey)
+	})
+}
+
+// nolint:dupl
+// handleUpdate calls all watchers and reconcilers for the resource kind for an update event
+func (c *InformerController) handleUpdate(ctx context.Context, resourceKind string, oldObj, newObj resource.Object) {
+	objectKey := c.keyForObject(resourceKind, newObj)
+
+	// Metrics for the whole reconcile process
+	eventStart := c.startEvent(string(ResourceActionUpdate), newObj.StaticMetadata().Kind)
+	defer c.completeEvent(string(ResourceActionUpdate), newObj.StaticMetadata().Kind, eventStart)
 
-			c.doReconcile(ctx, reconciler, req, retryKey)
+	ctx, span := GetTracer().Start(ctx, "controller-event-update")
+	defer span.End()
+	// Handle all watchers for the update for this resource kind
+	c.watchers.Range(resourceKind, func(idx int, watcher ResourceWatcher) {
+		// Generate the unique key for this object
+		retryKey := c.keyForWatcherEvent(resourceKind, idx, newObj)
+
+		// Dequeue retries according to the RetryDequeuePolicy
+		c.dequeueIfRequired(objectKey, retryKey, newObj, ResourceActionUpdate)
+
+		// Do the watcher's Update, check for error
+		c.wrapWatcherCall(string(ResourceActionUpdate), newObj.StaticMetadata().Kind, func() {
+			err := watcher.Update(ctx, oldObj, newObj)
+			if err != nil && c.ErrorHandler != nil {
+				c.ErrorHandler(ctx, err)
+			}
+			if err != nil && c.RetryPolicy != nil {
+				c.queueRetry(objectKey, retryKey, err, func() (*time.Duration, error) {
+					ctx, span := GetTracer().Start(ctx, "controller-retry")
+					defer span.End()
+					return nil, watcher.Update(ctx, oldObj, newObj)
+				}, ResourceActionUpdate, newObj)
+			}
 		})
-		return nil
-	}
+	})
+	// Handle all reconcilers for the update for this resource kind
+	c.reconcilers.Range(resourceKind, func(index int, reconciler Reconciler) {
+		// Generate the unique key for this object
+		retryKey := c.keyForReconcilerEvent(resourceKind, index, newObj)
+
+		// Dequeue retries according to the RetryDequeuePolicy
+		c.dequeueIfRequired(objectKey, retryKey, newObj, ResourceActionUpdate)
+
+		// Do the reconciler's update, check for error or a response with a specified RetryAfter
+		req := ReconcileRequest{
+			Action: ReconcileActionUpdated,
+			Object: newObj,
+		}
+		c.doReconcile(ctx, reconciler, req, objectKey, retryKey)
+	})
 }
 
-func (c *InformerController) dequeueIfRequired(retryKey string, currentObjectState resource.Object, action ResourceAction) {
-	if c.RetryDequeuePolicy != nil {
-		c.toRetry.RemoveItems(retryKey, func(info retryInfo) bool {
+// nolint:dupl
+// handleDelete calls all watchers and reconcilers for the resource kind for a delete event
+func (c *InformerController) handleDelete(ctx context.Context, resourceKind string, obj resource.Object) {
+	objectKey := c.keyForObject(resourceKind, obj)
+
+	// Metrics for the whole reconcile process
+	eventStart := c.startEvent(string(ResourceActionDelete), obj.StaticMetadata().Kind)
+	defer c.completeEvent(string(ResourceActionDelete), obj.StaticMetadata().Kind, eventStart)
+
+	ctx, span := GetTracer().Start(ctx, "controller-event-delete")
+	defer span.End()
+	// Handle all watchers for the add for this resource kind
+	c.watchers.Range(resourceKind, func(idx int, watcher ResourceWatcher) {
+		// Generate the unique key for this object
+		retryKey := c.keyForWatcherEvent(resourceKind, idx, obj)
+
+		// Dequeue retries according to the RetryDequeuePolicy
+		c.dequeueIfRequired(objectKey, retryKey, obj, ResourceActionDelete)
+
+		c.inflightActions.WithLabelValues(string(ResourceActionUpdate), obj.StaticMetadata().Kind).Inc()
+		defer c.inflightActions.WithLabelValues(string(ResourceActionUpdate), obj.StaticMetadata().Kind).Dec()
+
+		// Do the watcher's Delete, check for error
+		c.wrapWatcherCall(string(ResourceActionDelete), obj.StaticMetadata().Kind, func() {
+			err := watcher.Delete(ctx, obj)
+			if err != nil && c.ErrorHandler != nil {
+				c.ErrorHandler(ctx, err) // TODO: improve ErrorHandler
+			}
+			if err != nil && c.RetryPolicy != nil {
+				c.queueRetry(objectKey, retryKey, err, func() (*time.Duration, error) {
+					ctx, span := GetTracer().Start(ctx, "controller-retry")
+					defer span.End()
+					return nil, watcher.Delete(ctx, obj)
+				}, ResourceActionDelete, obj)
+			}
+		})
+	})
+	// Handle all reconcilers for the add for this resource kind
+	c.reconcilers.Range(resourceKind, func(idx int, reconciler Reconciler) {
+		// Generate the unique key for this object
+		retryKey := c.keyForReconcilerEvent(resourceKind, idx, obj)
+
+		// Dequeue retries according to the RetryDequeuePolicy
+		c.dequeueIfRequired(objectKey, retryKey, obj, ResourceActionDelete)
+
+		// Do the reconciler's add, check for error or a response with a specified RetryAfter
+		req := ReconcileRequest{
+			Action: ReconcileActionDeleted,
+			Object: obj,
+		}
+
+		c.doReconcile(ctx, reconciler, req, objectKey, retryKey)
+	})
+}
+
+// dequeueIfRequired removes the object's pending retries for the handler key according to the RetryDequeuePolicy
+func (c *InformerController) dequeueIfRequired(objectKey, retryKey string, currentObjectState resource.Object, action ResourceAction) {
+	c.toRetry.RemoveItems(objectKey, func(info retryInfo) bool {
+		if info.handlerKey != retryKey {
+			return false
+		}
+		if c.RetryDequeuePolicy != nil {
 			return c.RetryDequeuePolicy(action, currentObjectState, info.action, info.object, info.err)
-		}, -1)
-	} else {
+		}
 		// If no RetryDequeuePolicy exists, dequeue all retries for the object
-		c.toRetry.RemoveKey(retryKey)
-	}
+		return true
+	}, -1)
 }
 
-func (c *InformerController) doReconcile(ctx context.Context, reconciler Reconciler, req ReconcileRequest, retryKey string) {
+func (c *InformerController) doReconcile(ctx context.Context, reconciler Reconciler, req ReconcileRequest, objectKey, retryKey string) {
 	// Metrics for the reconcile action
 	action := ResourceActionFromReconcileAction(req.Action)
 	if c.inflightActions != nil {
@@ -491,7 +662,9 @@ func (c *InformerController) doReconcile(ctx context.Context, reconciler Reconci
 	}
 	if c.reconcilerLatency != nil {
 		start := time.Now()
-		defer c.reconcilerLatency.WithLabelValues(string(action), req.Object.StaticMetadata().Kind).Observe(time.Since(start).Seconds())
+		defer func() {
+			c.reconcilerLatency.WithLabelValues(string(action), req.Object.StaticMetadata().Kind).Observe(time.Since(start).Seconds())
+		}()
 	}
 
 	ctx, span := GetTracer().Start(ctx, "controller-event-reconcile")
@@ -504,7 +677,8 @@ func (c *InformerController) doReconcile(ctx context.Context, reconciler Reconci
 	}
 	if res.RequeueAfter != nil {
 		// If RequeueAfter is non-nil, add a retry to the queue for now+RequeueAfter
-		c.toRetry.AddItem(retryKey, retryInfo{
+		c.toRetry.AddItem(objectKey, retryInfo{
+			handlerKey: retryKey,
 			retryAfter: time.Now().Add(*res.RequeueAfter),
 			retryFunc: func() (*time.Duration, error) {
 				res, err := reconciler.Reconcile(ctx, req)
@@ -516,7 +690,7 @@ func (c *InformerController) doReconcile(ctx context.Context, reconciler Reconci
 		})
 	} else if err != nil {
 		// Otherwise, if err is non-nil, queue a retry according to the RetryPolicy
-		c.queueRetry(retryKey, err, func() (*time.Duration, error) {
+		c.queueRetry(objectKey, retryKey, err, func() (*time.Duration, error) {
 			ctx, span := GetTracer().Start(ctx, "controller-retry")
 			defer span.End()
 			res, err := reconciler.Reconcile(ctx, req)
@@ -525,52 +699,42 @@ func (c *InformerController) doReconcile(ctx context.Context, reconciler Reconci
 	}
 }
 
-// retryTicker blocks until stopCh is closed or receives a message.
-// It checks if there are function calls to be retried every second, and, if there are any, calls the function.
-// If the function returns an error, it schedules a new retry according to the RetryPolicy.
-func (c *InformerController) retryTicker(stopCh <-chan struct{}) {
-	ticker := time.NewTicker(c.retryTickerInterval)
-	defer ticker.Stop()
-	for {
-		select {
-		case t := <-ticker.C:
-			for _, key := range c.toRetry.Keys() {
-				// To be simple, we retry all retries which should be done now, and remove them from the list
-				// We then add back in retries which failed and need to be retried again
-				toAdd := make([]retryInfo, 0)
-				c.toRetry.RemoveItems(key, func(val retryInfo) bool {
-					if t.After(val.retryAfter) {
-						specifiedRetry, err := val.retryFunc()
-						if specifiedRetry != nil {
-							toAdd = append(toAdd, retryInfo{
-								attempt:    val.attempt, // TODO: whether or not this should trigger an attempt increase
-								retryAfter: t.Add(*specifiedRetry),
-								retryFunc:  val.retryFunc,
-								action:     val.action,
-								object:     val.object,
-							})
-						} else if err != nil && c.RetryPolicy != nil {
-							ok, after := c.RetryPolicy(err, val.attempt+1)
-							if ok {
-								toAdd = append(toAdd, retryInfo{
-									attempt:    val.attempt + 1,
-									retryAfter: t.Add(after),
-									retryFunc:  val.retryFunc,
-									action:     val.action,
-									object:     val.object,
-								})
-							}
-						}
-						return true
-					}
-					return false
-				}, -1)
-				for _, inf := range toAdd {
-					c.toRetry.AddItem(key, inf)
-				}
+// runRetries calls all retries for the object key which are due at time t, and removes them from the list.
+// Retries which fail and should be retried again according to the RetryPolicy
+// (or which specify a new retry time) are added back to the list.
+func (c *InformerController) runRetries(objectKey string, t time.Time) {
+	due := make([]retryInfo, 0)
+	c.toRetry.RemoveItems(objectKey, func(val retryInfo) bool {
+		if !t.Before(val.retryAfter) {
+			due = append(due, val)
+			return true
+		}
+		return false
+	}, -1)
+	for _, val := range due {
+		specifiedRetry, err := val.retryFunc()
+		if specifiedRetry != nil {
+			c.toRetry.AddItem(objectKey, retryInfo{
+				handlerKey: val.handlerKey,
+				attempt:    val.attempt, // TODO: whether or not this should trigger an attempt increase
+				retryAfter: time.Now().Add(*specifiedRetry),
+				retryFunc:  val.retryFunc,
+				action:     val.action,
+				object:     val.object,
+			})
+		} else if err != nil && c.RetryPolicy != nil {
+			ok, after := c.RetryPolicy(err, val.attempt+1)
+			if ok {
+				c.toRetry.AddItem(objectKey, retryInfo{
+					handlerKey: val.handlerKey,
+					attempt:    val.attempt + 1,
+					retryAfter: time.Now().Add(after),
+					retryFunc:  val.retryFunc,
+					action:     val.action,
+					object:     val.object,
+					err:        err,
+				})
 			}
-		case <-stopCh:
-			return
 		}
 	}
 }
@@ -606,6 +770,13 @@ func (c *InformerController) wrapWatcherCall(eventType string, resourceKind stri
 	}
 }
 
+func (*InformerController) keyForObject(resourceKind string, obj resource.Object) string {
+	if obj == nil {
+		return fmt.Sprintf("%s:nil:nil", resourceKind)
+	}
+	return fmt.Sprintf("%s:%s:%s", resourceKind, obj.StaticMetadata().Namespace, obj.StaticMetadata().Name)
+}
+
 func (*InformerController) keyForWatcherEvent(resourceKind string, watcherIndex int, obj resource.Object) string {
 	if obj == nil {
 		return fmt.Sprintf("%s:%d:nil:nil", resourceKind, watcherIndex)
@@ -620,13 +791,14 @@ func (*InformerController) keyForReconcilerEvent(resourceKind string, reconciler
 	return fmt.Sprintf("reconcile:%s:%d:%s:%s", resourceKind, reconcilerIndex, obj.StaticMetadata().Namespace, obj.StaticMetadata().Name)
 }
 
-func (c *InformerController) queueRetry(key string, err error, toRetry func() (*time.Duration, error), action ResourceAction, obj resource.Object) {
+func (c *InformerController) queueRetry(objectKey, retryKey string, err error, toRetry func() (*time.Duration, error), action ResourceAction, obj resource.Object) {
 	if c.RetryPolicy == nil {
 		return
 	}
 
 	if ok, after := c.RetryPolicy(err, 0); ok {
-		c.toRetry.AddItem(key, retryInfo{
+		c.toRetry.AddItem(objectKey, retryInfo{
+			handlerKey: retryKey,
 			retryAfter: time.Now().Add(after),
 			retryFunc:  toRetry,
 			action:     action,
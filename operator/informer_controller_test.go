@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/grafana/grafana-app-sdk/resource"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInformerController_AddWatcher(t *testing.T) {
@@ -409,6 +411,52 @@ func TestInformerController_Run(t *testing.T) {
 	})
 }
 
+func TestInformerController_Warm(t *testing.T) {
+	kind := "foo"
+	informerRuns := atomic.Int32{}
+	addCalls := atomic.Int32{}
+	added := make(chan struct{}, 1)
+	inf := &testInformer{}
+	c := NewInformerController(InformerControllerConfig{})
+	c.AddWatcher(&SimpleWatcher{
+		AddFunc: func(ctx context.Context, object resource.Object) error {
+			addCalls.Add(1)
+			added <- struct{}{}
+			return nil
+		},
+	}, kind)
+	c.AddInformer(&mockInformer{
+		AddEventHandlerFunc: func(handler ResourceWatcher) {
+			inf.AddEventHandler(handler)
+		},
+		RunFunc: func(stopCh <-chan struct{}) error {
+			informerRuns.Add(1)
+			<-stopCh
+			return nil
+		},
+	}, kind)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	require.Nil(t, c.Warm(stopCh))
+	assert.Eventually(t, func() bool { return informerRuns.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Events are queued, but not processed, while the controller is only warm
+	inf.FireAdd(context.Background(), emptyObject)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), addCalls.Load())
+
+	// Running the controller processes the queued events, without starting the informers again
+	go c.Run(stopCh)
+	select {
+	case <-added:
+	case <-time.After(time.Second):
+		t.Fatal("queued event was not processed")
+	}
+	assert.Equal(t, int32(1), addCalls.Load())
+	assert.Equal(t, int32(1), informerRuns.Load())
+}
+
 func TestInformerController_Run_WithWatcherAndReconciler(t *testing.T) {
 	t.Run("no errors", func(t *testing.T) {
 		kind := "foo"
@@ -416,15 +464,20 @@ func TestInformerController_Run_WithWatcherAndReconciler(t *testing.T) {
 		reconcileCalls := 0
 		inf := &testInformer{}
 		c := NewInformerController(InformerControllerConfig{})
+		// Events are processed asynchronously by the kind's workers, so wait for both calls
+		wg := sync.WaitGroup{}
+		wg.Add(2)
 		c.AddWatcher(&SimpleWatcher{
 			AddFunc: func(ctx context.Context, object resource.Object) error {
 				addCalls++
+				wg.Done()
 				return nil
 			},
 		}, kind)
 		c.AddReconciler(&SimpleReconciler{
 			ReconcileFunc: func(ctx context.Context, request ReconcileRequest) (ReconcileResult, error) {
 				reconcileCalls++
+				wg.Done()
 				return ReconcileResult{}, nil
 			},
 		}, kind)
@@ -434,6 +487,7 @@ func TestInformerController_Run_WithWatcherAndReconciler(t *testing.T) {
 		stopCh := make(chan struct{})
 		go c.Run(stopCh)
 		inf.FireAdd(context.Background(), emptyObject)
+		wg.Wait()
 		close(stopCh)
 		assert.Equal(t, 1, addCalls)
 		assert.Equal(t, 1, reconcileCalls)
@@ -451,7 +505,6 @@ func TestInformerController_Run_WithWatcherAndReconciler(t *testing.T) {
 			}
 			return true, time.Millisecond * 50
 		}
-		c.retryTickerInterval = time.Millisecond * 50
 		wg := sync.WaitGroup{}
 		wg.Add(2)
 		c.AddWatcher(&SimpleWatcher{
@@ -491,7 +544,6 @@ func TestInformerController_Run_WithWatcherAndReconciler(t *testing.T) {
 			}
 			return true, time.Millisecond * 50
 		}
-		c.retryTickerInterval = time.Millisecond * 50
 		wg := sync.WaitGroup{}
 		wg.Add(2)
 		c.AddWatcher(&SimpleWatcher{
@@ -540,7 +592,6 @@ func TestInformerController_Run_WithWatcherAndReconciler(t *testing.T) {
 			}
 			return true, time.Millisecond * 50
 		}
-		c.retryTickerInterval = time.Millisecond * 50
 		wg := sync.WaitGroup{}
 		wg.Add(4)
 		c.AddWatcher(&SimpleWatcher{
@@ -590,7 +641,6 @@ func TestInformerController_Run_WithWatcherAndReconciler(t *testing.T) {
 			}
 			return true, time.Millisecond * 50
 		}
-		c.retryTickerInterval = time.Millisecond * 50
 		wg := sync.WaitGroup{}
 		wg.Add(2)
 		c.AddWatcher(&SimpleWatcher{
@@ -630,7 +680,6 @@ func TestInformerController_Run_WithWatcherAndReconciler(t *testing.T) {
 			}
 			return true, time.Millisecond * 50
 		}
-		c.retryTickerInterval = time.Millisecond * 50
 		wg := sync.WaitGroup{}
 		wg.Add(4)
 		c.AddWatcher(&SimpleWatcher{
@@ -727,8 +776,6 @@ func TestInformerController_Run_WithRetries(t *testing.T) {
 			},
 		}
 		c := NewInformerController(InformerControllerConfig{})
-		// Make the retry ticker interval a half-second so we can run this test faster
-		c.retryTickerInterval = time.Millisecond * 500
 		// 500-ms linear retry policy
 		c.RetryPolicy = func(err error, attempt int) (bool, time.Duration) {
 			return true, time.Millisecond * 500
@@ -783,8 +830,6 @@ func TestInformerController_Run_WithRetries(t *testing.T) {
 			},
 		}
 		c := NewInformerController(InformerControllerConfig{})
-		// Make the retry ticker interval a 50 ms so we can run this test faster
-		c.retryTickerInterval = time.Millisecond * 50
 		// 500-ms linear retry policy
 		c.RetryPolicy = func(err error, attempt int) (bool, time.Duration) {
 			return true, time.Millisecond * 50
@@ -840,8 +885,6 @@ func TestInformerController_Run_WithRetries(t *testing.T) {
 			},
 		}
 		c := NewInformerController(InformerControllerConfig{})
-		// Make the retry ticker interval a 50 ms so we can run this test faster
-		c.retryTickerInterval = time.Millisecond * 50
 		// 500-ms linear retry policy
 		c.RetryPolicy = func(err error, attempt int) (bool, time.Duration) {
 			return attempt < 3, time.Millisecond * 50
@@ -894,7 +937,6 @@ func TestInformerController_Run_WithRetriesAndDequeuePolicy(t *testing.T) {
 			ret := <-retryResponse
 			return ret, time.Second
 		}
-		c.retryTickerInterval = time.Millisecond * 50
 		c.RetryDequeuePolicy = OpinionatedRetryDequeuePolicy
 		c.AddInformer(inf, "foo")
 		c.AddWatcher(&SimpleWatcher{
@@ -954,7 +996,6 @@ func TestInformerController_Run_WithRetriesAndDequeuePolicy(t *testing.T) {
 			ret := <-retryResponse
 			return ret, time.Second
 		}
-		c.retryTickerInterval = time.Millisecond * 50
 		c.RetryDequeuePolicy = OpinionatedRetryDequeuePolicy
 		c.AddInformer(inf, "foo")
 		c.AddWatcher(&SimpleWatcher{
@@ -1118,6 +1159,125 @@ func (i *mockInformer) Run(stopCh <-chan struct{}) error {
 	return nil
 }
 
+func TestInformerController_Run_Workers(t *testing.T) {
+	slowObject := &resource.SimpleObject[string]{BasicMetadataObject: resource.BasicMetadataObject{
+		StaticMeta: resource.StaticMetadata{Namespace: "default", Name: "slow"},
+	}}
+	fastObject := &resource.SimpleObject[string]{BasicMetadataObject: resource.BasicMetadataObject{
+		StaticMeta: resource.StaticMetadata{Namespace: "default", Name: "fast"},
+	}}
+	release := make(chan struct{})
+	fastDone := make(chan struct{})
+	inf := &testInformer{}
+	c := NewInformerController(InformerControllerConfig{
+		WorkersPerKind: 2,
+	})
+	c.AddWatcher(&SimpleWatcher{
+		AddFunc: func(ctx context.Context, object resource.Object) error {
+			if object.StaticMetadata().Name == "slow" {
+				<-release
+				return nil
+			}
+			close(fastDone)
+			return nil
+		},
+	}, "foo")
+	c.AddInformer(inf, "foo")
+
+	stopCh := make(chan struct{})
+	go c.Run(stopCh)
+	defer close(stopCh)
+	inf.FireAdd(context.Background(), slowObject)
+	inf.FireAdd(context.Background(), fastObject)
+	// The slow object's worker is blocked, but the other worker should still process the fast object
+	select {
+	case <-fastDone:
+	case <-time.After(time.Second):
+		t.Fatal("fast object was blocked by slow object")
+	}
+	close(release)
+}
+
+func TestInformerController_Run_RetryRateLimit(t *testing.T) {
+	hotErr := errors.New("hot")
+	retriedErr := errors.New("retried")
+	hotObject := &resource.SimpleObject[string]{BasicMetadataObject: resource.BasicMetadataObject{
+		StaticMeta: resource.StaticMetadata{Namespace: "default", Name: "hot"},
+	}}
+	retriedObject := &resource.SimpleObject[string]{BasicMetadataObject: resource.BasicMetadataObject{
+		StaticMeta: resource.StaticMetadata{Namespace: "default", Name: "retried"},
+	}}
+	newObject := &resource.SimpleObject[string]{BasicMetadataObject: resource.BasicMetadataObject{
+		StaticMeta: resource.StaticMetadata{Namespace: "default", Name: "new"},
+	}}
+	var hotUpdates, retriedAdds atomic.Int32
+	newAdded := make(chan struct{})
+	inf := &testInformer{}
+	c := NewInformerController(InformerControllerConfig{
+		WorkersPerKind:      2,
+		MaxRetriesPerSecond: 2,
+		MaxRetryBurst:       1,
+	})
+	c.RetryPolicy = func(err error, _ int) (bool, time.Duration) {
+		if errors.Is(err, hotErr) {
+			// Keep the hot object's retry pending for the whole test
+			return true, time.Minute
+		}
+		return true, 0
+	}
+	// Keep retries pending when there are new events for the object
+	c.RetryDequeuePolicy = func(ResourceAction, resource.Object, ResourceAction, resource.Object, error) bool {
+		return false
+	}
+	c.AddWatcher(&SimpleWatcher{
+		AddFunc: func(ctx context.Context, object resource.Object) error {
+			switch object.StaticMetadata().Name {
+			case "hot":
+				return hotErr
+			case "retried":
+				retriedAdds.Add(1)
+				return retriedErr
+			}
+			close(newAdded)
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, _ resource.Object, object resource.Object) error {
+			hotUpdates.Add(1)
+			return nil
+		},
+	}, "foo")
+	c.AddInformer(inf, "foo")
+
+	stopCh := make(chan struct{})
+	go c.Run(stopCh)
+	defer close(stopCh)
+
+	// The hot object has a pending retry, and many events, which must not use up the rate limit for retries
+	inf.FireAdd(context.Background(), hotObject)
+	for i := 0; i < 20; i++ {
+		inf.FireUpdate(context.Background(), hotObject, hotObject)
+		time.Sleep(5 * time.Millisecond)
+	}
+	require.Eventually(t, func() bool {
+		return hotUpdates.Load() > 10
+	}, time.Second, 10*time.Millisecond)
+
+	// Retries of the always-failing object are rate limited
+	inf.FireAdd(context.Background(), retriedObject)
+	time.Sleep(2 * time.Second)
+	adds := retriedAdds.Load()
+	assert.GreaterOrEqual(t, adds, int32(3), "retries should not be blocked by the hot object's events")
+	assert.LessOrEqual(t, adds, int32(7), "retries should be rate limited")
+
+	// New objects are processed immediately, even though retries are being rate limited
+	inf.FireAdd(context.Background(), newObject)
+	select {
+	case <-newAdded:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("new object was rate limited")
+	}
+}
+
 type testInformer struct {
 	handlers []ResourceWatcher
 	onStop   func()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -101,8 +102,9 @@ func NewKubernetesBasedInformerWithFilters(sch resource.Schema, client ListWatch
 					}
 					// If we can't extract a pure watch.Interface from the watch response, we have to make one
 					w := &watchWrapper{
-						watch: watchResp,
-						ch:    make(chan watch.Event),
+						watch:  watchResp,
+						ch:     make(chan watch.Event),
+						stopCh: make(chan struct{}),
 					}
 					go w.start()
 					return w, nil
@@ -214,6 +216,32 @@ func (k *KubernetesBasedInformer) Schema() resource.Schema {
 	return k.schema
 }
 
+// AddIndexers adds custom indexers to the informer's cache, which can then be queried with InformerCache.ByIndex.
+// Indexers must be added before the informer is run, otherwise an error is returned.
+func (k *KubernetesBasedInformer) AddIndexers(indexers map[string]IndexFunc) error {
+	converted := make(cache.Indexers)
+	for name, indexFunc := range indexers {
+		f := indexFunc
+		converted[name] = func(obj any) ([]string, error) {
+			cast, err := k.toResourceObject(obj)
+			if err != nil {
+				return nil, err
+			}
+			return f(cast)
+		}
+	}
+	return k.SharedIndexInformer.AddIndexers(converted)
+}
+
+// Cache returns a read-only InformerCache backed by the informer's local store.
+// The cache is only populated once the informer is running, use InformerCache.HasSynced to check if
+// the initial list has been completed.
+func (k *KubernetesBasedInformer) Cache() *InformerCache {
+	return &InformerCache{
+		informer: k,
+	}
+}
+
 func (k *KubernetesBasedInformer) toResourceObject(obj any) (resource.Object, error) {
 	// First, check if it's already a resource.Object
 	if cast, ok := obj.(resource.Object); ok {
@@ -342,13 +370,18 @@ func (o *objectWrapper) ResourceObject() resource.Object {
 }
 
 type watchWrapper struct {
-	watch resource.WatchResponse
-	ch    chan watch.Event
+	watch    resource.WatchResponse
+	ch       chan watch.Event
+	stopCh   chan struct{}
+	stopOnce sync.Once
 }
 
 func (w *watchWrapper) start() {
+	// Closing the channel here rather than in Stop ensures we never send on a closed channel
+	defer close(w.ch)
 	for e := range w.watch.WatchEvents() {
-		w.ch <- watch.Event{
+		select {
+		case w.ch <- watch.Event{
 			Type: watch.EventType(e.EventType),
 			Object: &objectWrapper{
 				ObjectMeta: metav1.ObjectMeta{
@@ -358,13 +391,18 @@ func (w *watchWrapper) start() {
 				},
 				Object: e.Object,
 			},
+		}:
+		case <-w.stopCh:
+			return
 		}
 	}
 }
 
 func (w *watchWrapper) Stop() {
-	w.watch.Stop()
-	close(w.ch)
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+		w.watch.Stop()
+	})
 }
 
 func (w *watchWrapper) ResultChan() <-chan watch.Event {
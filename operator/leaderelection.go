@@ -0,0 +1,217 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+const (
+	// DefaultLeaseDuration is the default LeaderElectionConfig.LeaseDuration
+	DefaultLeaseDuration = 15 * time.Second
+	// DefaultRenewDeadline is the default LeaderElectionConfig.RenewDeadline
+	DefaultRenewDeadline = 10 * time.Second
+	// DefaultRetryPeriod is the default LeaderElectionConfig.RetryPeriod
+	DefaultRetryPeriod = 2 * time.Second
+)
+
+// ErrLeadershipLost is returned by Operator.Run when leader election is enabled and the operator loses leadership.
+// Controllers cannot be safely restarted once stopped, so the operator process should exit (and be restarted)
+// when this error is returned.
+var ErrLeadershipLost = errors.New("operator lost leadership")
+
+// LeaderElectionLock is the lock used by an Operator to elect a leader.
+// Any implementation of the client-go resourcelock.Interface can be used, such as the Lease lock returned by
+// k8s.NewLeaseLock, or a lock stored using any resource.Client via NewResourceLock.
+type LeaderElectionLock = resourcelock.Interface
+
+// LeaderElectionConfig is the configuration for leader election in an Operator.
+type LeaderElectionConfig struct {
+	// Lock is the lock used to elect a leader. All operator replicas must use a lock backed by the same object,
+	// and each replica must use a unique identity.
+	Lock LeaderElectionLock
+	// LeaseDuration is the duration that non-leader replicas will wait after the last observed renewal
+	// before attempting to acquire leadership. Defaults to DefaultLeaseDuration.
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration that the leader will retry refreshing leadership before giving it up.
+	// It must be less than LeaseDuration. Defaults to DefaultRenewDeadline.
+	RenewDeadline time.Duration
+	// RetryPeriod is the duration replicas wait between attempts to acquire or renew leadership.
+	// Defaults to DefaultRetryPeriod.
+	RetryPeriod time.Duration
+	// ReleaseOnStop will release the lock when the operator is stopped, allowing another replica to acquire
+	// leadership without waiting for the lease to expire.
+	ReleaseOnStop bool
+	// OnStartedLeading is called when the operator becomes the leader, before its controllers are started.
+	// The provided context is canceled when leadership is lost or the operator is stopped.
+	OnStartedLeading func(ctx context.Context)
+	// OnStoppedLeading is called when the operator stops being the leader, either because leadership was lost,
+	// or because the operator was stopped. It is not called if the operator never became the leader.
+	OnStoppedLeading func()
+	// OnNewLeader is called when a new leader is observed, including when this operator becomes the leader.
+	OnNewLeader func(identity string)
+}
+
+// EnableLeaderElection turns on leader election for the operator. When leader election is enabled,
+// Operator.Run will wait until it acquires leadership before starting any controllers,
+// so only one replica of the operator runs controllers at a time. Non-leader replicas keep contending
+// for leadership, and start their controllers as soon as they acquire it. Controllers which implement
+// WarmableController (such as InformerController) are warmed up on all replicas, so followers keep their informers
+// and caches running, and only the processing of events by watchers and reconcilers waits for leadership.
+// If leadership is lost, all controllers are stopped and Run returns ErrLeadershipLost.
+// It must be called before Run.
+func (o *Operator) EnableLeaderElection(config LeaderElectionConfig) error {
+	if config.Lock == nil {
+		return errors.New("leader election lock cannot be nil")
+	}
+	if config.LeaseDuration == 0 {
+		config.LeaseDuration = DefaultLeaseDuration
+	}
+	if config.RenewDeadline == 0 {
+		config.RenewDeadline = DefaultRenewDeadline
+	}
+	if config.RetryPeriod == 0 {
+		config.RetryPeriod = DefaultRetryPeriod
+	}
+	if config.LeaseDuration <= config.RenewDeadline {
+		return errors.New("lease duration must be greater than renew deadline")
+	}
+	if config.RenewDeadline <= config.RetryPeriod {
+		return errors.New("renew deadline must be greater than retry period")
+	}
+	o.leaderElection = &config
+	return nil
+}
+
+// NewResourceLock returns a LeaderElectionLock which stores the leader election record in the spec of the object
+// with the provided identifier, using the provided resource.Client. The client's schema must have a spec
+// which can be marshaled to and from a resourcelock.LeaderElectionRecord, such as
+// resource.SimpleObject[resourcelock.LeaderElectionRecord]. Updates use the object's resourceVersion,
+// so the client's storage must reject conflicting updates.
+// This is primarily useful for running leader election against non-kubernetes storage, or an in-memory client in tests.
+//
+// The times in a resourcelock.LeaderElectionRecord are stored to the second, so renewals within the same second
+// don't change the stored record, and followers may observe renewals up to a second late.
+// LeaderElectionConfig.LeaseDuration should therefore be several seconds longer than RetryPeriod.
+func NewResourceLock(client resource.Client, identifier resource.Identifier, identity string) LeaderElectionLock {
+	return &resourceLock{
+		client:     client,
+		identifier: identifier,
+		identity:   identity,
+	}
+}
+
+type resourceLock struct {
+	client     resource.Client
+	identifier resource.Identifier
+	identity   string
+	mux        sync.Mutex
+	object     resource.Object
+}
+
+// Get returns the leader election record from the stored object
+func (r *resourceLock) Get(ctx context.Context) (*resourcelock.LeaderElectionRecord, []byte, error) {
+	obj, err := r.client.Get(ctx, r.identifier)
+	if err != nil {
+		return nil, nil, r.translateError(err)
+	}
+	raw, err := json.Marshal(obj.SpecObject())
+	if err != nil {
+		return nil, nil, err
+	}
+	record := resourcelock.LeaderElectionRecord{}
+	if err = json.Unmarshal(raw, &record); err != nil {
+		return nil, nil, err
+	}
+	r.mux.Lock()
+	r.object = obj
+	r.mux.Unlock()
+	return &record, raw, nil
+}
+
+// Create creates the stored object with the provided leader election record
+func (r *resourceLock) Create(ctx context.Context, record resourcelock.LeaderElectionRecord) error {
+	obj, err := r.client.Create(ctx, r.identifier, &resource.SimpleObject[resourcelock.LeaderElectionRecord]{
+		Spec: record,
+	}, resource.CreateOptions{})
+	if err != nil {
+		return r.translateError(err)
+	}
+	r.mux.Lock()
+	r.object = obj
+	r.mux.Unlock()
+	return nil
+}
+
+// Update updates the stored object with the provided leader election record.
+// It will fail if the object has changed since the last call to Get or Create.
+func (r *resourceLock) Update(ctx context.Context, record resourcelock.LeaderElectionRecord) error {
+	r.mux.Lock()
+	current := r.object
+	r.mux.Unlock()
+	if current == nil {
+		return errors.New("lock not initialized, call Get or Create first")
+	}
+	obj := &resource.SimpleObject[resourcelock.LeaderElectionRecord]{
+		BasicMetadataObject: resource.BasicMetadataObject{
+			StaticMeta: current.StaticMetadata(),
+			CommonMeta: current.CommonMetadata(),
+		},
+		Spec: record,
+	}
+	updated, err := r.client.Update(ctx, r.identifier, obj, resource.UpdateOptions{
+		ResourceVersion: current.CommonMetadata().ResourceVersion,
+	})
+	if err != nil {
+		return r.translateError(err)
+	}
+	r.mux.Lock()
+	r.object = updated
+	r.mux.Unlock()
+	return nil
+}
+
+// RecordEvent is a no-op, as events are not supported by resource.Client
+func (*resourceLock) RecordEvent(string) {}
+
+// Identity returns the identity of the lock holder
+func (r *resourceLock) Identity() string {
+	return r.identity
+}
+
+// Describe returns a string describing the lock
+func (r *resourceLock) Describe() string {
+	return fmt.Sprintf("%s/%s", r.identifier.Namespace, r.identifier.Name)
+}
+
+// translateError converts resource.APIServerResponseErrors into kubernetes API errors,
+// as the leader election logic relies on them to determine if the lock object exists.
+func (r *resourceLock) translateError(err error) error {
+	cast, ok := err.(resource.APIServerResponseError)
+	if !ok {
+		return err
+	}
+	// The resource.Client doesn't expose its schema, and the leader election logic only checks the error reason
+	gr := schema.GroupResource{}
+	switch cast.StatusCode() {
+	case http.StatusNotFound:
+		return apierrors.NewNotFound(gr, r.identifier.Name)
+	case http.StatusConflict:
+		return apierrors.NewConflict(gr, r.identifier.Name, err)
+	default:
+		return err
+	}
+}
+
+// Interface compliance compile-time check
+var _ LeaderElectionLock = &resourceLock{}
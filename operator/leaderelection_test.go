@@ -0,0 +1,190 @@
+package operator
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+	"github.com/grafana/grafana-app-sdk/resource/fake"
+)
+
+var (
+	leaseSchema     = resource.NewSimpleSchema("test.grafana.com", "v1", &resource.SimpleObject[resourcelock.LeaderElectionRecord]{}, resource.WithKind("Lease"))
+	leaseIdentifier = resource.Identifier{Namespace: "default", Name: "operator"}
+)
+
+func TestOperator_EnableLeaderElection(t *testing.T) {
+	client, err := fake.NewClientGenerator().ClientFor(leaseSchema)
+	require.Nil(t, err)
+	lock := NewResourceLock(client, leaseIdentifier, "a")
+
+	t.Run("nil lock", func(t *testing.T) {
+		assert.NotNil(t, New().EnableLeaderElection(LeaderElectionConfig{}))
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		o := New()
+		require.Nil(t, o.EnableLeaderElection(LeaderElectionConfig{Lock: lock}))
+		assert.Equal(t, DefaultLeaseDuration, o.leaderElection.LeaseDuration)
+		assert.Equal(t, DefaultRenewDeadline, o.leaderElection.RenewDeadline)
+		assert.Equal(t, DefaultRetryPeriod, o.leaderElection.RetryPeriod)
+	})
+
+	t.Run("renew deadline longer than lease", func(t *testing.T) {
+		assert.NotNil(t, New().EnableLeaderElection(LeaderElectionConfig{
+			Lock:          lock,
+			LeaseDuration: time.Second,
+			RenewDeadline: 2 * time.Second,
+		}))
+	})
+
+	t.Run("retry period longer than renew deadline", func(t *testing.T) {
+		assert.NotNil(t, New().EnableLeaderElection(LeaderElectionConfig{
+			Lock:          lock,
+			LeaseDuration: 3 * time.Second,
+			RenewDeadline: 2 * time.Second,
+			RetryPeriod:   2 * time.Second,
+		}))
+	})
+}
+
+type testReplica struct {
+	operator *Operator
+	running  atomic.Bool
+	warm     atomic.Bool
+	started  atomic.Int32
+	stopped  atomic.Int32
+	stopCh   chan struct{}
+	result   chan error
+}
+
+func newTestReplica(t *testing.T, client resource.Client, identity string) *testReplica {
+	r := &testReplica{
+		operator: New(),
+		stopCh:   make(chan struct{}),
+		result:   make(chan error, 1),
+	}
+	r.operator.AddController(&mockController{
+		WarmFunc: func(stopCh <-chan struct{}) error {
+			r.warm.Store(true)
+			go func() {
+				<-stopCh
+				r.warm.Store(false)
+			}()
+			return nil
+		},
+		RunFunc: func(stopCh <-chan struct{}) error {
+			r.running.Store(true)
+			<-stopCh
+			r.running.Store(false)
+			return nil
+		},
+	})
+	// Lease times are stored to the second (see NewResourceLock), so the lease must be several seconds long
+	require.Nil(t, r.operator.EnableLeaderElection(LeaderElectionConfig{
+		Lock:          NewResourceLock(client, leaseIdentifier, identity),
+		LeaseDuration: 3 * time.Second,
+		RenewDeadline: 2 * time.Second,
+		RetryPeriod:   250 * time.Millisecond,
+		ReleaseOnStop: true,
+		OnStartedLeading: func(context.Context) {
+			r.started.Add(1)
+		},
+		OnStoppedLeading: func() {
+			r.stopped.Add(1)
+		},
+	}))
+	return r
+}
+
+func (r *testReplica) run() {
+	go func() {
+		r.result <- r.operator.Run(r.stopCh)
+	}()
+}
+
+func TestOperator_RunWithLeaderElection(t *testing.T) {
+	t.Run("only leader runs controllers", func(t *testing.T) {
+		client, err := fake.NewClientGenerator().ClientFor(leaseSchema)
+		require.Nil(t, err)
+		first := newTestReplica(t, client, "first")
+		second := newTestReplica(t, client, "second")
+		first.run()
+		require.Eventually(t, first.running.Load, 5*time.Second, 10*time.Millisecond)
+		second.run()
+		// The follower warms its controllers, but should not run them while the leader is renewing its lease
+		require.Eventually(t, second.warm.Load, 5*time.Second, 10*time.Millisecond)
+		time.Sleep(4 * time.Second)
+		assert.False(t, second.running.Load())
+		assert.Equal(t, int32(0), second.started.Load())
+
+		// Stopping the leader releases the lock, and the follower takes over
+		close(first.stopCh)
+		assert.Nil(t, <-first.result)
+		assert.Eventually(t, func() bool { return !first.running.Load() }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, int32(1), first.stopped.Load())
+		require.Eventually(t, second.running.Load, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, int32(1), second.started.Load())
+
+		close(second.stopCh)
+		assert.Nil(t, <-second.result)
+		assert.Eventually(t, func() bool { return !second.warm.Load() }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("stopping a follower", func(t *testing.T) {
+		client, err := fake.NewClientGenerator().ClientFor(leaseSchema)
+		require.Nil(t, err)
+		leader := newTestReplica(t, client, "leader")
+		follower := newTestReplica(t, client, "follower")
+		leader.run()
+		require.Eventually(t, leader.running.Load, 5*time.Second, 10*time.Millisecond)
+		follower.run()
+		close(follower.stopCh)
+		assert.Nil(t, <-follower.result)
+		assert.Equal(t, int32(0), follower.stopped.Load())
+		close(leader.stopCh)
+		assert.Nil(t, <-leader.result)
+	})
+
+	t.Run("leadership lost", func(t *testing.T) {
+		client, err := fake.NewClientGenerator().ClientFor(leaseSchema)
+		require.Nil(t, err)
+		replica := newTestReplica(t, client, "replica")
+		replica.run()
+		require.Eventually(t, replica.running.Load, 5*time.Second, 10*time.Millisecond)
+
+		// Another holder takes the lock out from under the leader
+		obj, err := client.Get(context.Background(), leaseIdentifier)
+		require.Nil(t, err)
+		now := metav1.NewTime(time.Now())
+		_, err = client.Update(context.Background(), leaseIdentifier, &resource.SimpleObject[resourcelock.LeaderElectionRecord]{
+			BasicMetadataObject: resource.BasicMetadataObject{
+				StaticMeta: obj.StaticMetadata(),
+				CommonMeta: obj.CommonMetadata(),
+			},
+			Spec: resourcelock.LeaderElectionRecord{
+				HolderIdentity:       "other",
+				LeaseDurationSeconds: 60,
+				AcquireTime:          now,
+				RenewTime:            now,
+			},
+		}, resource.UpdateOptions{})
+		require.Nil(t, err)
+
+		select {
+		case err = <-replica.result:
+			assert.Equal(t, ErrLeadershipLost, err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("operator did not stop after losing leadership")
+		}
+		assert.Eventually(t, func() bool { return !replica.running.Load() }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, int32(1), replica.stopped.Load())
+	})
+}
@@ -1,7 +1,11 @@
 package operator
 
 import (
+	"context"
+	"sync/atomic"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/leaderelection"
 
 	"github.com/grafana/grafana-app-sdk/metrics"
 )
@@ -11,11 +15,22 @@ type Controller interface {
 	Run(<-chan struct{}) error
 }
 
+// WarmableController is a Controller which can be warmed up before it is run,
+// by starting the parts of the controller which only observe resources (such as informers and their caches).
+// When leader election is enabled, WarmableControllers are warmed on every replica, but only run on the leader.
+type WarmableController interface {
+	Controller
+	// Warm starts warming up the controller until the stopCh is closed. It must not block.
+	// Run may be called after Warm, and must not repeat the warm-up.
+	Warm(<-chan struct{}) error
+}
+
 // Operator is the highest-level construct of the `operator` package,
 // and contains one or more controllers which can be run.
 // Operator handles scaling and error propagation for its underlying controllers
 type Operator struct {
-	controllers []Controller
+	controllers    []Controller
+	leaderElection *LeaderElectionConfig
 }
 
 // New creates a new Operator
@@ -46,9 +61,16 @@ func (o *Operator) PrometheusCollectors() []prometheus.Collector {
 }
 
 // Run runs the operator until an unrecoverable error occurs or the stopCh is closed/receives a message.
+// If leader election is enabled (see EnableLeaderElection), controllers are only run while the operator is the leader,
+// and controllers which implement WarmableController are warmed up while waiting for leadership.
 func (o *Operator) Run(stopCh <-chan struct{}) error {
-	// TODO: operator should deal with scaling logic if possible.
+	if o.leaderElection != nil {
+		return o.runWithLeaderElection(stopCh)
+	}
+	return o.runControllers(stopCh)
+}
 
+func (o *Operator) runControllers(stopCh <-chan struct{}) error {
 	errs := make(chan error)
 	controllerStopChannel := make(chan struct{})
 
@@ -75,3 +97,75 @@ func (o *Operator) Run(stopCh <-chan struct{}) error {
 	// If we encountered an error, return it (if we didn't, this will be nil)
 	return err
 }
+
+func (o *Operator) runWithLeaderElection(stopCh <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// Warm controllers on every replica, so that followers are ready to run them as soon as they become the leader
+	for _, controller := range o.controllers {
+		if w, ok := controller.(WarmableController); ok {
+			if err := w.Warm(ctx.Done()); err != nil {
+				return err
+			}
+		}
+	}
+
+	cfg := o.leaderElection
+	leading := atomic.Bool{}
+	controllerErr := make(chan error, 1)
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            cfg.Lock,
+		LeaseDuration:   cfg.LeaseDuration,
+		RenewDeadline:   cfg.RenewDeadline,
+		RetryPeriod:     cfg.RetryPeriod,
+		ReleaseOnCancel: cfg.ReleaseOnStop,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				leading.Store(true)
+				if cfg.OnStartedLeading != nil {
+					cfg.OnStartedLeading(leaderCtx)
+				}
+				err := o.runControllers(leaderCtx.Done())
+				controllerErr <- err
+				if err != nil {
+					// Stop contending for leadership, as we are returning the error
+					cancel()
+				}
+			},
+			OnStoppedLeading: func() {
+				if leading.Load() && cfg.OnStoppedLeading != nil {
+					cfg.OnStoppedLeading()
+				}
+			},
+			OnNewLeader: cfg.OnNewLeader,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Run blocks until the context is canceled or leadership is lost
+	elector.Run(ctx)
+
+	if !leading.Load() {
+		return nil
+	}
+	// The leader context is canceled when Run returns, so controllers will stop
+	if err = <-controllerErr; err != nil {
+		return err
+	}
+	select {
+	case <-stopCh:
+		return nil
+	default:
+		return ErrLeadershipLost
+	}
+}
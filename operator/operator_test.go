@@ -9,7 +9,15 @@ import (
 )
 
 type mockController struct {
-	RunFunc func(<-chan struct{}) error
+	RunFunc  func(<-chan struct{}) error
+	WarmFunc func(<-chan struct{}) error
+}
+
+func (m *mockController) Warm(ch <-chan struct{}) error {
+	if m.WarmFunc != nil {
+		return m.WarmFunc(ch)
+	}
+	return nil
 }
 
 func (m *mockController) Run(ch <-chan struct{}) error {
@@ -0,0 +1,206 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grafana/grafana-app-sdk/logging"
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// OperatorStateValue is the machine-readable state of an OperatorState
+type OperatorStateValue string
+
+// OperatorStateValue values, as defined by the kindsys #OperatorState
+const (
+	OperatorStateSuccess    = OperatorStateValue("success")
+	OperatorStateInProgress = OperatorStateValue("in_progress")
+	OperatorStateFailed     = OperatorStateValue("failed")
+)
+
+// OperatorStateErrorDetailsKey is the key in OperatorState.Details which contains the error message
+// when the State is OperatorStateFailed
+const OperatorStateErrorDetailsKey = "error"
+
+// OperatorState is the state of an operator's last evaluation of an object,
+// as stored in the object's status.operatorStates map, keyed by operator name.
+// It mirrors the #OperatorState definition in kindsys/kindcat_custom.cue.
+type OperatorState struct {
+	// LastEvaluation is the ResourceVersion last evaluated
+	LastEvaluation string `json:"lastEvaluation"`
+	// State describes the state of the LastEvaluation
+	State OperatorStateValue `json:"state"`
+	// DescriptiveState is an optional more descriptive state field which has no requirements on format
+	DescriptiveState string `json:"descriptiveState,omitempty"`
+	// Details contains any extra information that is operator-specific
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// operatorStateWriter writes OperatorStates to the status subresource of objects
+type operatorStateWriter struct {
+	operatorName string
+	client       PatchClient
+	// written tracks the ResourceVersions of each object resulting from our own writes which haven't been seen yet,
+	// in the order they were written, so that the events caused by the writes themselves can be identified
+	written    map[resource.Identifier][]string
+	writtenMux sync.Mutex
+}
+
+func newOperatorStateWriter(operatorName string, client PatchClient) *operatorStateWriter {
+	return &operatorStateWriter{
+		operatorName: operatorName,
+		client:       client,
+		written:      make(map[resource.Identifier][]string),
+	}
+}
+
+// write patches status.operatorStates[operatorName] of the object with a state based on the provided error.
+// A nil error results in a success state, and a non-nil error results in a failed state which contains the error message.
+// Errors writing the state are logged and recorded on the span in the context, but not returned,
+// as the write should not cause the handler which produced the state to be retried.
+func (w *operatorStateWriter) write(ctx context.Context, object resource.Object, handlerErr error) {
+	state := OperatorState{
+		LastEvaluation: object.CommonMetadata().ResourceVersion,
+		State:          OperatorStateSuccess,
+	}
+	if handlerErr != nil {
+		state.State = OperatorStateFailed
+		state.Details = map[string]any{
+			OperatorStateErrorDetailsKey: handlerErr.Error(),
+		}
+	}
+	w.writeState(ctx, object, state)
+}
+
+// writeResult writes the state for a Reconcile call. In addition to the states set by write,
+// a successful ReconcileResult with a non-nil RequeueAfter results in an in-progress state.
+func (w *operatorStateWriter) writeResult(ctx context.Context, object resource.Object, result ReconcileResult,
+	reconcileErr error) {
+	if reconcileErr == nil && result.RequeueAfter != nil {
+		w.writeState(ctx, object, OperatorState{
+			LastEvaluation: object.CommonMetadata().ResourceVersion,
+			State:          OperatorStateInProgress,
+		})
+		return
+	}
+	w.write(ctx, object, reconcileErr)
+}
+
+func (w *operatorStateWriter) writeState(ctx context.Context, object resource.Object, state OperatorState) {
+	patch, err := w.patchFor(object, state)
+	if err == nil {
+		err = w.client.PatchInto(ctx, object.StaticMetadata().Identifier(), patch, resource.PatchOptions{
+			Subresource: "status",
+		}, object)
+	}
+	if err != nil {
+		err = fmt.Errorf("error writing operator state: %w", err)
+		trace.SpanFromContext(ctx).SetStatus(codes.Error, err.Error())
+		logging.FromContext(ctx).Error(err.Error(), "component", "OperatorStateWriter", "operator", w.operatorName,
+			"namespace", object.StaticMetadata().Namespace, "name", object.StaticMetadata().Name, "error", err)
+		return
+	}
+	w.recordWrite(object)
+}
+
+// recordWrite records the object's ResourceVersion as the result of our own write,
+// so that the event caused by the write can be identified by isOwnWrite.
+// The object must have been updated with the response of the write.
+func (w *operatorStateWriter) recordWrite(object resource.Object) {
+	w.writtenMux.Lock()
+	defer w.writtenMux.Unlock()
+	id := object.StaticMetadata().Identifier()
+	w.written[id] = append(w.written[id], object.CommonMetadata().ResourceVersion)
+}
+
+// isOwnWrite returns true if the object is the result of one of our own writes which was recorded by recordWrite.
+// Each write is only reported once, as each ResourceVersion only results in one event.
+// Events arrive in order, but may be coalesced, so recorded writes older than the object are dropped either way.
+func (w *operatorStateWriter) isOwnWrite(object resource.Object) bool {
+	w.writtenMux.Lock()
+	defer w.writtenMux.Unlock()
+	id := object.StaticMetadata().Identifier()
+	rv := object.CommonMetadata().ResourceVersion
+	written := w.written[id]
+	own := false
+	if i := slices.Index(written, rv); i >= 0 {
+		// Writes before this one won't be seen, as their events were coalesced
+		written = written[i+1:]
+		own = true
+	} else {
+		written = slices.DeleteFunc(written, func(recorded string) bool {
+			return !resourceVersionIsNewer(recorded, rv)
+		})
+	}
+	if len(written) == 0 {
+		delete(w.written, id)
+	} else {
+		w.written[id] = written
+	}
+	return own
+}
+
+// resourceVersionIsNewer returns true if the ResourceVersion rv is newer than the ResourceVersion than.
+// ResourceVersions are opaque, but kubernetes and the fake client both use increasing integers.
+// ResourceVersions which can't be compared are not considered newer.
+func resourceVersionIsNewer(rv, than string) bool {
+	parsed, err := strconv.ParseUint(rv, 10, 64)
+	if err != nil {
+		return false
+	}
+	parsedThan, err := strconv.ParseUint(than, 10, 64)
+	if err != nil {
+		return false
+	}
+	return parsed > parsedThan
+}
+
+// forget stops tracking writes for the object
+func (w *operatorStateWriter) forget(object resource.Object) {
+	w.writtenMux.Lock()
+	defer w.writtenMux.Unlock()
+	delete(w.written, object.StaticMetadata().Identifier())
+}
+
+// patchFor creates a patch to set the operator's state, adding the status and operatorStates maps if they don't exist
+func (w *operatorStateWriter) patchFor(object resource.Object, state OperatorState) (resource.PatchRequest, error) {
+	status := make(map[string]any)
+	if sr, ok := object.Subresources()["status"]; ok && sr != nil {
+		raw, err := json.Marshal(sr)
+		if err != nil {
+			return resource.PatchRequest{}, err
+		}
+		// A status which doesn't unmarshal into a map (such as null) is treated as missing
+		_ = json.Unmarshal(raw, &status)
+	}
+	op := resource.PatchOperation{
+		Operation: resource.PatchOpAdd,
+	}
+	switch {
+	case len(status) == 0:
+		op.Path = "/status"
+		op.Value = map[string]any{
+			"operatorStates": map[string]any{
+				w.operatorName: state,
+			},
+		}
+	case status["operatorStates"] == nil:
+		op.Path = "/status/operatorStates"
+		op.Value = map[string]any{
+			w.operatorName: state,
+		}
+	default:
+		op.Path = "/status/operatorStates/" + resource.EscapePointerToken(w.operatorName)
+		op.Value = state
+	}
+	return resource.PatchRequest{
+		Operations: []resource.PatchOperation{op},
+	}, nil
+}
@@ -0,0 +1,269 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+	"github.com/grafana/grafana-app-sdk/resource/fake"
+)
+
+type operatorStateTestStatus struct {
+	OperatorStates map[string]OperatorState `json:"operatorStates"`
+}
+
+func getOperatorState(t *testing.T, client resource.Client, identifier resource.Identifier, operatorName string) (OperatorState, bool) {
+	obj, err := client.Get(context.Background(), identifier)
+	require.Nil(t, err)
+	raw, ok := obj.Subresources()["status"].(json.RawMessage)
+	if !ok {
+		return OperatorState{}, false
+	}
+	status := operatorStateTestStatus{}
+	require.Nil(t, json.Unmarshal(raw, &status))
+	state, ok := status.OperatorStates[operatorName]
+	return state, ok
+}
+
+func newOperatorStateTestClient(t *testing.T) (resource.Schema, resource.Client) {
+	sch := resource.NewSimpleSchema("test.grafana.com", "v1", &resource.SimpleObject[cacheTestSpec]{}, resource.WithKind("Test"))
+	client, err := fake.NewClientGenerator().ClientFor(sch)
+	require.Nil(t, err)
+	return sch, client
+}
+
+func TestOperatorStateWriter_patchFor(t *testing.T) {
+	state := OperatorState{LastEvaluation: "1", State: OperatorStateSuccess}
+	writer := newOperatorStateWriter("my/operator", &mockPatchClient{})
+	tests := []struct {
+		name     string
+		status   any
+		expected resource.PatchOperation
+	}{{
+		name:   "no status",
+		status: nil,
+		expected: resource.PatchOperation{
+			Operation: resource.PatchOpAdd,
+			Path:      "/status",
+			Value:     map[string]any{"operatorStates": map[string]any{"my/operator": state}},
+		},
+	}, {
+		name:   "no operator states",
+		status: map[string]any{"foo": "bar"},
+		expected: resource.PatchOperation{
+			Operation: resource.PatchOpAdd,
+			Path:      "/status/operatorStates",
+			Value:     map[string]any{"my/operator": state},
+		},
+	}, {
+		name:   "existing operator states",
+		status: map[string]any{"operatorStates": map[string]any{"other": map[string]any{"state": "failed"}}},
+		expected: resource.PatchOperation{
+			Operation: resource.PatchOpAdd,
+			Path:      "/status/operatorStates/my~1operator",
+			Value:     state,
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj := &resource.SimpleObject[cacheTestSpec]{}
+			if test.status != nil {
+				obj.SubresourceMap = map[string]any{"status": test.status}
+			}
+			patch, err := writer.patchFor(obj, state)
+			require.Nil(t, err)
+			assert.Equal(t, []resource.PatchOperation{test.expected}, patch.Operations)
+		})
+	}
+}
+
+func TestOperatorStateWriter_isOwnWrite(t *testing.T) {
+	objectAt := func(rv string) resource.Object {
+		obj := &resource.SimpleObject[cacheTestSpec]{}
+		obj.SetStaticMetadata(resource.StaticMetadata{Namespace: "ns", Name: "test"})
+		obj.SetCommonMetadata(resource.CommonMetadata{ResourceVersion: rv})
+		return obj
+	}
+
+	t.Run("each write is seen once", func(t *testing.T) {
+		writer := newOperatorStateWriter("operator", &mockPatchClient{})
+		writer.recordWrite(objectAt("1"))
+		writer.recordWrite(objectAt("2"))
+		assert.True(t, writer.isOwnWrite(objectAt("1")))
+		assert.False(t, writer.isOwnWrite(objectAt("1")))
+		assert.True(t, writer.isOwnWrite(objectAt("2")))
+		assert.Empty(t, writer.written)
+	})
+
+	t.Run("coalesced writes are dropped", func(t *testing.T) {
+		writer := newOperatorStateWriter("operator", &mockPatchClient{})
+		writer.recordWrite(objectAt("1"))
+		writer.recordWrite(objectAt("2"))
+		assert.True(t, writer.isOwnWrite(objectAt("2")))
+		assert.Empty(t, writer.written)
+	})
+
+	t.Run("writes older than another change are dropped", func(t *testing.T) {
+		writer := newOperatorStateWriter("operator", &mockPatchClient{})
+		writer.recordWrite(objectAt("2"))
+		writer.recordWrite(objectAt("4"))
+		assert.False(t, writer.isOwnWrite(objectAt("3")))
+		assert.Len(t, writer.written, 1)
+		assert.False(t, writer.isOwnWrite(objectAt("5")))
+		assert.Empty(t, writer.written)
+	})
+}
+
+func TestOpinionatedWatcher_OperatorState(t *testing.T) {
+	ctx := context.Background()
+	sch, client := newOperatorStateTestClient(t)
+	id := resource.Identifier{Namespace: "ns", Name: "test"}
+	watcher, err := NewOpinionatedWatcher(sch, client)
+	require.Nil(t, err)
+	assert.NotNil(t, watcher.EnableOperatorState(""))
+	require.Nil(t, watcher.EnableOperatorState("test-operator"))
+
+	obj, err := client.Create(ctx, id, &resource.SimpleObject[cacheTestSpec]{
+		Spec:           cacheTestSpec{Owner: "alice"},
+		SubresourceMap: map[string]any{"status": map[string]any{"foo": "bar"}},
+	}, resource.CreateOptions{})
+	require.Nil(t, err)
+
+	t.Run("add failure", func(t *testing.T) {
+		watcher.AddFunc = func(context.Context, resource.Object) error {
+			return errors.New("I AM ERROR")
+		}
+		assert.NotNil(t, watcher.Add(ctx, obj.Copy()))
+		state, ok := getOperatorState(t, client, id, "test-operator")
+		require.True(t, ok)
+		assert.Equal(t, OperatorStateFailed, state.State)
+		assert.Equal(t, obj.CommonMetadata().ResourceVersion, state.LastEvaluation)
+		assert.Equal(t, map[string]any{OperatorStateErrorDetailsKey: "I AM ERROR"}, state.Details)
+	})
+
+	t.Run("add success", func(t *testing.T) {
+		watcher.AddFunc = func(context.Context, resource.Object) error {
+			return nil
+		}
+		added := obj.Copy()
+		require.Nil(t, watcher.Add(ctx, added))
+		state, ok := getOperatorState(t, client, id, "test-operator")
+		require.True(t, ok)
+		assert.Equal(t, OperatorStateSuccess, state.State)
+		assert.Nil(t, state.Details)
+		// The existing status fields are retained
+		current, err := client.Get(ctx, id)
+		require.Nil(t, err)
+		assert.Contains(t, string(current.Subresources()["status"].(json.RawMessage)), `"foo":"bar"`)
+		assert.Equal(t, []string{watcher.finalizer}, current.CommonMetadata().Finalizers)
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		current, err := client.Get(ctx, id)
+		require.Nil(t, err)
+		watcher.SyncFunc = func(context.Context, resource.Object) error {
+			return nil
+		}
+		evaluated := current.CommonMetadata().ResourceVersion
+		require.Nil(t, watcher.Add(ctx, current))
+		state, ok := getOperatorState(t, client, id, "test-operator")
+		require.True(t, ok)
+		assert.Equal(t, OperatorStateSuccess, state.State)
+		assert.Equal(t, evaluated, state.LastEvaluation)
+	})
+}
+
+func TestOpinionatedReconciler_OperatorState(t *testing.T) {
+	ctx := context.Background()
+	_, client := newOperatorStateTestClient(t)
+	id := resource.Identifier{Namespace: "ns", Name: "test"}
+	reconciler, err := NewOpinionatedReconciler(client, "finalizer")
+	require.Nil(t, err)
+	require.Nil(t, reconciler.EnableOperatorState("test-operator"))
+	calls := 0
+	var result ReconcileResult
+	reconciler.Reconciler = &SimpleReconciler{
+		ReconcileFunc: func(context.Context, ReconcileRequest) (ReconcileResult, error) {
+			calls++
+			return result, nil
+		},
+	}
+
+	// Replay the full sequence of events from a watch, as an informer would,
+	// including the events caused by the reconciler's own writes
+	watch, err := client.Watch(ctx, "ns", resource.WatchOptions{})
+	require.Nil(t, err)
+	defer watch.Stop()
+	actions := map[string]ReconcileAction{
+		fake.WatchEventTypeAdded:    ReconcileActionCreated,
+		fake.WatchEventTypeModified: ReconcileActionUpdated,
+		fake.WatchEventTypeDeleted:  ReconcileActionDeleted,
+	}
+	replay := func(t *testing.T) {
+		for {
+			select {
+			case evt := <-watch.WatchEvents():
+				_, err := reconciler.Reconcile(ctx, ReconcileRequest{
+					Action: actions[evt.EventType],
+					Object: evt.Object,
+				})
+				require.Nil(t, err)
+			case <-time.After(200 * time.Millisecond):
+				return
+			}
+		}
+	}
+
+	_, err = client.Create(ctx, id, &resource.SimpleObject[cacheTestSpec]{}, resource.CreateOptions{})
+	require.Nil(t, err)
+
+	t.Run("created, and updates from own writes are dropped", func(t *testing.T) {
+		replay(t)
+		assert.Equal(t, 1, calls)
+		state, ok := getOperatorState(t, client, id, "test-operator")
+		require.True(t, ok)
+		assert.Equal(t, OperatorStateSuccess, state.State)
+		current, err := client.Get(ctx, id)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"finalizer"}, current.CommonMetadata().Finalizers)
+	})
+
+	t.Run("requeue is in progress", func(t *testing.T) {
+		requeue := time.Second
+		result = ReconcileResult{RequeueAfter: &requeue}
+		current, err := client.Get(ctx, id)
+		require.Nil(t, err)
+		toUpdate := current.Copy().(*resource.SimpleObject[cacheTestSpec])
+		toUpdate.Spec.Owner = "bob"
+		updated, err := client.Update(ctx, id, toUpdate, resource.UpdateOptions{})
+		require.Nil(t, err)
+		evaluated := updated.CommonMetadata().ResourceVersion
+		replay(t)
+		assert.Equal(t, 2, calls)
+		state, ok := getOperatorState(t, client, id, "test-operator")
+		require.True(t, ok)
+		assert.Equal(t, OperatorStateInProgress, state.State)
+		assert.Equal(t, evaluated, state.LastEvaluation)
+	})
+
+	t.Run("status update by another writer is delegated", func(t *testing.T) {
+		result = ReconcileResult{}
+		_, err := client.Patch(ctx, id, resource.PatchRequest{
+			Operations: []resource.PatchOperation{{
+				Operation: resource.PatchOpAdd,
+				Path:      "/status/foo",
+				Value:     "bar",
+			}},
+		}, resource.PatchOptions{Subresource: "status"})
+		require.Nil(t, err)
+		replay(t)
+		assert.Equal(t, 3, calls)
+	})
+}
@@ -36,13 +36,14 @@ type PatchClient interface {
 //
 // OpinionatedWatcher contains unexported fields, and must be created with NewOpinionatedWatcher
 type OpinionatedWatcher struct {
-	AddFunc    func(ctx context.Context, object resource.Object) error
-	UpdateFunc func(ctx context.Context, old resource.Object, new resource.Object) error
-	DeleteFunc func(ctx context.Context, object resource.Object) error
-	SyncFunc   func(ctx context.Context, object resource.Object) error
-	finalizer  string
-	schema     resource.Schema
-	client     PatchClient
+	AddFunc     func(ctx context.Context, object resource.Object) error
+	UpdateFunc  func(ctx context.Context, old resource.Object, new resource.Object) error
+	DeleteFunc  func(ctx context.Context, object resource.Object) error
+	SyncFunc    func(ctx context.Context, object resource.Object) error
+	finalizer   string
+	schema      resource.Schema
+	client      PatchClient
+	stateWriter *operatorStateWriter
 }
 
 // FinalizerSupplier represents a function that creates string finalizer from provider schema.
@@ -89,6 +90,18 @@ func (o *OpinionatedWatcher) Wrap(watcher ResourceWatcher, syncToAdd bool) { //
 	}
 }
 
+// EnableOperatorState enables writing an OperatorState to `status.operatorStates[operatorName]` of objects
+// via the status subresource after every call to AddFunc, UpdateFunc, or SyncFunc.
+// The state is OperatorStateSuccess if the call succeeded, or OperatorStateFailed with the error message in its details
+// if the call returned an error. The object's kind must have a status subresource which allows this field.
+func (o *OpinionatedWatcher) EnableOperatorState(operatorName string) error {
+	if operatorName == "" {
+		return fmt.Errorf("operatorName cannot be empty")
+	}
+	o.stateWriter = newOperatorStateWriter(operatorName, o.client)
+	return nil
+}
+
 // Add is part of implementing ResourceWatcher,
 // and calls the underlying AddFunc, SyncFunc, or DeleteFunc based upon internal logic.
 // When the object is first added, AddFunc is called and a finalizer is attached to it.
@@ -128,6 +141,7 @@ func (o *OpinionatedWatcher) Add(ctx context.Context, object resource.Object) er
 			span.SetStatus(codes.Error, fmt.Sprintf("error removing finalizer: %s", err.Error()))
 			return err
 		}
+		o.forgetOperatorState(object)
 		return nil
 	}
 
@@ -135,7 +149,9 @@ func (o *OpinionatedWatcher) Add(ctx context.Context, object resource.Object) er
 	// If it is, we've already done the add logic on a previous run of the operator,
 	// and this event is due to the list call on startup. In that case, we call our sync handler
 	if slices.Contains(finalizers, o.finalizer) {
-		return o.syncFunc(ctx, object)
+		err := o.syncFunc(ctx, object)
+		o.writeOperatorState(ctx, object, err)
+		return err
 	}
 
 	// If this isn't a delete or an add we've seen before, then it's a new resource we need to handle appropriately.
@@ -143,6 +159,7 @@ func (o *OpinionatedWatcher) Add(ctx context.Context, object resource.Object) er
 	err := o.addFunc(ctx, object)
 	if err != nil {
 		span.SetStatus(codes.Error, fmt.Sprintf("watcher add error: %s", err.Error()))
+		o.writeOperatorState(ctx, object, err)
 		return err
 	}
 
@@ -151,6 +168,7 @@ func (o *OpinionatedWatcher) Add(ctx context.Context, object resource.Object) er
 	if err != nil {
 		return fmt.Errorf("error adding finalizer: %w", err)
 	}
+	o.writeOperatorState(ctx, object, nil)
 	return nil
 }
 
@@ -185,6 +203,7 @@ func (o *OpinionatedWatcher) Update(ctx context.Context, old resource.Object, ne
 		// Either way, we need to try calling AddFunc
 		err := o.addFunc(ctx, new)
 		if err != nil {
+			o.writeOperatorState(ctx, new, err)
 			return err
 		}
 		// Add the finalizer (which also updates `new` inline)
@@ -210,7 +229,11 @@ func (o *OpinionatedWatcher) Update(ctx context.Context, old resource.Object, ne
 			return err
 		}
 
-		return o.removeFinalizer(ctx, new, newFinalizers)
+		err = o.removeFinalizer(ctx, new, newFinalizers)
+		if err == nil {
+			o.forgetOperatorState(new)
+		}
+		return err
 	}
 
 	// Check if this was us adding our finalizer. If it was, we can ignore it.
@@ -219,6 +242,7 @@ func (o *OpinionatedWatcher) Update(ctx context.Context, old resource.Object, ne
 	}
 
 	err := o.updateFunc(ctx, old, new)
+	o.writeOperatorState(ctx, new, err)
 	if err != nil {
 		span.SetStatus(codes.Error, fmt.Sprintf("watcher update error: %s", err.Error()))
 		return err
@@ -269,6 +293,19 @@ func (o *OpinionatedWatcher) syncFunc(ctx context.Context, object resource.Objec
 	return nil
 }
 
+// writeOperatorState writes the operator state for the object if EnableOperatorState has been called
+func (o *OpinionatedWatcher) writeOperatorState(ctx context.Context, object resource.Object, err error) {
+	if o.stateWriter != nil {
+		o.stateWriter.write(ctx, object, err)
+	}
+}
+
+func (o *OpinionatedWatcher) forgetOperatorState(object resource.Object) {
+	if o.stateWriter != nil {
+		o.stateWriter.forget(object)
+	}
+}
+
 func (o *OpinionatedWatcher) addFinalizer(ctx context.Context, object resource.Object, finalizers []string) error {
 	if slices.Contains(finalizers, o.finalizer) {
 		// Finalizer already added
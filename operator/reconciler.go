@@ -133,9 +133,25 @@ func NewOpinionatedReconciler(client PatchClient, finalizer string) (*Opinionate
 // "resync" events on start-up when the reconciler has handled the "created" event on a previous run,
 // and ensures that "delete" events are not missed during reconciler down-time by using the finalizer.
 type OpinionatedReconciler struct {
-	Reconciler Reconciler
-	finalizer  string
-	client     PatchClient
+	Reconciler  Reconciler
+	finalizer   string
+	client      PatchClient
+	stateWriter *operatorStateWriter
+}
+
+// EnableOperatorState enables writing an OperatorState to `status.operatorStates[operatorName]` of objects
+// via the status subresource after every delegated Reconcile call for a Created, Updated, or Resynced action.
+// The state is OperatorStateSuccess if the call succeeded, OperatorStateInProgress if it succeeded with a RequeueAfter,
+// or OperatorStateFailed with the error message in its details if the call returned an error.
+// Updated actions caused by the state writes themselves (and by adding the finalizer after a Created action)
+// are dropped and not delegated.
+// The object's kind must have a status subresource which allows this field.
+func (o *OpinionatedReconciler) EnableOperatorState(operatorName string) error {
+	if operatorName == "" {
+		return fmt.Errorf("operatorName cannot be empty")
+	}
+	o.stateWriter = newOperatorStateWriter(operatorName, o.client)
+	return nil
 }
 
 const opinionatedReconcilerPatchStateKey = "grafana-app-sdk-opinionated-reconciler-create-patch-status"
@@ -146,6 +162,15 @@ const opinionatedReconcilerPatchStateKey = "grafana-app-sdk-opinionated-reconcil
 //   - If the action is an Update, and the DeletionTimestamp is non-nil, remove the OpinionatedReconciler's finalizer, and do not delegate (the subsequent Delete will be delegated)
 //   - If the action is an Update, and the OpinionatedReconciler's finalizer is missing (and DeletionTimestamp is nil), add the finalizer, and do not delegate (the subsequent update action will delegate)
 func (o *OpinionatedReconciler) Reconcile(ctx context.Context, request ReconcileRequest) (ReconcileResult, error) {
+	// If operator states are written, drop the updates caused by our own writes
+	if o.stateWriter != nil {
+		if request.Action == ReconcileActionUpdated && o.stateWriter.isOwnWrite(request.Object) {
+			return ReconcileResult{}, nil
+		}
+		if request.Action == ReconcileActionDeleted {
+			o.stateWriter.forget(request.Object)
+		}
+	}
 	// Check if this action is a create, and the resource already has a finalizer. If so, make it a sync.
 	if request.Action == ReconcileActionCreated && slices.Contains(request.Object.CommonMetadata().Finalizers, o.finalizer) {
 		request.Action = ReconcileActionResynced
@@ -158,6 +183,7 @@ func (o *OpinionatedReconciler) Reconcile(ctx context.Context, request Reconcile
 			var err error
 			resp, err = o.wrappedReconcile(ctx, request)
 			if err != nil || resp.RequeueAfter != nil {
+				o.writeOperatorState(ctx, request, resp, err)
 				return resp, err
 			}
 		}
@@ -176,6 +202,11 @@ func (o *OpinionatedReconciler) Reconcile(ctx context.Context, request Reconcile
 			}
 			resp.State[opinionatedReconcilerPatchStateKey] = patchErr
 		}
+		if patchErr == nil && o.stateWriter != nil {
+			// The update caused by adding the finalizer doesn't need to be delegated, as the object was just reconciled
+			o.stateWriter.recordWrite(request.Object)
+		}
+		o.writeOperatorState(ctx, request, resp, patchErr)
 		return resp, patchErr
 	}
 	if request.Action == ReconcileActionUpdated && request.Object.CommonMetadata().DeletionTimestamp != nil && slices.Contains(request.Object.CommonMetadata().Finalizers, o.finalizer) {
@@ -202,10 +233,25 @@ func (o *OpinionatedReconciler) Reconcile(ctx context.Context, request Reconcile
 }
 
 func (o *OpinionatedReconciler) wrappedReconcile(ctx context.Context, request ReconcileRequest) (ReconcileResult, error) {
+	res := ReconcileResult{}
+	var err error
 	if o.Reconciler != nil {
-		return o.Reconciler.Reconcile(ctx, request)
+		res, err = o.Reconciler.Reconcile(ctx, request)
 	}
-	return ReconcileResult{}, nil
+	if request.Action != ReconcileActionCreated {
+		// Created actions write their state after the finalizer is added
+		o.writeOperatorState(ctx, request, res, err)
+	}
+	return res, err
+}
+
+// writeOperatorState writes the operator state for the request's object if EnableOperatorState has been called
+func (o *OpinionatedReconciler) writeOperatorState(ctx context.Context, request ReconcileRequest, result ReconcileResult,
+	err error) {
+	if o.stateWriter == nil || request.Action == ReconcileActionDeleted {
+		return
+	}
+	o.stateWriter.writeResult(ctx, request.Object, result, err)
 }
 
 // Wrap wraps the provided Reconciler's Reconcile function with this OpinionatedReconciler
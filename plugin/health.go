@@ -0,0 +1,218 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"k8s.io/apimachinery/pkg/runtime"
+	kschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/rest"
+
+	"github.com/grafana/grafana-app-sdk/k8s"
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// DefaultHealthCheckTimeout is the default timeout of each request made by a KubernetesHealthChecker,
+// used if the rest.Config it is created with has no timeout.
+const DefaultHealthCheckTimeout = 10 * time.Second
+
+const (
+	// HealthCheckAPIServer is the name of the health check which verifies that the Kubernetes API server is reachable.
+	HealthCheckAPIServer = "apiserver"
+	// HealthCheckNamespace is the name of the health check which verifies that the namespace exists.
+	HealthCheckNamespace = "namespace"
+	// HealthCheckCRDPrefix is the prefix of the names of the health checks which verify that the CRD
+	// of a kind is registered. The full name is the prefix followed by the CRD name, such as `crd/foos.example.com`.
+	HealthCheckCRDPrefix = "crd/"
+)
+
+const (
+	// HealthCheckStatusOK is the status of a health check which passed.
+	HealthCheckStatusOK = "ok"
+	// HealthCheckStatusError is the status of a health check which failed.
+	HealthCheckStatusError = "error"
+	// HealthCheckStatusSkipped is the status of a health check which wasn't run,
+	// because the Kubernetes API server is unreachable.
+	HealthCheckStatusSkipped = "skipped"
+)
+
+// HealthDetails are the details of the result of a KubernetesHealthChecker's CheckHealth,
+// returned as the JSONDetails of the backend.CheckHealthResult.
+type HealthDetails struct {
+	Checks []HealthCheck `json:"checks"`
+}
+
+// HealthCheck is the result of a single check of a KubernetesHealthChecker
+type HealthCheck struct {
+	// Name is the name of the check, such as HealthCheckAPIServer
+	Name string `json:"name"`
+	// Status is one of HealthCheckStatusOK, HealthCheckStatusError, or HealthCheckStatusSkipped
+	Status string `json:"status"`
+	// Message describes why the check failed or was skipped, and is empty if it passed
+	Message string `json:"message,omitempty"`
+}
+
+// KubernetesHealthChecker implements backend.CheckHealthHandler for plugins which store their kinds in Kubernetes.
+// It checks that the Kubernetes API server is reachable, that the namespace exists,
+// and that the CRD of every kind in a resource.SchemaGroup is registered.
+type KubernetesHealthChecker struct {
+	namespace string
+	schemas   resource.SchemaGroup
+	client    rest.Interface
+	manager   *k8s.ResourceManager
+}
+
+// NewKubernetesHealthChecker creates a new KubernetesHealthChecker for the Kubernetes API server of cfg,
+// and the namespace and kinds the plugin uses, which are usually the RestConfig and Namespace of the instance's
+// kubeconfig.NamespacedConfig, and the schemas of the plugin's kinds.
+func NewKubernetesHealthChecker(
+	cfg rest.Config, namespace string, schemas resource.SchemaGroup,
+) (*KubernetesHealthChecker, error) {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultHealthCheckTimeout
+	}
+
+	// Create the manager for the CRD checks, which requires the path of API groups to be set
+	managerCfg := cfg
+	managerCfg.APIPath = "/apis"
+	manager, err := k8s.NewManager(managerCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the kubernetes client for the core API group, which is used for the API server and namespace checks
+	cfg.APIPath = "/api"
+	cfg.GroupVersion = &kschema.GroupVersion{
+		Version: "v1",
+	}
+	cfg.NegotiatedSerializer = serializer.WithoutConversionCodecFactory{
+		CodecFactory: serializer.NewCodecFactory(runtime.NewScheme()),
+	}
+	client, err := rest.RESTClientFor(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KubernetesHealthChecker{
+		namespace: namespace,
+		schemas:   schemas,
+		client:    client,
+		manager:   manager,
+	}, nil
+}
+
+// CheckHealth runs all checks, and returns a result with HealthStatusOk if they all passed, or HealthStatusError
+// if any of them failed. The result of each check is returned in the JSONDetails as HealthDetails.
+// If the API server is unreachable, all other checks are skipped.
+func (h *KubernetesHealthChecker) CheckHealth(
+	ctx context.Context, _ *backend.CheckHealthRequest,
+) (*backend.CheckHealthResult, error) {
+	checks := make([]HealthCheck, 0)
+	checks = append(checks, h.checkAPIServer(ctx))
+	reachable := checks[0].Status == HealthCheckStatusOK
+
+	checks = append(checks, h.runCheck(reachable, HealthCheckNamespace, func() error {
+		return h.checkNamespace(ctx)
+	}))
+	if h.schemas != nil {
+		for _, sch := range h.schemas.Schemas() {
+			s := sch
+			name := fmt.Sprintf("%s%s.%s", HealthCheckCRDPrefix, s.Plural(), s.Group())
+			checks = append(checks, h.runCheck(reachable, name, func() error {
+				return h.checkCRD(ctx, s)
+			}))
+		}
+	}
+
+	details, err := json.Marshal(HealthDetails{
+		Checks: checks,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	failed := make([]string, 0)
+	for _, c := range checks {
+		if c.Status != HealthCheckStatusOK {
+			failed = append(failed, c.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return &backend.CheckHealthResult{
+			Status:      backend.HealthStatusError,
+			Message:     fmt.Sprintf("%d of %d health checks failed: %s", len(failed), len(checks), strings.Join(failed, ", ")),
+			JSONDetails: details,
+		}, nil
+	}
+	return &backend.CheckHealthResult{
+		Status:      backend.HealthStatusOk,
+		Message:     fmt.Sprintf("all %d health checks passed", len(checks)),
+		JSONDetails: details,
+	}, nil
+}
+
+func (h *KubernetesHealthChecker) checkAPIServer(ctx context.Context) HealthCheck {
+	err := h.client.Get().AbsPath("/version").Do(ctx).Error()
+	if err != nil {
+		return HealthCheck{
+			Name:    HealthCheckAPIServer,
+			Status:  HealthCheckStatusError,
+			Message: fmt.Sprintf("API server is unreachable: %s", err.Error()),
+		}
+	}
+	return HealthCheck{
+		Name:   HealthCheckAPIServer,
+		Status: HealthCheckStatusOK,
+	}
+}
+
+func (h *KubernetesHealthChecker) checkNamespace(ctx context.Context) error {
+	sc := 0
+	err := h.client.Get().Resource("namespaces").Name(h.namespace).Do(ctx).StatusCode(&sc).Error()
+	if sc == http.StatusNotFound {
+		return fmt.Errorf("namespace '%s' does not exist", h.namespace)
+	}
+	return err
+}
+
+func (h *KubernetesHealthChecker) checkCRD(ctx context.Context, schema resource.Schema) error {
+	available, err := h.manager.IsAvailable(ctx, schema)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return fmt.Errorf("CRD for kind '%s' is not registered", schema.Kind())
+	}
+	return nil
+}
+
+// runCheck runs check if the API server is reachable, and returns its result as a HealthCheck
+func (*KubernetesHealthChecker) runCheck(reachable bool, name string, check func() error) HealthCheck {
+	if !reachable {
+		return HealthCheck{
+			Name:    name,
+			Status:  HealthCheckStatusSkipped,
+			Message: "API server is unreachable",
+		}
+	}
+	if err := check(); err != nil {
+		return HealthCheck{
+			Name:    name,
+			Status:  HealthCheckStatusError,
+			Message: err.Error(),
+		}
+	}
+	return HealthCheck{
+		Name:   name,
+		Status: HealthCheckStatusOK,
+	}
+}
+
+// Compile-time interface compliance check
+var _ backend.CheckHealthHandler = &KubernetesHealthChecker{}
@@ -0,0 +1,99 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+func TestKubernetesHealthChecker_CheckHealth(t *testing.T) {
+	schemas := resource.NewSimpleSchemaGroup("example.com", "v1")
+	schemas.AddSchema(&resource.SimpleObject[string]{}, resource.WithKind("Foo"))
+	schemas.AddSchema(&resource.SimpleObject[string]{}, resource.WithKind("Bar"))
+
+	// newServer returns a server which responds to the health check requests,
+	// with existing namespaces and CRDs
+	newServer := func(namespaces []string, crds []string) *httptest.Server {
+		found := map[string]bool{
+			"/version": true,
+		}
+		for _, ns := range namespaces {
+			found["/api/v1/namespaces/"+ns] = true
+		}
+		for _, crd := range crds {
+			found["/apis/apiextensions.k8s.io/v1/customresourcedefinitions/"+crd] = true
+		}
+		return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if !found[request.URL.Path] {
+				writer.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writer.Header().Set("Content-Type", "application/json")
+			writer.Write([]byte("{}"))
+		}))
+	}
+
+	check := func(t *testing.T, host string) (*backend.CheckHealthResult, HealthDetails) {
+		checker, err := NewKubernetesHealthChecker(rest.Config{Host: host}, "ns", schemas)
+		require.NoError(t, err)
+		res, err := checker.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		details := HealthDetails{}
+		require.NoError(t, json.Unmarshal(res.JSONDetails, &details))
+		return res, details
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		srv := newServer([]string{"ns"}, []string{"foos.example.com", "bars.example.com"})
+		defer srv.Close()
+
+		res, details := check(t, srv.URL)
+		assert.Equal(t, backend.HealthStatusOk, res.Status)
+		assert.Equal(t, "all 4 health checks passed", res.Message)
+		assert.Equal(t, []HealthCheck{
+			{Name: HealthCheckAPIServer, Status: HealthCheckStatusOK},
+			{Name: HealthCheckNamespace, Status: HealthCheckStatusOK},
+			{Name: "crd/foos.example.com", Status: HealthCheckStatusOK},
+			{Name: "crd/bars.example.com", Status: HealthCheckStatusOK},
+		}, details.Checks)
+	})
+
+	t.Run("missing namespace and CRD", func(t *testing.T) {
+		srv := newServer(nil, []string{"foos.example.com"})
+		defer srv.Close()
+
+		res, details := check(t, srv.URL)
+		assert.Equal(t, backend.HealthStatusError, res.Status)
+		assert.Equal(t, "2 of 4 health checks failed: namespace, crd/bars.example.com", res.Message)
+		assert.Equal(t, []HealthCheck{
+			{Name: HealthCheckAPIServer, Status: HealthCheckStatusOK},
+			{Name: HealthCheckNamespace, Status: HealthCheckStatusError, Message: "namespace 'ns' does not exist"},
+			{Name: "crd/foos.example.com", Status: HealthCheckStatusOK},
+			{Name: "crd/bars.example.com", Status: HealthCheckStatusError, Message: "CRD for kind 'Bar' is not registered"},
+		}, details.Checks)
+	})
+
+	t.Run("unreachable API server", func(t *testing.T) {
+		srv := newServer(nil, nil)
+		srv.Close()
+
+		res, details := check(t, srv.URL)
+		assert.Equal(t, backend.HealthStatusError, res.Status)
+		require.Len(t, details.Checks, 4)
+		assert.Equal(t, HealthCheckAPIServer, details.Checks[0].Name)
+		assert.Equal(t, HealthCheckStatusError, details.Checks[0].Status)
+		assert.Contains(t, details.Checks[0].Message, "API server is unreachable")
+		for _, c := range details.Checks[1:] {
+			assert.Equal(t, HealthCheckStatusSkipped, c.Status)
+		}
+	})
+}
@@ -0,0 +1,258 @@
+package kubeconfig
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/grafana/grafana-app-sdk/metrics"
+)
+
+const (
+	// DefaultCacheMaxSize is the default maximum number of configs cached by a CachingLoader.
+	DefaultCacheMaxSize = 1000
+	// DefaultCacheTTL is the default time after which configs cached by a CachingLoader are evicted if they aren't used.
+	DefaultCacheTTL = time.Hour
+)
+
+const (
+	// EvictionReasonSize is the eviction reason for entries which are evicted
+	// because the cache has reached its maximum size, and they are the least recently used entries.
+	EvictionReasonSize = "size"
+	// EvictionReasonExpired is the eviction reason for entries which are evicted
+	// because they haven't been used for longer than the TTL of the cache.
+	EvictionReasonExpired = "expired"
+	// EvictionReasonReplaced is the eviction reason for entries which are evicted
+	// because a new value was cached for the same config.
+	EvictionReasonReplaced = "replaced"
+)
+
+// CacheConfig is the configuration for the size and expiry of a cache of configs (or values initialized from configs).
+type CacheConfig struct {
+	// MaxSize is the maximum number of entries in the cache.
+	// When a new entry is added to a full cache, the least recently used entry is evicted.
+	// If MaxSize is <= 0, the size of the cache is not bounded.
+	MaxSize int
+	// TTL is the time after which entries which haven't been used are evicted.
+	// Expired entries are evicted when the cache is next used.
+	// If TTL is <= 0, entries don't expire.
+	TTL time.Duration
+	// Name is used as the value of the `cache` label of the cache metrics,
+	// to tell the metrics of different caches apart. If empty, a default name for the type of cache is used.
+	Name string
+	// MetricsConfig is the configuration of the cache metrics.
+	MetricsConfig metrics.Config
+}
+
+// DefaultCacheConfig returns a CacheConfig with DefaultCacheMaxSize and DefaultCacheTTL.
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		MaxSize: DefaultCacheMaxSize,
+		TTL:     DefaultCacheTTL,
+	}
+}
+
+// lruCache is a cache of values keyed by the CRC32 of a NamespacedConfig,
+// which evicts the least recently used entries when it's full, and entries which haven't been used for the TTL.
+// It is safe for concurrent use.
+type lruCache[V any] struct {
+	maxSize int
+	ttl     time.Duration
+	onEvict func(val V, reason string)
+	now     func() time.Time
+
+	lock    sync.Mutex
+	entries map[uint32]*list.Element
+	// order contains the entries from the most recently used to the least recently used
+	order *list.List
+	// loading contains the in-flight loads of values which aren't cached yet
+	loading map[uint32]*lruLoad[V]
+
+	hits      prometheus.Counter
+	misses    prometheus.Counter
+	evictions *prometheus.CounterVec
+}
+
+type lruEntry[V any] struct {
+	key      uint32
+	val      V
+	lastUsed time.Time
+}
+
+// lruLoad is a load of a value for a key, which concurrent lookups of the key wait for
+type lruLoad[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+type lruEviction[V any] struct {
+	val    V
+	reason string
+}
+
+func newLRUCache[V any](cfg CacheConfig, defaultName string, onEvict func(val V, reason string)) *lruCache[V] {
+	name := cfg.Name
+	if name == "" {
+		name = defaultName
+	}
+	labels := prometheus.Labels{"cache": name}
+	return &lruCache[V]{
+		maxSize: cfg.MaxSize,
+		ttl:     cfg.TTL,
+		onEvict: onEvict,
+		now:     time.Now,
+		entries: make(map[uint32]*list.Element),
+		order:   list.New(),
+		loading: make(map[uint32]*lruLoad[V]),
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   cfg.MetricsConfig.Namespace,
+			Subsystem:   "kubeconfig_cache",
+			Name:        "hits_total",
+			Help:        "Total number of kubeconfig cache lookups which found a cached entry",
+			ConstLabels: labels,
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   cfg.MetricsConfig.Namespace,
+			Subsystem:   "kubeconfig_cache",
+			Name:        "misses_total",
+			Help:        "Total number of kubeconfig cache lookups which didn't find a cached entry",
+			ConstLabels: labels,
+		}),
+		evictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   cfg.MetricsConfig.Namespace,
+			Subsystem:   "kubeconfig_cache",
+			Name:        "evictions_total",
+			Help:        "Total number of entries evicted from the kubeconfig cache",
+			ConstLabels: labels,
+		}, []string{"reason"}),
+	}
+}
+
+// errLoadIncomplete is returned to lookups waiting for a load which didn't return (because it panicked)
+var errLoadIncomplete = errors.New("loading the value did not complete")
+
+// getOrLoad returns the cached value for key if present and not expired, or otherwise calls load,
+// and caches the returned value if load doesn't return an error.
+// Concurrent lookups of a key which isn't cached share a single call to load: only the first lookup calls it,
+// and the others wait for it and get the same result (they are counted as hits).
+func (c *lruCache[V]) getOrLoad(key uint32, load func() (V, error)) (V, error) {
+	c.lock.Lock()
+	now := c.now()
+	evicted := c.evictExpired(now)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*lruEntry[V])
+		entry.lastUsed = now
+		val := entry.val
+		c.order.MoveToFront(elem)
+		c.lock.Unlock()
+
+		c.evicted(evicted)
+		c.hits.Inc()
+		return val, nil
+	}
+	if inflight, ok := c.loading[key]; ok {
+		c.lock.Unlock()
+
+		c.evicted(evicted)
+		c.hits.Inc()
+		<-inflight.done
+		return inflight.val, inflight.err
+	}
+	call := &lruLoad[V]{
+		done: make(chan struct{}),
+		err:  errLoadIncomplete,
+	}
+	c.loading[key] = call
+	c.lock.Unlock()
+
+	c.evicted(evicted)
+	c.misses.Inc()
+	defer c.loaded(key, call)
+	call.val, call.err = load()
+	return call.val, call.err
+}
+
+// loaded caches the result of a completed load, if it succeeded, and releases the lookups waiting for it
+func (c *lruCache[V]) loaded(key uint32, call *lruLoad[V]) {
+	c.lock.Lock()
+	delete(c.loading, key)
+	var evicted []lruEviction[V]
+	if call.err == nil {
+		evicted = c.set(key, call.val)
+	}
+	c.lock.Unlock()
+
+	close(call.done)
+	c.evicted(evicted)
+}
+
+// set adds or replaces the cached value for key, evicting the replaced value,
+// and the least recently used entries if the cache is full. The caller must hold the lock.
+func (c *lruCache[V]) set(key uint32, val V) []lruEviction[V] {
+	now := c.now()
+	evicted := c.evictExpired(now)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*lruEntry[V])
+		evicted = append(evicted, lruEviction[V]{
+			val:    entry.val,
+			reason: EvictionReasonReplaced,
+		})
+		entry.val = val
+		entry.lastUsed = now
+		c.order.MoveToFront(elem)
+	} else {
+		c.entries[key] = c.order.PushFront(&lruEntry[V]{
+			key:      key,
+			val:      val,
+			lastUsed: now,
+		})
+	}
+	for c.maxSize > 0 && c.order.Len() > c.maxSize {
+		evicted = append(evicted, c.remove(c.order.Back(), EvictionReasonSize))
+	}
+	return evicted
+}
+
+// evictExpired removes all entries which haven't been used since now-ttl.
+// As entries are ordered by their last use, these are always at the back of the list.
+// The caller must hold the lock.
+func (c *lruCache[V]) evictExpired(now time.Time) []lruEviction[V] {
+	if c.ttl <= 0 {
+		return nil
+	}
+	var evicted []lruEviction[V]
+	for elem := c.order.Back(); elem != nil && now.Sub(elem.Value.(*lruEntry[V]).lastUsed) > c.ttl; elem = c.order.Back() {
+		evicted = append(evicted, c.remove(elem, EvictionReasonExpired))
+	}
+	return evicted
+}
+
+// remove removes elem from the cache. The caller must hold the lock.
+func (c *lruCache[V]) remove(elem *list.Element, reason string) lruEviction[V] {
+	entry := elem.Value.(*lruEntry[V])
+	c.order.Remove(elem)
+	delete(c.entries, entry.key)
+	return lruEviction[V]{
+		val:    entry.val,
+		reason: reason,
+	}
+}
+
+// evicted updates the eviction metrics and calls the eviction callback for each of evicted.
+// It is called without holding the lock, so that callbacks can take as long as they need (or use the cache).
+func (c *lruCache[V]) evicted(evicted []lruEviction[V]) {
+	for _, e := range evicted {
+		c.evictions.WithLabelValues(e.reason).Inc()
+		if c.onEvict != nil {
+			c.onEvict(e.val, e.reason)
+		}
+	}
+}
+
+func (c *lruCache[V]) collectors() []prometheus.Collector {
+	return []prometheus.Collector{c.hits, c.misses, c.evictions}
+}
@@ -0,0 +1,33 @@
+package kubeconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache_set(t *testing.T) {
+	evicted := make([]string, 0)
+	cache := newLRUCache(CacheConfig{MaxSize: 2}, "test", func(val string, reason string) {
+		evicted = append(evicted, val+":"+reason)
+	})
+
+	cache.evicted(cache.set(1, "a"))
+	cache.evicted(cache.set(2, "b"))
+	assert.Empty(t, evicted)
+
+	// Replacing a value evicts the old value
+	cache.evicted(cache.set(1, "a2"))
+	assert.Equal(t, []string{"a:" + EvictionReasonReplaced}, evicted)
+
+	// 2 is now the least recently used
+	cache.evicted(cache.set(3, "c"))
+	assert.Equal(t, []string{"a:" + EvictionReasonReplaced, "b:" + EvictionReasonSize}, evicted)
+
+	val, err := cache.getOrLoad(1, func() (string, error) {
+		t.Fatal("value should be cached")
+		return "", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "a2", val)
+}
@@ -1,7 +1,7 @@
 package kubeconfig
 
 import (
-	"sync"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Initializer is a function that initializes some value T that depends on a Config.
@@ -15,38 +15,71 @@ type Initializer[T any] func(cfg NamespacedConfig) (T, error)
 //
 // Only one value is cached at any given time. Passing a different Config will replace cached value,
 // i.e. with calls like `ini(config1), ini(config2), ini(config1)` the third call will not be cached.
+// To cache values for multiple configs, or to close values when they are replaced, use NewInitializerCache.
 func CachingInitializer[T any](ini Initializer[T]) Initializer[T] {
-	var (
-		// TODO: we could cache multiple values with a map and CRC of the config.
-		// Is it worth it?
-		hasCached bool
-		cachedCfg NamespacedConfig
-		cachedVal T
-		cacheLock sync.RWMutex
-	)
-
-	return func(cfg NamespacedConfig) (T, error) {
-		cacheLock.RLock()
-		if hasCached && cachedCfg.Equals(cfg) {
-			defer cacheLock.RUnlock()
-			// If we've already initialized the value
-			// AND the config hasn't changed,
-			// just return cached value.
-			return cachedVal, nil
-		}
-		cacheLock.RUnlock()
+	return NewInitializerCache(ini, InitializerCacheConfig[T]{
+		CacheConfig: CacheConfig{
+			MaxSize: 1,
+		},
+	}).Initialize
+}
 
-		val, err := ini(cfg)
-		if err != nil {
-			return cachedVal, err
-		}
+// InitializerCacheConfig is the configuration for an InitializerCache.
+type InitializerCacheConfig[T any] struct {
+	CacheConfig
+	// OnEvict is called with each value evicted from the cache, the config it was initialized with,
+	// and the reason it was evicted (EvictionReasonSize, EvictionReasonExpired, or EvictionReasonReplaced), if it is not nil.
+	// It can be used to close clients or stop goroutines which are no longer used.
+	OnEvict func(cfg NamespacedConfig, val T, reason string)
+}
 
-		cacheLock.Lock()
-		hasCached = true
-		cachedVal = val
-		cachedCfg = cfg
-		cacheLock.Unlock()
+// InitializerCache caches values returned from an Initializer for multiple configs.
+//
+// Caching is based on the Config passed to the initializer, so Initialize will only call the initializer once
+// for each Config, until the value for the Config is evicted from the cache (see InitializerCacheConfig).
+//
+// The cache is safe for concurrent use, but MUST NOT be copied after initialization.
+type InitializerCache[T any] struct {
+	ini   Initializer[T]
+	cache *lruCache[initializedValue[T]]
+}
 
-		return cachedVal, nil
+type initializedValue[T any] struct {
+	cfg NamespacedConfig
+	val T
+}
+
+// NewInitializerCache returns a new InitializerCache which caches values returned from ini according to cfg.
+func NewInitializerCache[T any](ini Initializer[T], cfg InitializerCacheConfig[T]) *InitializerCache[T] {
+	var onEvict func(initializedValue[T], string)
+	if cfg.OnEvict != nil {
+		onEvict = func(v initializedValue[T], reason string) {
+			cfg.OnEvict(v.cfg, v.val, reason)
+		}
 	}
+	return &InitializerCache[T]{
+		ini:   ini,
+		cache: newLRUCache(cfg.CacheConfig, "initializer", onEvict),
+	}
+}
+
+// Initialize returns the cached value for cfg, or calls the initializer and caches the returned value
+// if there is no cached value for cfg. Values are not cached if the initializer returns an error.
+// Concurrent calls with the same uncached cfg only call the initializer once, and all return its result.
+// Initialize has the signature of an Initializer, so it can be used in place of one.
+func (c *InitializerCache[T]) Initialize(cfg NamespacedConfig) (T, error) {
+	v, err := c.cache.getOrLoad(cfg.CRC32, func() (initializedValue[T], error) {
+		val, err := c.ini(cfg)
+		return initializedValue[T]{
+			cfg: cfg,
+			val: val,
+		}, err
+	})
+	return v.val, err
+}
+
+// PrometheusCollectors returns the prometheus metric collectors of the cache (hits, misses and evictions)
+// to allow for registration
+func (c *InitializerCache[T]) PrometheusCollectors() []prometheus.Collector {
+	return c.cache.collectors()
 }
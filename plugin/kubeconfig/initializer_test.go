@@ -1,8 +1,12 @@
 package kubeconfig_test
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/client-go/rest"
 
@@ -66,3 +70,139 @@ func TestCachingInitializer(t *testing.T) {
 		assert.Equal(t, 3, numCalls)
 	})
 }
+
+func TestInitializerCache(t *testing.T) {
+	newConfig := func(crc uint32) kubeconfig.NamespacedConfig {
+		return kubeconfig.NamespacedConfig{
+			CRC32:     crc,
+			Namespace: "default",
+		}
+	}
+
+	t.Run("should evict least recently used values", func(t *testing.T) {
+		var numCalls int
+		evicted := make([]uint32, 0)
+
+		cache := kubeconfig.NewInitializerCache(func(cfg kubeconfig.NamespacedConfig) (uint32, error) {
+			numCalls++
+			return cfg.CRC32, nil
+		}, kubeconfig.InitializerCacheConfig[uint32]{
+			CacheConfig: kubeconfig.CacheConfig{
+				MaxSize: 2,
+			},
+			OnEvict: func(cfg kubeconfig.NamespacedConfig, val uint32, reason string) {
+				assert.Equal(t, cfg.CRC32, val)
+				assert.Equal(t, kubeconfig.EvictionReasonSize, reason)
+				evicted = append(evicted, val)
+			},
+		})
+
+		_, _ = cache.Initialize(newConfig(1))
+		_, _ = cache.Initialize(newConfig(2))
+		_, _ = cache.Initialize(newConfig(1)) // 1 is now the most recently used
+		assert.Equal(t, 2, numCalls)
+		assert.Empty(t, evicted)
+
+		_, _ = cache.Initialize(newConfig(3))
+		assert.Equal(t, []uint32{2}, evicted)
+
+		val, err := cache.Initialize(newConfig(1))
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), val)
+		assert.Equal(t, 3, numCalls)
+
+		assert.Equal(t, float64(2), testutil.ToFloat64(cache.PrometheusCollectors()[0]))
+		assert.Equal(t, float64(3), testutil.ToFloat64(cache.PrometheusCollectors()[1]))
+		assert.Equal(t, 1, testutil.CollectAndCount(cache.PrometheusCollectors()[2]))
+	})
+
+	t.Run("should evict expired values", func(t *testing.T) {
+		var numCalls int
+		evicted := make([]string, 0)
+
+		cache := kubeconfig.NewInitializerCache(func(cfg kubeconfig.NamespacedConfig) (uint32, error) {
+			numCalls++
+			return cfg.CRC32, nil
+		}, kubeconfig.InitializerCacheConfig[uint32]{
+			CacheConfig: kubeconfig.CacheConfig{
+				TTL: 50 * time.Millisecond,
+			},
+			OnEvict: func(_ kubeconfig.NamespacedConfig, _ uint32, reason string) {
+				evicted = append(evicted, reason)
+			},
+		})
+
+		_, _ = cache.Initialize(newConfig(1))
+		_, _ = cache.Initialize(newConfig(1))
+		assert.Equal(t, 1, numCalls)
+
+		time.Sleep(100 * time.Millisecond)
+		_, _ = cache.Initialize(newConfig(1))
+		assert.Equal(t, 2, numCalls)
+		assert.Equal(t, []string{kubeconfig.EvictionReasonExpired}, evicted)
+	})
+
+	t.Run("should not cache errors", func(t *testing.T) {
+		var numCalls int
+
+		cache := kubeconfig.NewInitializerCache(func(cfg kubeconfig.NamespacedConfig) (uint32, error) {
+			numCalls++
+			return 0, assert.AnError
+		}, kubeconfig.InitializerCacheConfig[uint32]{})
+
+		_, err := cache.Initialize(newConfig(1))
+		assert.ErrorIs(t, err, assert.AnError)
+		_, err = cache.Initialize(newConfig(1))
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 2, numCalls)
+	})
+}
+
+func TestInitializerCache_Concurrent(t *testing.T) {
+	var numCalls atomic.Int32
+	var numEvicted atomic.Int32
+	release := make(chan struct{})
+
+	cache := kubeconfig.NewInitializerCache(func(cfg kubeconfig.NamespacedConfig) (*int32, error) {
+		n := numCalls.Add(1)
+		<-release
+		return &n, nil
+	}, kubeconfig.InitializerCacheConfig[*int32]{
+		CacheConfig: kubeconfig.DefaultCacheConfig(),
+		OnEvict: func(kubeconfig.NamespacedConfig, *int32, string) {
+			numEvicted.Add(1)
+		},
+	})
+
+	cfg := kubeconfig.NamespacedConfig{
+		CRC32:     123,
+		Namespace: "default",
+	}
+	results := make(chan *int32, 5)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := cache.Initialize(cfg)
+			assert.NoError(t, err)
+			results <- val
+		}()
+	}
+	// Give all calls time to wait for the first one before it returns
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	assert.Equal(t, int32(1), numCalls.Load())
+	assert.Equal(t, int32(0), numEvicted.Load())
+	first := <-results
+	for val := range results {
+		assert.Same(t, first, val)
+	}
+	val, err := cache.Initialize(cfg)
+	assert.NoError(t, err)
+	assert.Same(t, first, val)
+	assert.Equal(t, int32(1), numCalls.Load())
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
@@ -33,24 +34,52 @@ type ChecksumLoader interface {
 	CRC32(config, namespace string) (uint32, error)
 }
 
+// RawConfigLoader is implemented by loaders which restrict the config sources that can be loaded from secureJsonData.
+// CachingLoader uses it to load raw config data with the restrictions of the loader it wraps.
+type RawConfigLoader interface {
+	LoadRawConfig(src map[string]string) (cfg string, ns string, err error)
+}
+
+// LoaderConfig is the configuration for a Loader.
+// Config sources which can run commands or read files on the host are refused unless they are allowed here,
+// as anyone who can edit the app's settings can set them.
+type LoaderConfig struct {
+	// AllowExec allows ConfigSourceExec and ConfigSourceAuthProvider, which run the configured command
+	// or auth provider plugin on the host, and users with an exec or auth-provider in kubeconfig documents.
+	AllowExec bool
+	// AllowedPathPrefixes are the directories which KeyTokenFile and KeyCAFile paths are allowed to be in,
+	// as are the tokenFile, client-certificate, client-key, and certificate-authority paths in kubeconfig documents.
+	// Paths must be absolute, and are refused if this is empty.
+	AllowedPathPrefixes []string
+}
+
 // Loader is a ConfigLoader that loads NamespacedConfig
 // from serialized config and namespace values.
 //
 // The loader is safe for concurrent use, but MUST NOT be copied after initialization.
 type Loader struct {
+	cfg  LoaderConfig
 	hash hash.Hash32
 	lock sync.Mutex
 }
 
-// NewLoader returns a new Loader.
+// NewLoader returns a new Loader, which refuses config sources that run commands or read files on the host.
 func NewLoader() *Loader {
+	return NewLoaderWithConfig(LoaderConfig{})
+}
+
+// NewLoaderWithConfig returns a new Loader which allows the config sources in cfg.
+func NewLoaderWithConfig(cfg LoaderConfig) *Loader {
 	return &Loader{
+		cfg:  cfg,
 		hash: crc32.NewIEEE(),
 	}
 }
 
 // Load loads the NamespacedConfig into dst.
 // An error will be returned upon any failures (e.g. missing or malformed data).
+// Users and clusters of the kubeconfig document which run commands or read files on the host are refused
+// unless they are allowed by the loader's LoaderConfig, as they are for the config sources in LoadRawConfig.
 // Load IS NOT guaranteed to clear dst - the caller is responsible for that.
 func (c *Loader) Load(config, namespace string, dst *NamespacedConfig) error {
 	crc, err := c.CRC32(config, namespace)
@@ -73,6 +102,9 @@ func (c *Loader) Load(config, namespace string, dst *NamespacedConfig) error {
 		if err != nil {
 			return err
 		}
+		if err := c.cfg.checkConfig(ccfg); err != nil {
+			return err
+		}
 
 		// TODO: figure out if we need to switch context and such.
 		cfg, err = clientcmd.NewDefaultClientConfig(*ccfg, nil).ClientConfig()
@@ -92,7 +124,7 @@ func (c *Loader) Load(config, namespace string, dst *NamespacedConfig) error {
 
 // LoadFromSettings loads the config from the AppInstanceSettings.
 func (c *Loader) LoadFromSettings(set backend.AppInstanceSettings, dst *NamespacedConfig) error {
-	cf, ns, err := LoadRawConfig(set.DecryptedSecureJSONData)
+	cf, ns, err := c.LoadRawConfig(set.DecryptedSecureJSONData)
 	if err != nil {
 		return err
 	}
@@ -100,6 +132,12 @@ func (c *Loader) LoadFromSettings(set backend.AppInstanceSettings, dst *Namespac
 	return c.Load(cf, ns, dst)
 }
 
+// LoadRawConfig loads raw config data from decrypted secureJsonData (see LoadRawConfig),
+// allowing the config sources in the loader's LoaderConfig.
+func (c *Loader) LoadRawConfig(src map[string]string) (cfg string, ns string, err error) {
+	return loadRawConfig(src, c.cfg)
+}
+
 // CRC32 returns the CRC 32 value of config and namespace strings.
 func (c *Loader) CRC32(config, namespace string) (uint32, error) {
 	c.lock.Lock()
@@ -123,22 +161,42 @@ func (c *Loader) CRC32(config, namespace string) (uint32, error) {
 // and caches the result for the next calls.
 //
 // Caching is done based on a CRC32 of the config.
+// The cache is bounded in size, and unused configs expire (see CachingLoaderConfig).
 //
 // The loader is safe for concurrent use, but MUST NOT be copied after initialization.
 type CachingLoader struct {
-	load ChecksumLoader
-	stor sync.Map
+	load  ChecksumLoader
+	cache *lruCache[NamespacedConfig]
+}
+
+// CachingLoaderConfig is the configuration for a CachingLoader.
+type CachingLoaderConfig struct {
+	CacheConfig
+	// OnEvict is called with each config evicted from the cache, and the reason it was evicted
+	// (EvictionReasonSize, EvictionReasonExpired, or EvictionReasonReplaced), if it is not nil.
+	OnEvict func(cfg NamespacedConfig, reason string)
 }
 
-// NewCachingLoader returns a new CachingLoader with an empty cache.
+// NewCachingLoader returns a new CachingLoader with an empty cache,
+// which caches up to DefaultCacheMaxSize configs, and evicts configs which haven't been used for DefaultCacheTTL.
 func NewCachingLoader() *CachingLoader {
 	return NewCustomCachingLoader(NewLoader())
 }
 
-// NewCustomCachingLoader returns a new CachingLoader that uses loader for loading configs.
+// NewCustomCachingLoader returns a new CachingLoader that uses loader for loading configs,
+// which caches up to DefaultCacheMaxSize configs, and evicts configs which haven't been used for DefaultCacheTTL.
 func NewCustomCachingLoader(loader ChecksumLoader) *CachingLoader {
+	return NewCachingLoaderWithConfig(loader, CachingLoaderConfig{
+		CacheConfig: DefaultCacheConfig(),
+	})
+}
+
+// NewCachingLoaderWithConfig returns a new CachingLoader that uses loader for loading configs,
+// and caches them according to cfg.
+func NewCachingLoaderWithConfig(loader ChecksumLoader, cfg CachingLoaderConfig) *CachingLoader {
 	return &CachingLoader{
-		load: loader,
+		load:  loader,
+		cache: newLRUCache(cfg.CacheConfig, "loader", cfg.OnEvict),
 	}
 }
 
@@ -151,30 +209,39 @@ func (c *CachingLoader) Load(config, namespace string, dst *NamespacedConfig) er
 		return err
 	}
 
-	val, ok := c.stor.Load(crc)
-	if ok {
-		if res, ok := val.(NamespacedConfig); ok {
-			// Only return cached config if it's OK.
-			// Otherwise we reload it (e.g. upon corruption).
-			*dst = res
-			return nil
-		}
-	}
-
-	if err := c.load.Load(config, namespace, dst); err != nil {
+	// Concurrent loads of the same config share a single call to the underlying loader.
+	// Make sure we cache by value, otherwise it can change (because pointers).
+	res, err := c.cache.getOrLoad(crc, func() (NamespacedConfig, error) {
+		loaded := NamespacedConfig{}
+		err := c.load.Load(config, namespace, &loaded)
+		return loaded, err
+	})
+	if err != nil {
 		return err
 	}
 
-	// Cache config for future use.
-	// Make sure we cache by value, otherwise it can change (because pointers).
-	c.stor.Store(crc, *dst)
-
+	*dst = res
 	return nil
 }
 
+// PrometheusCollectors returns the prometheus metric collectors of the cache (hits, misses and evictions)
+// to allow for registration
+func (c *CachingLoader) PrometheusCollectors() []prometheus.Collector {
+	return c.cache.collectors()
+}
+
 // LoadFromSettings loads the config from the AppInstanceSettings.
+// If the underlying loader is a RawConfigLoader, it is used to load the raw config data, otherwise LoadRawConfig is used.
 func (c *CachingLoader) LoadFromSettings(set backend.AppInstanceSettings, dst *NamespacedConfig) error {
-	cf, ns, err := LoadRawConfig(set.DecryptedSecureJSONData)
+	var (
+		cf, ns string
+		err    error
+	)
+	if raw, ok := c.load.(RawConfigLoader); ok {
+		cf, ns, err = raw.LoadRawConfig(set.DecryptedSecureJSONData)
+	} else {
+		cf, ns, err = LoadRawConfig(set.DecryptedSecureJSONData)
+	}
 	if err != nil {
 		return err
 	}
@@ -196,10 +263,20 @@ const (
 )
 
 // LoadRawConfig loads raw config data from decrypted secureJsonData.
+// The config is loaded from the source in the KeyConfigSource key (see the ConfigSource constants),
+// which defaults to ConfigSourceKubeconfig. For sources other than ConfigSourceKubeconfig,
+// cfg is a kubeconfig document built from the source's keys.
+//
+// Config sources which run commands or read files on the host are refused with ErrConfigSourceNotAllowed
+// or ErrPathNotAllowed. Use a Loader created with NewLoaderWithConfig to allow them.
 func LoadRawConfig(src map[string]string) (cfg string, ns string, err error) {
-	cval, ok := src[KeyConfig]
-	if !ok {
-		return "", "", ErrConfigMissing
+	return loadRawConfig(src, LoaderConfig{})
+}
+
+func loadRawConfig(src map[string]string, allowed LoaderConfig) (cfg string, ns string, err error) {
+	cval, err := loadSourceConfig(strings.TrimSpace(src[KeyConfigSource]), src, allowed)
+	if err != nil {
+		return "", "", err
 	}
 
 	nval, ok := src[KeyNamespace]
@@ -209,10 +286,6 @@ func LoadRawConfig(src map[string]string) (cfg string, ns string, err error) {
 
 	// AppInstallation controller uses standard base64 encoding for setting kubeconfig / namespace values,
 	// so we try to decode them here and if it fails we fall back gracefully to unencoded values.
-	if dec, err := base64.StdEncoding.DecodeString(cval); err == nil {
-		cval = string(dec)
-	}
-
 	if dec, err := base64.StdEncoding.DecodeString(nval); err == nil {
 		nval = strings.TrimSpace(string(dec))
 	}
@@ -2,10 +2,17 @@ package kubeconfig_test
 
 import (
 	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/grafana/grafana-app-sdk/plugin/kubeconfig"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -364,6 +371,313 @@ current-context: default
 	}
 }
 
+func TestLoader_LoadFromSettings_Sources(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("file-token"), 0600))
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, []byte("data"), 0600))
+	allowed := kubeconfig.LoaderConfig{
+		AllowExec:           true,
+		AllowedPathPrefixes: []string{dir},
+	}
+	// document returns a kubeconfig document with the user and cluster, and another unused user
+	document := func(user, cluster string) string {
+		return `{"kind":"Config","apiVersion":"v1",` +
+			`"clusters":[{"name":"cluster","cluster":{"server":"https://localhost:6443",` + cluster + `}}],` +
+			`"users":[{"name":"user","user":{` + user + `}},{"name":"other","user":{"token":"other-token"}}],` +
+			`"contexts":[{"name":"default","context":{"cluster":"cluster","user":"user"}}],"current-context":"default"}`
+	}
+	execUser := `"exec":{"apiVersion":"client.authentication.k8s.io/v1","command":"get-token","interactiveMode":"Never"}`
+
+	tests := []struct {
+		name    string
+		cfg     kubeconfig.LoaderConfig
+		data    map[string]string
+		check   func(t *testing.T, cfg rest.Config)
+		wantErr error
+	}{
+		{
+			name: "token with PEM CA data",
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceToken,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyToken:        "my-token",
+				kubeconfig.KeyCAData:       "-----BEGIN CERTIFICATE-----\ndata\n-----END CERTIFICATE-----\n",
+			},
+			check: func(t *testing.T, cfg rest.Config) {
+				assert.Equal(t, "https://localhost:6443", cfg.Host)
+				assert.Equal(t, "/apis", cfg.APIPath)
+				assert.Equal(t, "my-token", cfg.BearerToken)
+				assert.Equal(t, []byte("-----BEGIN CERTIFICATE-----\ndata\n-----END CERTIFICATE-----\n"), cfg.CAData)
+			},
+		},
+		{
+			name: "token with base64-encoded CA data",
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceToken,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyToken:        "my-token",
+				kubeconfig.KeyCAData:       "ZGF0YQo=",
+			},
+			check: func(t *testing.T, cfg rest.Config) {
+				assert.Equal(t, []byte("data\n"), cfg.CAData)
+			},
+		},
+		{
+			name: "token file",
+			cfg:  allowed,
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceTokenFile,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyTokenFile:    tokenFile,
+				kubeconfig.KeyCAFile:       caFile,
+			},
+			check: func(t *testing.T, cfg rest.Config) {
+				assert.Equal(t, "file-token", cfg.BearerToken)
+				assert.Equal(t, tokenFile, cfg.BearerTokenFile)
+				assert.Equal(t, caFile, cfg.CAFile)
+			},
+		},
+		{
+			name: "exec",
+			cfg:  allowed,
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceExec,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyExec:         `{"command":"get-token","args":["--cluster","test"],"env":[{"name":"FOO","value":"bar"}]}`,
+			},
+			check: func(t *testing.T, cfg rest.Config) {
+				require.NotNil(t, cfg.ExecProvider)
+				assert.Equal(t, "get-token", cfg.ExecProvider.Command)
+				assert.Equal(t, []string{"--cluster", "test"}, cfg.ExecProvider.Args)
+				assert.Equal(t, []clientcmdapi.ExecEnvVar{{Name: "FOO", Value: "bar"}}, cfg.ExecProvider.Env)
+				assert.Equal(t, "client.authentication.k8s.io/v1", cfg.ExecProvider.APIVersion)
+				assert.Equal(t, clientcmdapi.NeverExecInteractiveMode, cfg.ExecProvider.InteractiveMode)
+			},
+		},
+		{
+			name: "auth provider",
+			cfg:  allowed,
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceAuthProvider,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyAuthProvider: `{"name":"oidc","config":{"client-id":"grafana"}}`,
+			},
+			check: func(t *testing.T, cfg rest.Config) {
+				assert.Equal(t, &clientcmdapi.AuthProviderConfig{
+					Name:   "oidc",
+					Config: map[string]string{"client-id": "grafana"},
+				}, cfg.AuthProvider)
+			},
+		},
+		{
+			name: "token file not allowed by default",
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceTokenFile,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyTokenFile:    tokenFile,
+			},
+			wantErr: kubeconfig.ErrPathNotAllowed,
+		},
+		{
+			name: "token file outside of allowed prefixes",
+			cfg:  allowed,
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceTokenFile,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyTokenFile:    filepath.Join(dir, "..", "token"),
+			},
+			wantErr: kubeconfig.ErrPathNotAllowed,
+		},
+		{
+			name: "relative token file",
+			cfg:  allowed,
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceTokenFile,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyTokenFile:    "token",
+			},
+			wantErr: kubeconfig.ErrPathNotAllowed,
+		},
+		{
+			name: "CA file not allowed by default",
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceToken,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyToken:        "my-token",
+				kubeconfig.KeyCAFile:       caFile,
+			},
+			wantErr: kubeconfig.ErrPathNotAllowed,
+		},
+		{
+			name: "CA file in a directory sharing the allowed prefix",
+			cfg:  allowed,
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceToken,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyToken:        "my-token",
+				kubeconfig.KeyCAFile:       dir + "-other/ca.crt",
+			},
+			wantErr: kubeconfig.ErrPathNotAllowed,
+		},
+		{
+			name: "exec not allowed by default",
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceExec,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyExec:         `{"command":"get-token"}`,
+			},
+			wantErr: kubeconfig.ErrConfigSourceNotAllowed,
+		},
+		{
+			name: "auth provider not allowed by default",
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceAuthProvider,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+				kubeconfig.KeyAuthProvider: `{"name":"oidc"}`,
+			},
+			wantErr: kubeconfig.ErrConfigSourceNotAllowed,
+		},
+		{
+			name: "kubeconfig document with exec, token file, and CA file",
+			cfg:  allowed,
+			data: map[string]string{
+				kubeconfig.KeyConfig: document(execUser+`,"tokenFile":"`+tokenFile+`"`, `"certificate-authority":"`+caFile+`"`),
+			},
+			check: func(t *testing.T, cfg rest.Config) {
+				require.NotNil(t, cfg.ExecProvider)
+				assert.Equal(t, "get-token", cfg.ExecProvider.Command)
+				assert.Equal(t, tokenFile, cfg.BearerTokenFile)
+				assert.Equal(t, caFile, cfg.CAFile)
+			},
+		},
+		{
+			name: "kubeconfig document with exec not allowed by default",
+			data: map[string]string{
+				kubeconfig.KeyConfig: document(execUser, `"insecure-skip-tls-verify":true`),
+			},
+			wantErr: kubeconfig.ErrConfigSourceNotAllowed,
+		},
+		{
+			name: "kubeconfig document with exec for an unused user not allowed by default",
+			data: map[string]string{
+				kubeconfig.KeyConfig: strings.Replace(document(`"token":"my-token"`, `"insecure-skip-tls-verify":true`),
+					`"token":"other-token"`, execUser, 1),
+			},
+			wantErr: kubeconfig.ErrConfigSourceNotAllowed,
+		},
+		{
+			name: "kubeconfig document with auth provider not allowed by default",
+			data: map[string]string{
+				kubeconfig.KeyConfig: document(`"auth-provider":{"name":"oidc"}`, `"insecure-skip-tls-verify":true`),
+			},
+			wantErr: kubeconfig.ErrConfigSourceNotAllowed,
+		},
+		{
+			name: "kubeconfig document with token file not allowed by default",
+			data: map[string]string{
+				kubeconfig.KeyConfig: document(`"tokenFile":"`+tokenFile+`"`, `"insecure-skip-tls-verify":true`),
+			},
+			wantErr: kubeconfig.ErrPathNotAllowed,
+		},
+		{
+			name: "kubeconfig document with client certificate outside of allowed prefixes",
+			cfg:  allowed,
+			data: map[string]string{
+				kubeconfig.KeyConfig: document(`"client-certificate":"/etc/tls.crt","client-key":"`+caFile+`"`,
+					`"insecure-skip-tls-verify":true`),
+			},
+			wantErr: kubeconfig.ErrPathNotAllowed,
+		},
+		{
+			name: "kubeconfig document with client key outside of allowed prefixes",
+			cfg:  allowed,
+			data: map[string]string{
+				kubeconfig.KeyConfig: document(`"client-certificate":"`+caFile+`","client-key":"/etc/tls.key"`,
+					`"insecure-skip-tls-verify":true`),
+			},
+			wantErr: kubeconfig.ErrPathNotAllowed,
+		},
+		{
+			name: "kubeconfig document with CA file not allowed by default",
+			data: map[string]string{
+				kubeconfig.KeyConfig: document(`"token":"my-token"`, `"certificate-authority":"`+caFile+`"`),
+			},
+			wantErr: kubeconfig.ErrPathNotAllowed,
+		},
+		{
+			name: "missing server",
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceToken,
+				kubeconfig.KeyToken:        "my-token",
+			},
+			wantErr: kubeconfig.ErrConfigMissing,
+		},
+		{
+			name: "missing token",
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceToken,
+				kubeconfig.KeyServer:       "https://localhost:6443",
+			},
+			wantErr: kubeconfig.ErrConfigMissing,
+		},
+		{
+			name: "unknown source",
+			data: map[string]string{
+				kubeconfig.KeyConfigSource: "magic",
+			},
+			wantErr: kubeconfig.ErrUnknownConfigSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.data[kubeconfig.KeyNamespace] = "custom"
+
+			var res kubeconfig.NamespacedConfig
+			err := kubeconfig.NewLoaderWithConfig(tt.cfg).LoadFromSettings(backend.AppInstanceSettings{
+				DecryptedSecureJSONData: tt.data,
+			}, &res)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "custom", res.Namespace)
+			tt.check(t, res.RestConfig)
+		})
+	}
+}
+
+func TestCachingLoader_LoadFromSettings_Sources(t *testing.T) {
+	settings := backend.AppInstanceSettings{
+		DecryptedSecureJSONData: map[string]string{
+			kubeconfig.KeyConfigSource: kubeconfig.ConfigSourceExec,
+			kubeconfig.KeyServer:       "https://localhost:6443",
+			kubeconfig.KeyExec:         `{"command":"get-token"}`,
+			kubeconfig.KeyNamespace:    "custom",
+		},
+	}
+
+	t.Run("refused by default", func(t *testing.T) {
+		var res kubeconfig.NamespacedConfig
+		err := kubeconfig.NewCachingLoader().LoadFromSettings(settings, &res)
+		assert.ErrorIs(t, err, kubeconfig.ErrConfigSourceNotAllowed)
+	})
+
+	t.Run("allowed by the underlying loader", func(t *testing.T) {
+		var res kubeconfig.NamespacedConfig
+		l := kubeconfig.NewCustomCachingLoader(kubeconfig.NewLoaderWithConfig(kubeconfig.LoaderConfig{
+			AllowExec: true,
+		}))
+		require.NoError(t, l.LoadFromSettings(settings, &res))
+		require.NotNil(t, res.RestConfig.ExecProvider)
+		assert.Equal(t, "get-token", res.RestConfig.ExecProvider.Command)
+	})
+}
+
 func TestCachingLoader_Load(t *testing.T) {
 	t.Run("should forward errors", func(t *testing.T) {
 		assert.Error(
@@ -472,6 +786,49 @@ func TestCachingLoader_Load(t *testing.T) {
 	})
 }
 
+func TestCachingLoader_Eviction(t *testing.T) {
+	var loadCalls int
+	evicted := make([]string, 0)
+
+	l := kubeconfig.NewCachingLoaderWithConfig(&FakeLoader{
+		loadFn: func(conf, ns string, nc *kubeconfig.NamespacedConfig) error {
+			loadCalls++
+			nc.Namespace = ns
+			return nil
+		},
+		crcFn: func(conf, ns string) (uint32, error) {
+			return uint32(len(ns)), nil
+		},
+	}, kubeconfig.CachingLoaderConfig{
+		CacheConfig: kubeconfig.CacheConfig{
+			MaxSize: 1,
+			TTL:     50 * time.Millisecond,
+		},
+		OnEvict: func(cfg kubeconfig.NamespacedConfig, reason string) {
+			evicted = append(evicted, cfg.Namespace+":"+reason)
+		},
+	})
+
+	var res kubeconfig.NamespacedConfig
+	assert.NoError(t, l.Load("config", "a", &res))
+	assert.NoError(t, l.Load("config", "a", &res))
+	assert.Equal(t, 1, loadCalls)
+
+	assert.NoError(t, l.Load("config", "bb", &res))
+	assert.Equal(t, 2, loadCalls)
+	assert.Equal(t, []string{"a:" + kubeconfig.EvictionReasonSize}, evicted)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, l.Load("config", "bb", &res))
+	assert.Equal(t, "bb", res.Namespace)
+	assert.Equal(t, 3, loadCalls)
+	assert.Equal(t, []string{"a:" + kubeconfig.EvictionReasonSize, "bb:" + kubeconfig.EvictionReasonExpired}, evicted)
+
+	collectors := l.PrometheusCollectors()
+	assert.Equal(t, float64(1), testutil.ToFloat64(collectors[0]))
+	assert.Equal(t, float64(3), testutil.ToFloat64(collectors[1]))
+}
+
 type FakeLoader struct {
 	crcFn     func(string, string) (uint32, error)
 	loadFn    func(string, string, *kubeconfig.NamespacedConfig) error
@@ -0,0 +1,313 @@
+package kubeconfig
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+var (
+	// ErrUnknownConfigSource is the error returned when secureJsonData
+	// contains a config source which is not supported.
+	ErrUnknownConfigSource = errors.New("unknown config source in secureJsonData")
+
+	// ErrConfigSourceNotAllowed is the error returned when secureJsonData
+	// contains a config source which runs commands on the host, and it is not allowed by the LoaderConfig.
+	ErrConfigSourceNotAllowed = errors.New("config source is not allowed")
+
+	// ErrPathNotAllowed is the error returned when secureJsonData
+	// contains a file path which is not in one of the LoaderConfig's AllowedPathPrefixes.
+	ErrPathNotAllowed = errors.New("path is not allowed")
+)
+
+const (
+	// KeyConfigSource is the key in secureJsonData used for looking up the source of the kubeconfig.
+	// If it is not present, ConfigSourceKubeconfig is used.
+	KeyConfigSource = "kubeconfigsource"
+
+	// KeyServer is the key in secureJsonData used for looking up the URL of the Kubernetes API server.
+	// It is used by all config sources except ConfigSourceKubeconfig and ConfigSourceInCluster.
+	KeyServer = "kubeserver"
+
+	// KeyCAData is the key in secureJsonData used for looking up the CA bundle of the Kubernetes API server,
+	// either as PEM-encoded certificates, or as base64-encoded PEM-encoded certificates.
+	// It is optional, and if neither it nor KeyCAFile are present, the system's CA bundle is used.
+	KeyCAData = "kubecadata"
+
+	// KeyCAFile is the key in secureJsonData used for looking up the path to a CA bundle file
+	// of the Kubernetes API server. It is optional, and ignored if KeyCAData is present.
+	// The path must be in one of the LoaderConfig's AllowedPathPrefixes.
+	KeyCAFile = "kubecafile"
+
+	// KeyToken is the key in secureJsonData used for looking up the bearer token used by ConfigSourceToken.
+	KeyToken = "kubetoken"
+
+	// KeyTokenFile is the key in secureJsonData used for looking up the path to the token file
+	// used by ConfigSourceTokenFile.
+	KeyTokenFile = "kubetokenfile"
+
+	// KeyExec is the key in secureJsonData used for looking up the exec credential plugin used by ConfigSourceExec.
+	// The value is a JSON object in the same format as the `exec` section of a kubeconfig user.
+	KeyExec = "kubeexec"
+
+	// KeyAuthProvider is the key in secureJsonData used for looking up the auth provider used by ConfigSourceAuthProvider.
+	// The value is a JSON object in the same format as the `auth-provider` section of a kubeconfig user.
+	KeyAuthProvider = "kubeauthprovider"
+)
+
+const (
+	// ConfigSourceKubeconfig loads the whole kubeconfig document from the KeyConfig key in secureJsonData.
+	ConfigSourceKubeconfig = "kubeconfig"
+
+	// ConfigSourceInCluster uses the in-cluster config of the pod the plugin runs in.
+	// It is equivalent to ConfigSourceKubeconfig with a kubeconfig value of "cluster".
+	ConfigSourceInCluster = "cluster"
+
+	// ConfigSourceToken authenticates to KeyServer with the bearer token from KeyToken.
+	ConfigSourceToken = "token"
+
+	// ConfigSourceTokenFile authenticates to KeyServer with the bearer token read from the file at the path in KeyTokenFile,
+	// such as a projected service account token. The file is periodically re-read, so rotated tokens are picked up
+	// without reloading the config. The path must be in one of the LoaderConfig's AllowedPathPrefixes.
+	ConfigSourceTokenFile = "tokenfile"
+
+	// ConfigSourceExec authenticates to KeyServer with credentials provided by the exec credential plugin in KeyExec.
+	// If the plugin doesn't specify an apiVersion, client.authentication.k8s.io/v1 is used,
+	// and if it doesn't specify an interactiveMode, Never is used, as plugins can't interact with users.
+	// It must be allowed with the LoaderConfig's AllowExec.
+	ConfigSourceExec = "exec"
+
+	// ConfigSourceAuthProvider authenticates to KeyServer with the auth provider plugin in KeyAuthProvider.
+	// It must be allowed with the LoaderConfig's AllowExec. The auth provider plugin must be registered with client-go, usually by importing it, for example:
+	//
+	//	import _ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
+	ConfigSourceAuthProvider = "authprovider"
+)
+
+const (
+	defaultExecAPIVersion      = "client.authentication.k8s.io/v1"
+	defaultExecInteractiveMode = clientcmdv1.NeverExecInteractiveMode
+	sourceClusterName          = "cluster"
+	sourceUserName             = "user"
+	sourceContextName          = "default"
+)
+
+// loadSourceConfig returns the serialized kubeconfig for source from decrypted secureJsonData.
+// For ConfigSourceKubeconfig, this is the value of KeyConfig,
+// and for all other sources a kubeconfig document is built from the source's keys.
+// Sources and paths which are not allowed by the LoaderConfig are refused.
+func loadSourceConfig(source string, src map[string]string, allowed LoaderConfig) (string, error) {
+	switch source {
+	case "", ConfigSourceKubeconfig:
+		cval, ok := src[KeyConfig]
+		if !ok {
+			return "", ErrConfigMissing
+		}
+
+		// AppInstallation controller uses standard base64 encoding for setting kubeconfig / namespace values,
+		// so we try to decode them here and if it fails we fall back gracefully to unencoded values.
+		if dec, err := base64.StdEncoding.DecodeString(cval); err == nil {
+			cval = string(dec)
+		}
+
+		return cval, nil
+	case ConfigSourceInCluster:
+		return "cluster", nil
+	}
+
+	user := clientcmdv1.AuthInfo{}
+	switch source {
+	case ConfigSourceToken:
+		token, err := requiredKey(source, src, KeyToken)
+		if err != nil {
+			return "", err
+		}
+		user.Token = token
+	case ConfigSourceTokenFile:
+		file, err := requiredKey(source, src, KeyTokenFile)
+		if err != nil {
+			return "", err
+		}
+		if err := allowed.checkPath(KeyTokenFile, file); err != nil {
+			return "", err
+		}
+		user.TokenFile = file
+	case ConfigSourceExec:
+		if !allowed.AllowExec {
+			return "", fmt.Errorf("%w: '%s' requires AllowExec", ErrConfigSourceNotAllowed, source)
+		}
+		raw, err := requiredKey(source, src, KeyExec)
+		if err != nil {
+			return "", err
+		}
+		exec := &clientcmdv1.ExecConfig{}
+		if err := json.Unmarshal([]byte(raw), exec); err != nil {
+			return "", fmt.Errorf("invalid %s in secureJsonData: %w", KeyExec, err)
+		}
+		if exec.APIVersion == "" {
+			exec.APIVersion = defaultExecAPIVersion
+		}
+		if exec.InteractiveMode == "" {
+			exec.InteractiveMode = defaultExecInteractiveMode
+		}
+		user.Exec = exec
+	case ConfigSourceAuthProvider:
+		if !allowed.AllowExec {
+			return "", fmt.Errorf("%w: '%s' requires AllowExec", ErrConfigSourceNotAllowed, source)
+		}
+		raw, err := requiredKey(source, src, KeyAuthProvider)
+		if err != nil {
+			return "", err
+		}
+		provider := &clientcmdv1.AuthProviderConfig{}
+		if err := json.Unmarshal([]byte(raw), provider); err != nil {
+			return "", fmt.Errorf("invalid %s in secureJsonData: %w", KeyAuthProvider, err)
+		}
+		user.AuthProvider = provider
+	default:
+		return "", fmt.Errorf("%w: '%s'", ErrUnknownConfigSource, source)
+	}
+
+	server, err := requiredKey(source, src, KeyServer)
+	if err != nil {
+		return "", err
+	}
+	cluster := clientcmdv1.Cluster{
+		Server: server,
+	}
+	if ca, ok := src[KeyCAData]; ok {
+		cluster.CertificateAuthorityData, err = decodeCAData(ca)
+		if err != nil {
+			return "", err
+		}
+	} else if file, ok := src[KeyCAFile]; ok {
+		if err := allowed.checkPath(KeyCAFile, file); err != nil {
+			return "", err
+		}
+		cluster.CertificateAuthority = file
+	}
+
+	cfg, err := json.Marshal(clientcmdv1.Config{
+		Kind:       "Config",
+		APIVersion: "v1",
+		Clusters: []clientcmdv1.NamedCluster{{
+			Name:    sourceClusterName,
+			Cluster: cluster,
+		}},
+		AuthInfos: []clientcmdv1.NamedAuthInfo{{
+			Name:     sourceUserName,
+			AuthInfo: user,
+		}},
+		Contexts: []clientcmdv1.NamedContext{{
+			Name: sourceContextName,
+			Context: clientcmdv1.Context{
+				Cluster:  sourceClusterName,
+				AuthInfo: sourceUserName,
+			},
+		}},
+		CurrentContext: sourceContextName,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(cfg), nil
+}
+
+// checkConfig applies the restrictions of the LoaderConfig to every user and cluster of a loaded kubeconfig document,
+// so that they can't be bypassed by loading a whole kubeconfig document with ConfigSourceKubeconfig.
+// Users with an exec credential plugin or auth provider require AllowExec, and the tokenFile, client-certificate,
+// and client-key paths of users, and the certificate-authority paths of clusters, must be in one of the AllowedPathPrefixes.
+func (c LoaderConfig) checkConfig(cfg *clientcmdapi.Config) error {
+	for _, name := range sortedKeys(cfg.AuthInfos) {
+		user := cfg.AuthInfos[name]
+		if user == nil {
+			continue
+		}
+		if user.Exec != nil && !c.AllowExec {
+			return fmt.Errorf("%w: user '%s' has an exec credential plugin, which requires AllowExec", ErrConfigSourceNotAllowed, name)
+		}
+		if user.AuthProvider != nil && !c.AllowExec {
+			return fmt.Errorf("%w: user '%s' has an auth provider, which requires AllowExec", ErrConfigSourceNotAllowed, name)
+		}
+		for _, file := range []struct{ key, path string }{
+			{"tokenFile", user.TokenFile},
+			{"client-certificate", user.ClientCertificate},
+			{"client-key", user.ClientKey},
+		} {
+			if file.path == "" {
+				continue
+			}
+			if err := c.checkPath(fmt.Sprintf("user '%s' %s", name, file.key), file.path); err != nil {
+				return err
+			}
+		}
+	}
+	for _, name := range sortedKeys(cfg.Clusters) {
+		cluster := cfg.Clusters[name]
+		if cluster == nil || cluster.CertificateAuthority == "" {
+			continue
+		}
+		if err := c.checkPath(fmt.Sprintf("cluster '%s' certificate-authority", name), cluster.CertificateAuthority); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkPath returns an error wrapping ErrPathNotAllowed if path, the value of key,
+// is not an absolute path in one of the AllowedPathPrefixes
+func (c LoaderConfig) checkPath(key, path string) error {
+	if filepath.IsAbs(path) {
+		path = filepath.Clean(path)
+		for _, prefix := range c.AllowedPathPrefixes {
+			prefix = filepath.Clean(prefix)
+			if path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, string(filepath.Separator))+string(filepath.Separator)) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %s '%s' is not in an allowed path prefix", ErrPathNotAllowed, key, path)
+}
+
+// requiredKey returns the value of key in src, or an error wrapping ErrConfigMissing if src doesn't contain key
+func requiredKey(source string, src map[string]string, key string) (string, error) {
+	val, ok := src[key]
+	if !ok || val == "" {
+		return "", fmt.Errorf("%w: %s is required for config source '%s'", ErrConfigMissing, key, source)
+	}
+
+	return val, nil
+}
+
+// decodeCAData returns the PEM-encoded certificates from a CA bundle, which may also be base64-encoded
+func decodeCAData(ca string) ([]byte, error) {
+	if strings.Contains(ca, "-----BEGIN") {
+		return []byte(ca), nil
+	}
+
+	dec, err := base64.StdEncoding.DecodeString(strings.TrimSpace(ca))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s in secureJsonData: expected PEM or base64-encoded PEM data", KeyCAData)
+	}
+
+	return dec, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
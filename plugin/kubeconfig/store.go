@@ -0,0 +1,65 @@
+package kubeconfig
+
+import (
+	"context"
+	"errors"
+
+	"github.com/grafana/grafana-app-sdk/k8s"
+	"github.com/grafana/grafana-app-sdk/plugin/router"
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+var (
+	// ErrNamespaceMissing is an error that's returned when resolving a Store
+	// from a context which contains a Config without a namespace (e.g. because the Config failed to load).
+	ErrNamespaceMissing = errors.New("kubeconfig in context does not have a namespace")
+)
+
+// NewStoreResolver returns a new router.StoreResolver which resolves the namespace and Store for each request
+// from the Config in the request context, as stored by LoadingMiddleware or MustLoadMiddleware.
+// This allows a single router.ResourceGroupRouter to serve many app instances, each with their own kubeconfig and namespace.
+//
+// A Store for resourceGroup is created for each distinct Config, using a k8s.ClientRegistry with clientConfig,
+// and is re-used for all subsequent requests with the same Config, until it is evicted from the cache
+// (see NewStoreResolverWithInitializer).
+func NewStoreResolver(resourceGroup resource.SchemaGroup, clientConfig k8s.ClientConfig) router.StoreResolver {
+	return NewStoreResolverWithInitializer(func(cfg NamespacedConfig) (router.Store, error) {
+		return resource.NewStore(k8s.NewClientRegistry(cfg.RestConfig, clientConfig), resourceGroup), nil
+	})
+}
+
+// NewStoreResolverWithInitializer returns a new router.StoreResolver which resolves the namespace and Store for each request
+// from the Config in the request context, as stored by LoadingMiddleware or MustLoadMiddleware.
+// The Store is created by ini, which is called once for each distinct Config.
+// Stores are cached in an InitializerCache with DefaultCacheConfig, so that Stores for configs which are no longer used
+// (such as after credentials are rotated) are evicted. Use NewStoreResolverWithCache to configure the cache.
+func NewStoreResolverWithInitializer(ini Initializer[router.Store]) router.StoreResolver {
+	cfg := DefaultCacheConfig()
+	cfg.Name = "store_resolver"
+	return NewStoreResolverWithCache(NewInitializerCache(ini, InitializerCacheConfig[router.Store]{
+		CacheConfig: cfg,
+	}))
+}
+
+// NewStoreResolverWithCache returns a new router.StoreResolver which resolves the namespace and Store for each request
+// from the Config in the request context, as stored by LoadingMiddleware or MustLoadMiddleware.
+// The Store is initialized by cache, which controls how many Stores are kept, when they expire,
+// and what happens to them when they are evicted.
+func NewStoreResolverWithCache(cache *InitializerCache[router.Store]) router.StoreResolver {
+	return func(ctx context.Context) (string, router.Store, error) {
+		cfg, err := FromContext(ctx)
+		if err != nil {
+			return "", nil, err
+		}
+		if cfg.Namespace == "" {
+			return "", nil, ErrNamespaceMissing
+		}
+
+		store, err := cache.Initialize(cfg)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return cfg.Namespace, store, nil
+	}
+}
@@ -0,0 +1,115 @@
+package kubeconfig_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+
+	"github.com/grafana/grafana-app-sdk/k8s"
+	"github.com/grafana/grafana-app-sdk/plugin/kubeconfig"
+	"github.com/grafana/grafana-app-sdk/plugin/router"
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+func TestNewStoreResolver(t *testing.T) {
+	resolver := kubeconfig.NewStoreResolver(resource.NewSimpleSchemaGroup("test.resource", "v1"), k8s.DefaultClientConfig())
+
+	t.Run("missing config", func(t *testing.T) {
+		_, _, err := resolver(context.Background())
+		assert.Equal(t, kubeconfig.ErrContextValueMissing, err)
+	})
+
+	t.Run("config not loaded", func(t *testing.T) {
+		_, _, err := resolver(kubeconfig.WithContext(context.Background(), kubeconfig.NamespacedConfig{}))
+		assert.Equal(t, kubeconfig.ErrNamespaceMissing, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ns, store, err := resolver(kubeconfig.WithContext(context.Background(), kubeconfig.NamespacedConfig{
+			CRC32:     123,
+			Namespace: "stack-1",
+			RestConfig: rest.Config{
+				Host: "https://some.url:443",
+			},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "stack-1", ns)
+		assert.NotNil(t, store)
+	})
+}
+
+func TestNewStoreResolverWithInitializer(t *testing.T) {
+	initialized := make(map[string]int)
+	resolver := kubeconfig.NewStoreResolverWithInitializer(func(cfg kubeconfig.NamespacedConfig) (router.Store, error) {
+		initialized[cfg.Namespace]++
+		if cfg.Namespace == "broken" {
+			return nil, assert.AnError
+		}
+		return resource.NewStore(k8s.NewClientRegistry(cfg.RestConfig, k8s.DefaultClientConfig()),
+			resource.NewSimpleSchemaGroup("test.resource", "v1")), nil
+	})
+	cfg := func(crc uint32, namespace string) context.Context {
+		return kubeconfig.WithContext(context.Background(), kubeconfig.NamespacedConfig{
+			CRC32:     crc,
+			Namespace: namespace,
+			RestConfig: rest.Config{
+				Host: "https://some.url:443",
+			},
+		})
+	}
+
+	t.Run("initializer error", func(t *testing.T) {
+		_, _, err := resolver(cfg(1, "broken"))
+		assert.Equal(t, assert.AnError, err)
+	})
+
+	t.Run("stores are cached per config", func(t *testing.T) {
+		ns1, store1, err := resolver(cfg(2, "org-1"))
+		require.NoError(t, err)
+		ns2, store2, err := resolver(cfg(3, "org-2"))
+		require.NoError(t, err)
+		ns1Again, store1Again, err := resolver(cfg(2, "org-1"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "org-1", ns1)
+		assert.Equal(t, "org-2", ns2)
+		assert.Equal(t, "org-1", ns1Again)
+		assert.NotSame(t, store1, store2)
+		assert.Same(t, store1, store1Again)
+		assert.Equal(t, 1, initialized["org-1"])
+		assert.Equal(t, 1, initialized["org-2"])
+	})
+}
+
+func TestNewStoreResolverWithCache(t *testing.T) {
+	evicted := make([]string, 0)
+	cache := kubeconfig.NewInitializerCache(func(cfg kubeconfig.NamespacedConfig) (router.Store, error) {
+		return resource.NewStore(k8s.NewClientRegistry(cfg.RestConfig, k8s.DefaultClientConfig()),
+			resource.NewSimpleSchemaGroup("test.resource", "v1")), nil
+	}, kubeconfig.InitializerCacheConfig[router.Store]{
+		CacheConfig: kubeconfig.CacheConfig{
+			MaxSize: 1,
+		},
+		OnEvict: func(cfg kubeconfig.NamespacedConfig, _ router.Store, reason string) {
+			evicted = append(evicted, cfg.Namespace+":"+reason)
+		},
+	})
+	resolver := kubeconfig.NewStoreResolverWithCache(cache)
+	cfg := func(crc uint32, namespace string) context.Context {
+		return kubeconfig.WithContext(context.Background(), kubeconfig.NamespacedConfig{
+			CRC32:     crc,
+			Namespace: namespace,
+		})
+	}
+
+	// Rotating the credentials of org-1 changes its config, so the store for the old config is evicted
+	_, store1, err := resolver(cfg(1, "org-1"))
+	require.NoError(t, err)
+	_, rotated, err := resolver(cfg(2, "org-1"))
+	require.NoError(t, err)
+	assert.NotSame(t, store1, rotated)
+	assert.Equal(t, []string{"org-1:" + kubeconfig.EvictionReasonSize}, evicted)
+}
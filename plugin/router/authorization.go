@@ -0,0 +1,118 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana-app-sdk/plugin"
+)
+
+// Grafana organization roles, in increasing order of privilege.
+const (
+	RoleNone   = "None"
+	RoleViewer = "Viewer"
+	RoleEditor = "Editor"
+	RoleAdmin  = "Admin"
+)
+
+// roleRanks is used to compare roles, as each role has all the privileges of the roles ranked below it
+var roleRanks = map[string]int{
+	RoleNone:   0,
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// AuthorizationPolicy decides whether the user is allowed to make a request to the matched route.
+// It returns nil if the request is allowed, and an error if it is not.
+// The error should be a plugin.Error with a 403 Forbidden (or 401 Unauthorized) code,
+// as errors without a code are treated as a 500 Internal Server Error.
+// user is nil if the request has no user.
+type AuthorizationPolicy func(ctx context.Context, route RouteInfo, user *backend.User) error
+
+// RequireRole returns an AuthorizationPolicy which allows users with role, or a role with more privileges than role
+// (for example, RequireRole(RoleEditor) allows Editors and Admins).
+// Requests with no user are denied with a 401 Unauthorized, and users without the role with a 403 Forbidden.
+// If role is not one of the Grafana organization roles, only users with exactly that role are allowed.
+func RequireRole(role string) AuthorizationPolicy {
+	return func(_ context.Context, _ RouteInfo, user *backend.User) error {
+		if user == nil {
+			return plugin.NewError(http.StatusUnauthorized, "request has no user")
+		}
+		if hasRole(user.Role, role) {
+			return nil
+		}
+		return plugin.NewError(http.StatusForbidden,
+			fmt.Sprintf("user '%s' has role '%s', but role '%s' is required", user.Login, user.Role, role))
+	}
+}
+
+// ResourceGroupPolicy is the AuthorizationPolicy for the routes of a ResourceGroupRouter used by WithAuthorization
+// when no policy is provided. It requires the Viewer role for GET requests (reads, lists, and watches),
+// and the Editor role for all other requests (writes).
+func ResourceGroupPolicy(ctx context.Context, route RouteInfo, user *backend.User) error {
+	if route.Method == http.MethodGet || route.Method == http.MethodHead {
+		return RequireRole(RoleViewer)(ctx, route, user)
+	}
+	return RequireRole(RoleEditor)(ctx, route, user)
+}
+
+// NewAuthorizationMiddleware returns a MiddlewareFunc which authorizes every request before it is handled.
+// Requests to routes with their own policy (see RouteHandler.RequireRole and RouteHandler.Authorize)
+// are authorized with the route's policy, and requests to all other routes with defaultPolicy.
+// If defaultPolicy is nil, requests to routes without their own policy are allowed.
+//
+// Denied requests are not handled, and are responded to with a JSONErrorResponse with the code of the policy's error.
+func NewAuthorizationMiddleware(defaultPolicy AuthorizationPolicy) MiddlewareFunc {
+	return newAuthorizationMiddleware(defaultPolicy, nil)
+}
+
+// newAuthorizationMiddleware returns an authorization middleware which responds to denied requests
+// in the same way as a JSONRouter with errHandler
+func newAuthorizationMiddleware(defaultPolicy AuthorizationPolicy, errHandler JSONErrorHandler) MiddlewareFunc {
+	errRouter := &JSONRouter{
+		errHandler: errHandler,
+	}
+	return func(handler HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) {
+			policy := defaultPolicy
+			if route := matchedRouteHandler(ctx); route != nil && route.policy != nil {
+				policy = route.policy
+			}
+			if policy != nil {
+				if err := policy(ctx, MatchedRouteFromContext(ctx), req.PluginContext.User); err != nil {
+					errRouter.sendErr(ctx, sender, err)
+					return
+				}
+			}
+			handler(ctx, req, sender)
+		}
+	}
+}
+
+// RequireRole requires users to have role (or a role with more privileges) to make requests to the route,
+// when the router uses an authorization middleware (see NewAuthorizationMiddleware).
+// It replaces any existing policy for the route, including the default policy of the middleware.
+func (h *RouteHandler) RequireRole(role string) *RouteHandler {
+	return h.Authorize(RequireRole(role))
+}
+
+// Authorize sets the AuthorizationPolicy used to authorize requests to the route,
+// when the router uses an authorization middleware (see NewAuthorizationMiddleware).
+// It replaces any existing policy for the route, including the default policy of the middleware.
+func (h *RouteHandler) Authorize(policy AuthorizationPolicy) *RouteHandler {
+	h.policy = policy
+	return h
+}
+
+func hasRole(userRole, requiredRole string) bool {
+	required, ok := roleRanks[requiredRole]
+	if !ok {
+		return userRole == requiredRole
+	}
+	actual, ok := roleRanks[userRole]
+	return ok && actual >= required
+}
@@ -0,0 +1,208 @@
+package router_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana-app-sdk/plugin"
+	"github.com/grafana/grafana-app-sdk/plugin/router"
+)
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name         string
+		required     string
+		user         *backend.User
+		expectedCode int
+	}{
+		{
+			name:         "no user",
+			required:     router.RoleViewer,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "same role",
+			required: router.RoleEditor,
+			user:     &backend.User{Role: router.RoleEditor},
+		},
+		{
+			name:     "higher role",
+			required: router.RoleViewer,
+			user:     &backend.User{Role: router.RoleAdmin},
+		},
+		{
+			name:         "lower role",
+			required:     router.RoleEditor,
+			user:         &backend.User{Role: router.RoleViewer},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "no role",
+			required:     router.RoleViewer,
+			user:         &backend.User{Role: router.RoleNone},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "unknown user role",
+			required:     router.RoleViewer,
+			user:         &backend.User{Role: "Superuser"},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:     "custom role",
+			required: "Superuser",
+			user:     &backend.User{Role: "Superuser"},
+		},
+		{
+			name:         "custom role not granted by admin",
+			required:     "Superuser",
+			user:         &backend.User{Role: router.RoleAdmin},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := router.RequireRole(test.required)(context.Background(), router.RouteInfo{}, test.user)
+			if test.expectedCode == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, test.expectedCode, plugin.FromError(err).Code)
+		})
+	}
+}
+
+func TestNewAuthorizationMiddleware(t *testing.T) {
+	ok := func(ctx context.Context, req *backend.CallResourceRequest, res backend.CallResourceResponseSender) {
+		_ = res.Send(&backend.CallResourceResponse{
+			Status: http.StatusOK,
+		})
+	}
+	var policyRoute router.RouteInfo
+	r := router.NewRouter()
+	r.Use(router.NewAuthorizationMiddleware(router.RequireRole(router.RoleViewer)))
+	r.Handle("default", ok, http.MethodGet)
+	r.Handle("editor", ok, http.MethodGet).RequireRole(router.RoleEditor)
+	r.Subrouter("sub/").Handle("policy/{id}", ok, http.MethodPost).Name("policy").Authorize(
+		func(ctx context.Context, route router.RouteInfo, user *backend.User) error {
+			policyRoute = route
+			if user.Login != "owner" {
+				return plugin.NewError(http.StatusForbidden, "only the owner can do this")
+			}
+			return nil
+		})
+
+	tests := []struct {
+		name          string
+		path          string
+		method        string
+		user          *backend.User
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:         "default policy allowed",
+			path:         "default",
+			method:       http.MethodGet,
+			user:         &backend.User{Role: router.RoleViewer},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "default policy denied",
+			path:          "default",
+			method:        http.MethodGet,
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: "request has no user",
+		},
+		{
+			name:         "route role allowed",
+			path:         "editor",
+			method:       http.MethodGet,
+			user:         &backend.User{Role: router.RoleAdmin},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "route role denied",
+			path:          "editor",
+			method:        http.MethodGet,
+			user:          &backend.User{Login: "user", Role: router.RoleViewer},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "user 'user' has role 'Viewer', but role 'Editor' is required",
+		},
+		{
+			name:         "route policy allowed",
+			path:         "sub/policy/1",
+			method:       http.MethodPost,
+			user:         &backend.User{Login: "owner", Role: router.RoleNone},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "route policy denied",
+			path:          "sub/policy/1",
+			method:        http.MethodPost,
+			user:          &backend.User{Login: "other", Role: router.RoleAdmin},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "only the owner can do this",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := &router.CapturingSender{}
+			err := r.CallResource(context.Background(), &backend.CallResourceRequest{
+				PluginContext: backend.PluginContext{
+					User: test.user,
+				},
+				Path:   test.path,
+				Method: test.method,
+			}, res)
+			require.NoError(t, err)
+			require.NotNil(t, res.Response)
+			assert.Equal(t, test.expectedCode, res.Response.Status)
+			if test.expectedError != "" {
+				errResponse := router.JSONErrorResponse{}
+				require.NoError(t, json.Unmarshal(res.Response.Body, &errResponse))
+				assert.Equal(t, router.JSONErrorResponse{
+					Code:  test.expectedCode,
+					Error: test.expectedError,
+				}, errResponse)
+			}
+		})
+	}
+
+	t.Run("policy receives matched route", func(t *testing.T) {
+		err := r.CallResource(context.Background(), &backend.CallResourceRequest{
+			PluginContext: backend.PluginContext{
+				User: &backend.User{Login: "owner"},
+			},
+			Path:   "sub/policy/1",
+			Method: http.MethodPost,
+		}, &router.CapturingSender{})
+		require.NoError(t, err)
+		assert.Equal(t, router.RouteInfo{
+			Name:   "policy",
+			Path:   "sub/policy/{id}",
+			Method: http.MethodPost,
+		}, policyRoute)
+	})
+
+	t.Run("no default policy", func(t *testing.T) {
+		r := router.NewRouter()
+		r.Use(router.NewAuthorizationMiddleware(nil))
+		r.Handle("open", ok)
+		res := &router.CapturingSender{}
+		require.NoError(t, r.CallResource(context.Background(), &backend.CallResourceRequest{
+			Path:   "open",
+			Method: http.MethodGet,
+		}, res))
+		assert.Equal(t, http.StatusOK, res.Response.Status)
+	})
+}
@@ -2,8 +2,11 @@ package router
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"runtime/debug"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +18,7 @@ import (
 
 	"github.com/grafana/grafana-app-sdk/logging"
 	"github.com/grafana/grafana-app-sdk/metrics"
+	"github.com/grafana/grafana-app-sdk/plugin"
 )
 
 // MiddlewareFunc is a function that receives a HandlerFunc and returns another HandlerFunc.
@@ -168,3 +172,139 @@ func NewMetricsMiddleware(cfg metrics.Config, registerer prometheus.Registerer)
 		responseBytes.WithLabelValues(req.Method, routeInfo.Path).Observe(float64(len(resp.Body)))
 	})
 }
+
+// NewRecoveryMiddleware returns a MiddlewareFunc which recovers from panics in downstream handlers and middlewares,
+// so that a panic while handling a request doesn't crash the plugin.
+// The panic and its stack trace are logged as an error with logger, and the request is responded to with
+// a 500 Internal Server Error JSONErrorResponse (unless the handler had already sent a response).
+// It should be the first middleware used by the router, so that it recovers from panics in all other middlewares.
+func NewRecoveryMiddleware(logger logging.Logger) MiddlewareFunc {
+	errRouter := &JSONRouter{}
+	return func(handler HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) {
+			tracked := &trackingSender{
+				CallResourceResponseSender: sender,
+			}
+			defer func() {
+				if r := recover(); r != nil {
+					logger.WithContext(ctx).Error(fmt.Sprintf("panic handling request %s %s: %v", req.Method, req.Path, r),
+						"request.http.method", req.Method,
+						"request.http.path", req.Path,
+						"panic", fmt.Sprint(r),
+						"stack", string(debug.Stack()),
+					)
+					if !tracked.sent {
+						errRouter.sendErr(ctx, sender, plugin.WrapError(http.StatusInternalServerError, fmt.Errorf("panic: %v", r)))
+					}
+				}
+			}()
+			handler(ctx, req, tracked)
+		}
+	}
+}
+
+// NewTimeoutMiddleware returns a MiddlewareFunc which sets a deadline on the context of each request,
+// timeout after the request is received. Routes with their own timeout (see RouteHandler.Timeout) use that timeout instead.
+// If timeout is zero or negative, requests to routes without their own timeout have no deadline.
+//
+// Handlers are expected to stop work and return when the context is done. If a handler responds with a 5xx error
+// after the deadline has passed (or doesn't respond at all), the response is replaced with a 504 Gateway Timeout
+// JSONErrorResponse.
+func NewTimeoutMiddleware(timeout time.Duration) MiddlewareFunc {
+	errRouter := &JSONRouter{}
+	return func(handler HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) {
+			routeTimeout := timeout
+			if route := matchedRouteHandler(ctx); route != nil && route.timeout != nil {
+				routeTimeout = *route.timeout
+			}
+			if routeTimeout <= 0 {
+				handler(ctx, req, sender)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, routeTimeout)
+			defer cancel()
+			timeoutErr := plugin.NewError(http.StatusGatewayTimeout,
+				fmt.Sprintf("request timed out after %s", routeTimeout.String()))
+			tracked := &trackingSender{
+				CallResourceResponseSender: &timeoutSender{
+					ctx:    ctx,
+					sender: sender,
+					onTimeout: func() {
+						errRouter.sendErr(ctx, sender, timeoutErr)
+					},
+				},
+			}
+			handler(ctx, req, tracked)
+			if !tracked.sent && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				errRouter.sendErr(ctx, sender, timeoutErr)
+			}
+		}
+	}
+}
+
+// NewMaxBodySizeMiddleware returns a MiddlewareFunc which rejects requests with a body larger than maxBytes
+// with a 413 Request Entity Too Large JSONErrorResponse, without calling the handler.
+// Routes with their own maximum body size (see RouteHandler.MaxBodySize) use that maximum instead.
+// If maxBytes is zero or negative, the body size of requests to routes without their own maximum is not limited.
+func NewMaxBodySizeMiddleware(maxBytes int64) MiddlewareFunc {
+	errRouter := &JSONRouter{}
+	return func(handler HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) {
+			limit := maxBytes
+			if route := matchedRouteHandler(ctx); route != nil && route.maxBodySize != nil {
+				limit = *route.maxBodySize
+			}
+			if limit > 0 && int64(len(req.Body)) > limit {
+				errRouter.sendErr(ctx, sender, plugin.NewError(http.StatusRequestEntityTooLarge,
+					fmt.Sprintf("request body of %d bytes exceeds the maximum of %d bytes", len(req.Body), limit)))
+				return
+			}
+			handler(ctx, req, sender)
+		}
+	}
+}
+
+// Timeout sets the timeout for requests to the route, used instead of the default timeout of the router's
+// timeout middleware (see NewTimeoutMiddleware). A zero or negative timeout disables the timeout for the route,
+// which should be used for long-lived routes such as streams.
+func (h *RouteHandler) Timeout(timeout time.Duration) *RouteHandler {
+	h.timeout = &timeout
+	return h
+}
+
+// MaxBodySize sets the maximum body size (in bytes) for requests to the route, used instead of the default maximum
+// of the router's body size middleware (see NewMaxBodySizeMiddleware).
+// A zero or negative maximum disables the limit for the route.
+func (h *RouteHandler) MaxBodySize(maxBytes int64) *RouteHandler {
+	h.maxBodySize = &maxBytes
+	return h
+}
+
+// trackingSender is a backend.CallResourceResponseSender which tracks whether a response has been sent
+type trackingSender struct {
+	backend.CallResourceResponseSender
+	sent bool
+}
+
+func (t *trackingSender) Send(res *backend.CallResourceResponse) error {
+	t.sent = true
+	return t.CallResourceResponseSender.Send(res)
+}
+
+// timeoutSender is a backend.CallResourceResponseSender which calls onTimeout instead of sending
+// a 5xx response if the deadline of ctx has passed
+type timeoutSender struct {
+	ctx       context.Context
+	sender    backend.CallResourceResponseSender
+	onTimeout func()
+}
+
+func (t *timeoutSender) Send(res *backend.CallResourceResponse) error {
+	if res.Status >= http.StatusInternalServerError && errors.Is(t.ctx.Err(), context.DeadlineExceeded) {
+		t.onTimeout()
+		return nil
+	}
+	return t.sender.Send(res)
+}
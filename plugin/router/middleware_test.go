@@ -1,13 +1,18 @@
 package router_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/grafana/grafana-app-sdk/logging"
 	"github.com/grafana/grafana-app-sdk/plugin/router"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -77,3 +82,143 @@ func TestMiddleware_CapturingMiddleware(t *testing.T) {
 	require.NoError(t, err, "no error expected from calling router")
 	require.Equal(t, "12hi!21", string(res.Response.Body))
 }
+
+func TestNewRecoveryMiddleware(t *testing.T) {
+	logs := &bytes.Buffer{}
+	r := router.NewRouter()
+	r.Use(router.NewRecoveryMiddleware(logging.NewSLogLogger(slog.NewTextHandler(logs, nil))))
+	r.Handle("panic", func(context.Context, *backend.CallResourceRequest, backend.CallResourceResponseSender) {
+		panic("I AM PANIC")
+	})
+	r.Handle("panic-after-send", func(_ context.Context, _ *backend.CallResourceRequest,
+		res backend.CallResourceResponseSender) {
+		_ = res.Send(&backend.CallResourceResponse{Status: http.StatusOK})
+		panic("JE SUIS PANIC")
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		res := &router.CapturingSender{}
+		require.NotPanics(t, func() {
+			require.NoError(t, r.CallResource(context.Background(), &backend.CallResourceRequest{
+				Path:   "panic",
+				Method: http.MethodGet,
+			}, res))
+		})
+		require.NotNil(t, res.Response)
+		assert.Equal(t, http.StatusInternalServerError, res.Response.Status)
+		assert.JSONEq(t, `{"code":500,"error":"internal server error"}`, string(res.Response.Body))
+		assert.Contains(t, logs.String(), "I AM PANIC")
+		assert.Contains(t, logs.String(), "stack=")
+	})
+
+	t.Run("panic after response", func(t *testing.T) {
+		sent := 0
+		require.NotPanics(t, func() {
+			require.NoError(t, r.CallResource(context.Background(), &backend.CallResourceRequest{
+				Path:   "panic-after-send",
+				Method: http.MethodGet,
+			}, fakeSender{
+				sendFunc: func(res *backend.CallResourceResponse) error {
+					sent++
+					assert.Equal(t, http.StatusOK, res.Status)
+					return nil
+				},
+			}))
+		})
+		assert.Equal(t, 1, sent)
+		assert.Contains(t, logs.String(), "JE SUIS PANIC")
+	})
+}
+
+func TestNewTimeoutMiddleware(t *testing.T) {
+	r := router.NewRouter()
+	r.Use(router.NewTimeoutMiddleware(10 * time.Millisecond))
+	waitForDeadline := func(ctx context.Context, _ *backend.CallResourceRequest, res backend.CallResourceResponseSender) {
+		if _, ok := ctx.Deadline(); !ok {
+			_ = res.Send(&backend.CallResourceResponse{Status: http.StatusOK})
+			return
+		}
+		<-ctx.Done()
+		_ = res.Send(&backend.CallResourceResponse{Status: http.StatusInternalServerError})
+	}
+	r.Handle("default", waitForDeadline)
+	r.Handle("no-response", func(ctx context.Context, _ *backend.CallResourceRequest, _ backend.CallResourceResponseSender) {
+		<-ctx.Done()
+	})
+	r.Handle("fast", func(ctx context.Context, _ *backend.CallResourceRequest, res backend.CallResourceResponseSender) {
+		_ = res.Send(&backend.CallResourceResponse{Status: http.StatusInternalServerError})
+	})
+	r.Handle("long", waitForDeadline).Timeout(20 * time.Millisecond)
+	r.Handle("stream", waitForDeadline).Timeout(0)
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedCode int
+	}{
+		{"default timeout", "default", http.StatusGatewayTimeout},
+		{"no response", "no-response", http.StatusGatewayTimeout},
+		{"error before deadline", "fast", http.StatusInternalServerError},
+		{"route timeout", "long", http.StatusGatewayTimeout},
+		{"route without timeout", "stream", http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := &router.CapturingSender{}
+			require.NoError(t, r.CallResource(context.Background(), &backend.CallResourceRequest{
+				Path:   test.path,
+				Method: http.MethodGet,
+			}, res))
+			require.NotNil(t, res.Response)
+			assert.Equal(t, test.expectedCode, res.Response.Status)
+		})
+	}
+
+	t.Run("timeout message", func(t *testing.T) {
+		res := &router.CapturingSender{}
+		require.NoError(t, r.CallResource(context.Background(), &backend.CallResourceRequest{
+			Path:   "long",
+			Method: http.MethodGet,
+		}, res))
+		assert.JSONEq(t, `{"code":504,"error":"request timed out after 20ms"}`, string(res.Response.Body))
+	})
+}
+
+func TestNewMaxBodySizeMiddleware(t *testing.T) {
+	r := router.NewRouter()
+	r.Use(router.NewMaxBodySizeMiddleware(4))
+	ok := func(_ context.Context, _ *backend.CallResourceRequest, res backend.CallResourceResponseSender) {
+		_ = res.Send(&backend.CallResourceResponse{Status: http.StatusOK})
+	}
+	r.Handle("default", ok, http.MethodPost)
+	r.Handle("large", ok, http.MethodPost).MaxBodySize(8)
+	r.Handle("unlimited", ok, http.MethodPost).MaxBodySize(0)
+
+	tests := []struct {
+		name         string
+		path         string
+		body         string
+		expectedCode int
+	}{
+		{"default within limit", "default", "1234", http.StatusOK},
+		{"default over limit", "default", "12345", http.StatusRequestEntityTooLarge},
+		{"route within limit", "large", "12345678", http.StatusOK},
+		{"route over limit", "large", "123456789", http.StatusRequestEntityTooLarge},
+		{"route without limit", "unlimited", "123456789", http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := &router.CapturingSender{}
+			require.NoError(t, r.CallResource(context.Background(), &backend.CallResourceRequest{
+				Path:   test.path,
+				Method: http.MethodPost,
+				Body:   []byte(test.body),
+			}, res))
+			require.NotNil(t, res.Response)
+			assert.Equal(t, test.expectedCode, res.Response.Status)
+			if test.expectedCode == http.StatusRequestEntityTooLarge {
+				assert.Contains(t, string(res.Response.Body), "exceeds the maximum")
+			}
+		})
+	}
+}
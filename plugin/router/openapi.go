@@ -0,0 +1,241 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+const (
+	// OpenAPIPath is the well-known path at which HandleOpenAPI serves the OpenAPI document for a Router.
+	OpenAPIPath = "openapi/v3"
+	// OpenAPIVersion is the OpenAPI specification version of documents created by a Router.
+	OpenAPIVersion = "3.0.3"
+)
+
+// OpenAPIDocument is an OpenAPI 3 document describing the routes of a Router.
+// It contains only the subset of the OpenAPI specification which is used to describe Router routes.
+type OpenAPIDocument struct {
+	OpenAPI    string                     `json:"openapi" yaml:"openapi"`
+	Info       OpenAPIInfo                `json:"info" yaml:"info"`
+	Paths      map[string]OpenAPIPathItem `json:"paths" yaml:"paths"`
+	Components *OpenAPIComponents         `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+// OpenAPIComponents contains the reusable schemas of an OpenAPIDocument, keyed by name.
+type OpenAPIComponents struct {
+	Schemas map[string]map[string]any `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+}
+
+// OpenAPIInfo is the metadata of an OpenAPIDocument.
+type OpenAPIInfo struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string `json:"version" yaml:"version"`
+}
+
+// OpenAPIPathItem is the set of operations for a path, keyed by lower-case HTTP method.
+type OpenAPIPathItem map[string]*OpenAPIOperation
+
+// OpenAPIOperation describes a single method of a route.
+type OpenAPIOperation struct {
+	Summary     string                     `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
+	OperationID string                     `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Tags        []string                   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses" yaml:"responses"`
+}
+
+// OpenAPIParameter is a path, query, or header parameter of an OpenAPIOperation.
+type OpenAPIParameter struct {
+	Name        string         `json:"name" yaml:"name"`
+	In          string         `json:"in" yaml:"in"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool           `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      map[string]any `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// OpenAPIRequestBody is the request body of an OpenAPIOperation.
+type OpenAPIRequestBody struct {
+	Description string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool                        `json:"required,omitempty" yaml:"required,omitempty"`
+	Content     map[string]OpenAPIMediaType `json:"content" yaml:"content"`
+}
+
+// OpenAPIResponse is a response of an OpenAPIOperation.
+type OpenAPIResponse struct {
+	Description string                      `json:"description" yaml:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// OpenAPIMediaType contains the schema of a request or response body for a content type.
+type OpenAPIMediaType struct {
+	Schema map[string]any `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// JSONContent returns the content map for a JSON body with the provided schema.
+func JSONContent(schema map[string]any) map[string]OpenAPIMediaType {
+	return map[string]OpenAPIMediaType{
+		ContentTypeJSON: {
+			Schema: schema,
+		},
+	}
+}
+
+// AddOpenAPISchema adds a named schema to the components of the Router's OpenAPI document,
+// and returns a schema which references it, for use in the OpenAPIOperations of routes.
+// Adding a schema with the same name as an existing one replaces it.
+func (r *Router) AddOpenAPISchema(name string, schema map[string]any) map[string]any {
+	if r.openAPISchemas == nil {
+		r.openAPISchemas = make(map[string]map[string]any)
+	}
+	r.openAPISchemas[name] = schema
+	return map[string]any{
+		"$ref": "#/components/schemas/" + name,
+	}
+}
+
+// OpenAPI sets the OpenAPIOperation used to describe the route in OpenAPI documents.
+// The path parameters of the route are added to the operation's parameters automatically,
+// and a default response is used if the operation has no responses.
+func (h *RouteHandler) OpenAPI(operation OpenAPIOperation) *RouteHandler {
+	h.operation = &operation
+	return h
+}
+
+// OpenAPI returns an OpenAPIDocument describing all routes registered with the Router and its subrouters.
+// Each path variable in a route (such as `{name}` or `{name:[a-z]+}`) is described as a path parameter,
+// with a pattern if the variable has a match expression.
+func (r *Router) OpenAPI(info OpenAPIInfo) OpenAPIDocument {
+	doc := OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info:    info,
+		Paths:   make(map[string]OpenAPIPathItem),
+	}
+	r.addOpenAPIPaths(&doc, "")
+	return doc
+}
+
+func (r *Router) addOpenAPIPaths(doc *OpenAPIDocument, prefix string) {
+	for name, schema := range r.openAPISchemas {
+		if doc.Components == nil {
+			doc.Components = &OpenAPIComponents{
+				Schemas: make(map[string]map[string]any),
+			}
+		}
+		doc.Components.Schemas[name] = schema
+	}
+	for _, route := range r.routes {
+		path, params := openAPIPath(prefix + route.path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(OpenAPIPathItem)
+			doc.Paths[path] = item
+		}
+		for _, method := range route.sortedMethods() {
+			if _, ok := item[strings.ToLower(method)]; ok {
+				// As with request matching, the first route registered for a path and method takes precedence
+				continue
+			}
+			item[strings.ToLower(method)] = route.openAPIOperation(params)
+		}
+	}
+	for _, sr := range r.subrouters {
+		sr.addOpenAPIPaths(doc, prefix+sr.pattern)
+	}
+}
+
+// HandleOpenAPI registers a route at OpenAPIPath which serves the OpenAPI document of the Router as JSON.
+// The document is created when it is requested, so it includes routes registered after HandleOpenAPI is called.
+func (r *Router) HandleOpenAPI(info OpenAPIInfo) *RouteHandler {
+	return r.Handle(OpenAPIPath, func(ctx context.Context, _ *backend.CallResourceRequest, sender backend.CallResourceResponseSender) {
+		body, err := json.Marshal(r.OpenAPI(info))
+		if err != nil {
+			_ = sender.Send(&backend.CallResourceResponse{
+				Status: http.StatusInternalServerError,
+				Body:   []byte(`{"code":500,"error":"internal server error"}`),
+			})
+			return
+		}
+		_ = sender.Send(&backend.CallResourceResponse{
+			Status: http.StatusOK,
+			Headers: map[string][]string{
+				"Content-Type": {ContentTypeJSON},
+			},
+			Body: body,
+		})
+	}, http.MethodGet).OpenAPI(OpenAPIOperation{
+		Summary: "Get the OpenAPI document for all routes",
+		Responses: map[string]OpenAPIResponse{
+			strconv.Itoa(http.StatusOK): {
+				Description: "OpenAPI document",
+				Content:     JSONContent(map[string]any{"type": "object"}),
+			},
+		},
+	})
+}
+
+// openAPIOperation returns the OpenAPIOperation for the route, with params added as path parameters
+func (h *RouteHandler) openAPIOperation(params []OpenAPIParameter) *OpenAPIOperation {
+	op := OpenAPIOperation{}
+	if h.operation != nil {
+		op = *h.operation
+	}
+	if op.OperationID == "" {
+		op.OperationID = h.name
+	}
+	parameters := make([]OpenAPIParameter, 0, len(params)+len(op.Parameters))
+	parameters = append(parameters, params...)
+	op.Parameters = append(parameters, op.Parameters...)
+	if len(op.Responses) == 0 {
+		op.Responses = map[string]OpenAPIResponse{
+			"default": {
+				Description: "Response",
+			},
+		}
+	}
+	return &op
+}
+
+func (h *RouteHandler) sortedMethods() []string {
+	methods := make([]string, 0, len(h.methods))
+	for method := range h.methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// openAPIPath converts a router path into an OpenAPI path, and returns the path parameters of the path.
+func openAPIPath(path string) (string, []OpenAPIParameter) {
+	params := make([]OpenAPIParameter, 0)
+	for _, match := range replArgRegex.FindAllStringSubmatch(path, -1) {
+		if len(match) != 3 {
+			continue
+		}
+		schema := map[string]any{
+			"type": "string",
+		}
+		if match[2] != "" {
+			schema["pattern"] = "^" + match[2] + "$"
+		}
+		params = append(params, OpenAPIParameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   schema,
+		})
+		path = strings.Replace(path, match[0], "{"+match[1]+"}", 1)
+	}
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	return "/" + strings.TrimPrefix(path, "/"), params
+}
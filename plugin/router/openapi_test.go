@@ -0,0 +1,203 @@
+package router_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/grafana-app-sdk/plugin/router"
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+func TestRouter_OpenAPI(t *testing.T) {
+	noop := func(context.Context, router.JSONRequest) (router.JSONResponse, error) {
+		return nil, nil
+	}
+	r := router.NewJSONRouter()
+	r.Handle("foo", noop, http.MethodGet, http.MethodPost).Name("foo")
+	sub := r.Subroute("bar/{id:[0-9]+}/")
+	sub.Handle("baz/{name}", noop, http.MethodDelete).OpenAPI(router.OpenAPIOperation{
+		Summary: "Delete a baz",
+		Parameters: []router.OpenAPIParameter{{
+			Name: "force",
+			In:   "query",
+		}},
+		Responses: map[string]router.OpenAPIResponse{
+			"204": {Description: "Deleted"},
+		},
+	})
+
+	doc := r.OpenAPI(router.OpenAPIInfo{
+		Title:   "test",
+		Version: "1.0.0",
+	})
+	assert.Equal(t, router.OpenAPIVersion, doc.OpenAPI)
+	assert.Equal(t, router.OpenAPIInfo{Title: "test", Version: "1.0.0"}, doc.Info)
+	assert.Nil(t, doc.Components)
+	assert.Equal(t, map[string]router.OpenAPIPathItem{
+		"/foo": {
+			"get": &router.OpenAPIOperation{
+				OperationID: "foo",
+				Parameters:  []router.OpenAPIParameter{},
+				Responses:   map[string]router.OpenAPIResponse{"default": {Description: "Response"}},
+			},
+			"post": &router.OpenAPIOperation{
+				OperationID: "foo",
+				Parameters:  []router.OpenAPIParameter{},
+				Responses:   map[string]router.OpenAPIResponse{"default": {Description: "Response"}},
+			},
+		},
+		"/bar/{id}/baz/{name}": {
+			"delete": &router.OpenAPIOperation{
+				Summary: "Delete a baz",
+				Parameters: []router.OpenAPIParameter{{
+					Name:     "id",
+					In:       "path",
+					Required: true,
+					Schema:   map[string]any{"type": "string", "pattern": "^[0-9]+$"},
+				}, {
+					Name:     "name",
+					In:       "path",
+					Required: true,
+					Schema:   map[string]any{"type": "string"},
+				}, {
+					Name: "force",
+					In:   "query",
+				}},
+				Responses: map[string]router.OpenAPIResponse{
+					"204": {Description: "Deleted"},
+				},
+			},
+		},
+	}, doc.Paths)
+}
+
+func TestRouter_HandleOpenAPI(t *testing.T) {
+	r := router.NewRouter()
+	r.HandleOpenAPI(router.OpenAPIInfo{Title: "test", Version: "1.0.0"})
+	// Routes added after HandleOpenAPI are still part of the document
+	r.Handle("foo", func(context.Context, *backend.CallResourceRequest, backend.CallResourceResponseSender) {})
+
+	var response *backend.CallResourceResponse
+	err := r.CallResource(context.Background(), &backend.CallResourceRequest{
+		Path:   router.OpenAPIPath,
+		Method: http.MethodGet,
+	}, fakeSender{
+		sendFunc: func(res *backend.CallResourceResponse) error {
+			response = res
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	assert.Equal(t, http.StatusOK, response.Status)
+	assert.Equal(t, []string{router.ContentTypeJSON}, response.Headers["Content-Type"])
+	doc := router.OpenAPIDocument{}
+	require.NoError(t, json.Unmarshal(response.Body, &doc))
+	assert.Equal(t, "test", doc.Info.Title)
+	assert.Contains(t, doc.Paths, "/"+router.OpenAPIPath)
+	assert.Contains(t, doc.Paths, "/foo")
+}
+
+func TestResourceGroupRouter_OpenAPI(t *testing.T) {
+	objectSchema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"spec": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"some_info": map[string]any{"type": "string"},
+				},
+			},
+			"status": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"status": map[string]any{"type": "string"},
+				},
+			},
+		},
+	}
+	group := resource.NewSimpleSchemaGroup("test.resource", "v1")
+	group.AddSchema(&Test{}, resource.WithKind("Test"), resource.WithOpenAPISchema(objectSchema))
+	rgr, err := router.NewResourceGroupRouterWithStore(group, metav1.NamespaceDefault, fakeStore{})
+	require.NoError(t, err)
+
+	doc := rgr.OpenAPI(router.OpenAPIInfo{Title: "test", Version: "1.0.0"})
+	require.NotNil(t, doc.Components)
+	assert.Equal(t, objectSchema, doc.Components.Schemas["test.resource.v1.Test"])
+	assert.Contains(t, doc.Components.Schemas, "ResourceErrorResponse")
+	ref := map[string]any{"$ref": "#/components/schemas/test.resource.v1.Test"}
+
+	require.Contains(t, doc.Paths, "/test.resource/v1/tests")
+	assert.ElementsMatch(t, []string{"get", "post"}, keys(doc.Paths["/test.resource/v1/tests"]))
+	assert.Equal(t, ref, doc.Paths["/test.resource/v1/tests"]["post"].RequestBody.Content[router.ContentTypeJSON].Schema)
+	assert.Len(t, doc.Paths["/test.resource/v1/tests"]["get"].Parameters, 3)
+
+	require.Contains(t, doc.Paths, "/test.resource/v1/watch/tests")
+	assert.ElementsMatch(t, []string{"get"}, keys(doc.Paths["/test.resource/v1/watch/tests"]))
+	assert.Contains(t, doc.Paths["/test.resource/v1/watch/tests"]["get"].Responses["200"].Content, router.ContentTypeEventStream)
+
+	require.Contains(t, doc.Paths, "/test.resource/v1/tests/{name}")
+	assert.ElementsMatch(t, []string{"get", "put", "patch", "delete"}, keys(doc.Paths["/test.resource/v1/tests/{name}"]))
+	get := doc.Paths["/test.resource/v1/tests/{name}"]["get"]
+	assert.Equal(t, "name", get.Parameters[0].Name)
+	assert.Equal(t, ref, get.Responses["200"].Content[router.ContentTypeJSON].Schema)
+	assert.Contains(t, doc.Paths["/test.resource/v1/tests/{name}"]["patch"].RequestBody.Content, "application/json-patch+json")
+
+	require.Contains(t, doc.Paths, "/test.resource/v1/tests/{name}/status")
+	assert.ElementsMatch(t, []string{"get", "put", "patch"}, keys(doc.Paths["/test.resource/v1/tests/{name}/status"]))
+	assert.Equal(t, objectSchema["properties"].(map[string]any)["status"],
+		doc.Paths["/test.resource/v1/tests/{name}/status"]["get"].Responses["200"].Content[router.ContentTypeJSON].Schema)
+}
+
+func TestResourceGroupRouter_HandleOpenAPI(t *testing.T) {
+	group := resource.NewSimpleSchemaGroup("test.resource", "v1")
+	group.AddSchema(&Test{}, resource.WithKind("Test"))
+	getDocument := func(t *testing.T, rgr *router.ResourceGroupRouter) router.OpenAPIDocument {
+		var response *backend.CallResourceResponse
+		err := rgr.CallResource(context.Background(), &backend.CallResourceRequest{
+			Path:   router.OpenAPIPath,
+			Method: http.MethodGet,
+		}, fakeSender{
+			sendFunc: func(res *backend.CallResourceResponse) error {
+				response = res
+				return nil
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, response)
+		require.Equal(t, http.StatusOK, response.Status)
+		doc := router.OpenAPIDocument{}
+		require.NoError(t, json.Unmarshal(response.Body, &doc))
+		return doc
+	}
+
+	t.Run("default info", func(t *testing.T) {
+		rgr, err := router.NewResourceGroupRouterWithStore(group, metav1.NamespaceDefault, fakeStore{})
+		require.NoError(t, err)
+		doc := getDocument(t, rgr)
+		assert.Equal(t, router.OpenAPIInfo{Title: "test.resource API", Version: "1.0.0"}, doc.Info)
+		assert.Contains(t, doc.Paths, "/test.resource/v1/tests/{name}")
+	})
+
+	t.Run("WithOpenAPIInfo", func(t *testing.T) {
+		info := router.OpenAPIInfo{Title: "Test API", Description: "Tests", Version: "2.0.0"}
+		rgr, err := router.NewResourceGroupRouterWithStore(group, metav1.NamespaceDefault, fakeStore{}, router.WithOpenAPIInfo(info))
+		require.NoError(t, err)
+		assert.Equal(t, info, getDocument(t, rgr).Info)
+	})
+}
+
+func keys[T any](m map[string]T) []string {
+	k := make([]string, 0, len(m))
+	for key := range m {
+		k = append(k, key)
+	}
+	return k
+}
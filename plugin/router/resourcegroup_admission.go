@@ -0,0 +1,181 @@
+package router
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// NewAdmissionUserInfoMiddleware returns a middleware which sets the resource.AdmissionUserInfo of the request context
+// (see resource.ContextWithAdmissionUserInfo) from the Grafana user in the plugin context,
+// so in-process admission controllers (such as those added to a resource.Store) have the user making the request.
+// The Username is the user's login, and the user's name, email, role, and org ID are set in Extra.
+// ResourceGroupRouter uses this middleware for all of its routes.
+func NewAdmissionUserInfoMiddleware() MiddlewareFunc {
+	return func(handler HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) {
+			handler(resource.ContextWithAdmissionUserInfo(ctx, admissionUserInfo(req.PluginContext)), req, sender)
+		}
+	}
+}
+
+func admissionUserInfo(pCtx backend.PluginContext) resource.AdmissionUserInfo {
+	info := resource.AdmissionUserInfo{
+		Extra: map[string]any{
+			"orgID": strconv.FormatInt(pCtx.OrgID, 10),
+		},
+	}
+	if pCtx.User != nil {
+		info.Username = pCtx.User.Login
+		info.Extra["name"] = pCtx.User.Name
+		info.Extra["email"] = pCtx.User.Email
+		info.Extra["role"] = pCtx.User.Role
+	}
+	return info
+}
+
+// AddValidatingAdmissionController adds a resource.ValidatingAdmissionController which validates
+// creates, updates, patches, and deletes of resources of schema's kind made through the router, before they are written
+// to the Store. It replaces any existing ValidatingAdmissionController for the kind.
+// The AdmissionRequest has the user info of the Grafana user making the request (see NewAdmissionUserInfoMiddleware).
+// If the Store also runs admission controllers (such as a resource.Store with admission controllers), both are run.
+func (router *ResourceGroupRouter) AddValidatingAdmissionController(
+	controller resource.ValidatingAdmissionController, schema resource.Schema,
+) {
+	router.admission.AddValidatingAdmissionController(controller, schema)
+}
+
+// AddMutatingAdmissionController adds a resource.MutatingAdmissionController which mutates
+// creates, updates, and patches of resources of schema's kind made through the router, before they are written
+// to the Store. It replaces any existing MutatingAdmissionController for the kind.
+// As with AddValidatingAdmissionController, it runs in addition to any admission controllers run by the Store.
+func (router *ResourceGroupRouter) AddMutatingAdmissionController(
+	controller resource.MutatingAdmissionController, schema resource.Schema,
+) {
+	router.admission.AddMutatingAdmissionController(controller, schema)
+}
+
+// admittingStore is a Store which admits writes with a resource.InProcessAdmission before passing them to the Store.
+// Writes other than creates first get the existing resource from the Store, if there are controllers for the kind.
+type admittingStore struct {
+	Store
+	admission *resource.InProcessAdmission
+	group     resource.SchemaGroup
+}
+
+func (s *admittingStore) Add(ctx context.Context, obj resource.Object) (resource.Object, error) {
+	if s.admission.Handles(obj.StaticMetadata().Group, obj.StaticMetadata().Kind) {
+		var err error
+		if obj, err = s.admission.AdmitCreate(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+	return s.Store.Add(ctx, obj)
+}
+
+func (s *admittingStore) Update(ctx context.Context, obj resource.Object) (resource.Object, error) {
+	if s.admission.Handles(obj.StaticMetadata().Group, obj.StaticMetadata().Kind) {
+		existing, err := s.existing(ctx, obj.StaticMetadata().Kind, obj.StaticMetadata().Identifier())
+		if err != nil {
+			return nil, err
+		}
+		if obj, err = s.admission.AdmitUpdate(ctx, existing, obj); err != nil {
+			return nil, err
+		}
+	}
+	return s.Store.Update(ctx, obj)
+}
+
+func (s *admittingStore) UpdateSubresource(ctx context.Context, kind string, identifier resource.Identifier,
+	subresourceName resource.SubresourceName, obj any) (resource.Object, error) {
+	if s.handles(kind) {
+		existing, err := s.existing(ctx, kind, identifier)
+		if err != nil {
+			return nil, err
+		}
+		if obj, err = s.admission.AdmitSubresourceUpdate(ctx, existing, subresourceName, obj); err != nil {
+			return nil, err
+		}
+	}
+	return s.Store.UpdateSubresource(ctx, kind, identifier, subresourceName, obj)
+}
+
+func (s *admittingStore) Patch(ctx context.Context, kind string, identifier resource.Identifier,
+	patch resource.PatchRequest) (resource.Object, error) {
+	if s.handles(kind) {
+		existing, err := s.existing(ctx, kind, identifier)
+		if err != nil {
+			return nil, err
+		}
+		if patch, err = s.admission.AdmitPatch(ctx, existing, patch, resource.PatchOptions{}); err != nil {
+			return nil, err
+		}
+	}
+	return s.Store.Patch(ctx, kind, identifier, patch)
+}
+
+func (s *admittingStore) PatchSubresource(ctx context.Context, kind string, identifier resource.Identifier,
+	subresourceName resource.SubresourceName, patch resource.PatchRequest) (resource.Object, error) {
+	if s.handles(kind) {
+		existing, err := s.existing(ctx, kind, identifier)
+		if err != nil {
+			return nil, err
+		}
+		patch, err = s.admission.AdmitPatch(ctx, existing, patch, resource.PatchOptions{
+			Subresource: string(subresourceName),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.Store.PatchSubresource(ctx, kind, identifier, subresourceName, patch)
+}
+
+func (s *admittingStore) Delete(ctx context.Context, kind string, identifier resource.Identifier) error {
+	if s.handles(kind) {
+		existing, err := s.existing(ctx, kind, identifier)
+		if err != nil {
+			return err
+		}
+		if err = s.admission.AdmitDelete(ctx, existing); err != nil {
+			return err
+		}
+	}
+	return s.Store.Delete(ctx, kind, identifier)
+}
+
+// handles returns true if there are admission controllers for the kind in the router's resource group
+func (s *admittingStore) handles(kind string) bool {
+	sch := s.schema(kind)
+	return sch != nil && s.admission.Handles(sch.Group(), sch.Kind())
+}
+
+// existing gets the existing resource from the Store, ensuring its StaticMetadata has the group, version, and kind
+// of the schema, so it is admitted by the controllers for the kind
+func (s *admittingStore) existing(ctx context.Context, kind string, identifier resource.Identifier) (
+	resource.Object, error) {
+	obj, err := s.Store.Get(ctx, kind, identifier)
+	if err != nil {
+		return nil, err
+	}
+	md := obj.StaticMetadata()
+	if sch := s.schema(kind); sch != nil && (md.Group == "" || md.Kind == "") {
+		md.Group = sch.Group()
+		md.Version = sch.Version()
+		md.Kind = sch.Kind()
+		obj.SetStaticMetadata(md)
+	}
+	return obj, nil
+}
+
+func (s *admittingStore) schema(kind string) resource.Schema {
+	for _, sch := range s.group.Schemas() {
+		if sch.Kind() == kind {
+			return sch
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// OpenAPISchemaProvider is an optional interface for a resource.Schema which provides the OpenAPI schema of its objects.
+// ResourceGroupRouter uses the schema for request and response bodies when describing its routes in OpenAPI documents.
+// resource.SimpleSchema implements OpenAPISchemaProvider, returning the schema set by resource.WithOpenAPISchema.
+type OpenAPISchemaProvider interface {
+	OpenAPISchema() map[string]any
+}
+
+var (
+	resourceErrorOpenAPISchema = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"code":   map[string]any{"type": "integer"},
+			"reason": map[string]any{"type": "string"},
+			"error":  map[string]any{"type": "string"},
+		},
+	}
+	jsonPatchOpenAPISchema = map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"op":    map[string]any{"type": "string"},
+				"path":  map[string]any{"type": "string"},
+				"value": map[string]any{},
+			},
+			"required": []string{"op", "path"},
+		},
+	}
+)
+
+// resourceOpenAPI builds the OpenAPIOperations for the routes of a resource.Schema in a ResourceGroupRouter
+type resourceOpenAPI struct {
+	kind string
+	// schema is the full OpenAPI schema of the objects
+	schema map[string]any
+	// object is a reference to the schema in the router's OpenAPI components
+	object map[string]any
+	// errorSchema is a reference to the ResourceErrorResponse schema in the router's OpenAPI components
+	errorSchema map[string]any
+}
+
+// newResourceOpenAPI returns a resourceOpenAPI for schema,
+// adding the schema of its objects to the OpenAPI components of router
+func newResourceOpenAPI(router *Router, schema resource.Schema) resourceOpenAPI {
+	object := map[string]any{
+		"type": "object",
+	}
+	if provider, ok := schema.(OpenAPISchemaProvider); ok && provider.OpenAPISchema() != nil {
+		object = provider.OpenAPISchema()
+	}
+	return resourceOpenAPI{
+		kind:        schema.Kind(),
+		schema:      object,
+		object:      router.AddOpenAPISchema(fmt.Sprintf("%s.%s.%s", schema.Group(), schema.Version(), schema.Kind()), object),
+		errorSchema: router.AddOpenAPISchema("ResourceErrorResponse", resourceErrorOpenAPISchema),
+	}
+}
+
+// subresource returns the schema of the subresource, from the properties of the object schema
+func (r resourceOpenAPI) subresource(subresource string) map[string]any {
+	if props, ok := r.schema["properties"].(map[string]any); ok {
+		if sch, ok := props[subresource].(map[string]any); ok {
+			return sch
+		}
+	}
+	return map[string]any{
+		"type": "object",
+	}
+}
+
+func (r resourceOpenAPI) operation(summary string, code int, response map[string]any) OpenAPIOperation {
+	resp := OpenAPIResponse{
+		Description: http.StatusText(code),
+	}
+	if response != nil {
+		resp.Content = JSONContent(response)
+	}
+	return OpenAPIOperation{
+		Summary: summary,
+		Tags:    []string{r.kind},
+		Responses: map[string]OpenAPIResponse{
+			strconv.Itoa(code): resp,
+			"default": {
+				Description: "Error",
+				Content:     JSONContent(r.errorSchema),
+			},
+		},
+	}
+}
+
+func (r resourceOpenAPI) create() OpenAPIOperation {
+	op := r.operation(fmt.Sprintf("Create a %s", r.kind), http.StatusAccepted, r.object)
+	op.RequestBody = &OpenAPIRequestBody{
+		Required: true,
+		Content:  JSONContent(r.object),
+	}
+	return op
+}
+
+func (r resourceOpenAPI) list() OpenAPIOperation {
+	op := r.operation(fmt.Sprintf("List %s resources", r.kind), http.StatusOK, map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"metadata": map[string]any{"type": "object"},
+			"items": map[string]any{
+				"type":  "array",
+				"items": r.object,
+			},
+		},
+	})
+	op.Parameters = []OpenAPIParameter{{
+		Name:        "labelSelector",
+		In:          "query",
+		Description: "A selector to restrict the list of returned resources by their labels",
+		Schema:      map[string]any{"type": "string"},
+	}, {
+		Name:        "limit",
+		In:          "query",
+		Description: "The maximum number of resources to return",
+		Schema:      map[string]any{"type": "integer", "minimum": 1},
+	}, {
+		Name:        "continue",
+		In:          "query",
+		Description: "The continue token from the metadata of a previous list response, to retrieve the next page",
+		Schema:      map[string]any{"type": "string"},
+	}}
+	return op
+}
+
+func (r resourceOpenAPI) watch() OpenAPIOperation {
+	op := r.operation(fmt.Sprintf("Watch %s resources", r.kind), http.StatusOK, nil)
+	op.Description = "Streams server-sent events for changes to the resources. " +
+		"The data of each event is an object with the event type and the changed object, " +
+		"and the ID of each event is the resourceVersion of the object."
+	op.Responses[strconv.Itoa(http.StatusOK)] = OpenAPIResponse{
+		Description: http.StatusText(http.StatusOK),
+		Content: map[string]OpenAPIMediaType{
+			ContentTypeEventStream: {
+				Schema: map[string]any{"type": "string"},
+			},
+		},
+	}
+	op.Parameters = []OpenAPIParameter{{
+		Name:        "labelSelector",
+		In:          "query",
+		Description: "A selector to restrict the watched resources by their labels",
+		Schema:      map[string]any{"type": "string"},
+	}, {
+		Name:        "resourceVersion",
+		In:          "query",
+		Description: "The resourceVersion to resume the watch from",
+		Schema:      map[string]any{"type": "string"},
+	}, {
+		Name:        "Last-Event-ID",
+		In:          "header",
+		Description: "The ID of the last received event, to resume the watch from if resourceVersion is not set",
+		Schema:      map[string]any{"type": "string"},
+	}}
+	return op
+}
+
+func (r resourceOpenAPI) get() OpenAPIOperation {
+	return r.operation(fmt.Sprintf("Get a %s", r.kind), http.StatusOK, r.object)
+}
+
+func (r resourceOpenAPI) update() OpenAPIOperation {
+	op := r.operation(fmt.Sprintf("Replace a %s", r.kind), http.StatusAccepted, r.object)
+	op.Parameters = []OpenAPIParameter{{
+		Name:        "If-Match",
+		In:          "header",
+		Description: "The resourceVersion the update is conditional on",
+		Schema:      map[string]any{"type": "string"},
+	}}
+	op.RequestBody = &OpenAPIRequestBody{
+		Required: true,
+		Content:  JSONContent(r.object),
+	}
+	return op
+}
+
+func (r resourceOpenAPI) patch(subresource string) OpenAPIOperation {
+	summary := fmt.Sprintf("Patch a %s", r.kind)
+	if subresource != "" {
+		summary = fmt.Sprintf("Patch the %s of a %s", subresource, r.kind)
+	}
+	op := r.operation(summary, http.StatusAccepted, r.object)
+	op.RequestBody = &OpenAPIRequestBody{
+		Required: true,
+		Content: map[string]OpenAPIMediaType{
+			"application/json-patch+json": {
+				Schema: jsonPatchOpenAPISchema,
+			},
+			"application/merge-patch+json": {
+				Schema: map[string]any{"type": "object"},
+			},
+		},
+	}
+	return op
+}
+
+func (r resourceOpenAPI) delete() OpenAPIOperation {
+	return r.operation(fmt.Sprintf("Delete a %s", r.kind), http.StatusNoContent, nil)
+}
+
+func (r resourceOpenAPI) getSubresource(subresource string) OpenAPIOperation {
+	return r.operation(fmt.Sprintf("Get the %s of a %s", subresource, r.kind), http.StatusOK, r.subresource(subresource))
+}
+
+func (r resourceOpenAPI) updateSubresource(subresource string) OpenAPIOperation {
+	op := r.operation(fmt.Sprintf("Replace the %s of a %s", subresource, r.kind), http.StatusAccepted, r.object)
+	op.RequestBody = &OpenAPIRequestBody{
+		Required: true,
+		Content:  JSONContent(r.subresource(subresource)),
+	}
+	return op
+}
@@ -1,10 +1,17 @@
 package router
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/grafana/grafana-app-sdk/plugin"
 	"github.com/grafana/grafana-app-sdk/resource"
@@ -14,17 +21,170 @@ import (
 type Store interface {
 	Add(ctx context.Context, obj resource.Object) (resource.Object, error)
 	Get(ctx context.Context, kind string, identifier resource.Identifier) (resource.Object, error)
-	List(ctx context.Context, kind, namespace string, filters ...string) (resource.ListObject, error)
+	List(ctx context.Context, kind, namespace string, options resource.ListOptions) (resource.ListObject, error)
 	Update(ctx context.Context, obj resource.Object) (resource.Object, error)
+	UpdateSubresource(ctx context.Context, kind string, identifier resource.Identifier,
+		subresourceName resource.SubresourceName, obj any) (resource.Object, error)
+	Patch(ctx context.Context, kind string, identifier resource.Identifier, patch resource.PatchRequest) (
+		resource.Object, error)
+	PatchSubresource(ctx context.Context, kind string, identifier resource.Identifier,
+		subresourceName resource.SubresourceName, patch resource.PatchRequest) (resource.Object, error)
 	Delete(ctx context.Context, kind string, identifier resource.Identifier) error
 }
 
+// StoreResolver resolves the namespace and Store to use for a request from the request's context.
+// Returning a plugin.Error allows the resolver to control the response code if resolution fails.
+type StoreResolver func(ctx context.Context) (namespace string, store Store, err error)
+
+// StaticStoreResolver returns a StoreResolver which always resolves to namespace and store.
+func StaticStoreResolver(namespace string, store Store) StoreResolver {
+	return func(context.Context) (string, Store, error) {
+		return namespace, store, nil
+	}
+}
+
+// ResourceErrorReason is a machine-readable reason for the failure of a ResourceGroupRouter request.
+// Reasons match the equivalent kubernetes API status reasons where one exists.
+type ResourceErrorReason string
+
+const (
+	ResourceErrorReasonBadRequest         = ResourceErrorReason("BadRequest")
+	ResourceErrorReasonUnauthorized       = ResourceErrorReason("Unauthorized")
+	ResourceErrorReasonForbidden          = ResourceErrorReason("Forbidden")
+	ResourceErrorReasonNotFound           = ResourceErrorReason("NotFound")
+	ResourceErrorReasonConflict           = ResourceErrorReason("Conflict")
+	ResourceErrorReasonGone               = ResourceErrorReason("Gone")
+	ResourceErrorReasonPreconditionFailed = ResourceErrorReason("PreconditionFailed")
+	ResourceErrorReasonInvalid            = ResourceErrorReason("Invalid")
+	ResourceErrorReasonInternalError      = ResourceErrorReason("InternalError")
+	ResourceErrorReasonUnknown            = ResourceErrorReason("Unknown")
+)
+
+var resourceErrorReasons = map[int]ResourceErrorReason{
+	http.StatusBadRequest:          ResourceErrorReasonBadRequest,
+	http.StatusUnauthorized:        ResourceErrorReasonUnauthorized,
+	http.StatusForbidden:           ResourceErrorReasonForbidden,
+	http.StatusNotFound:            ResourceErrorReasonNotFound,
+	http.StatusConflict:            ResourceErrorReasonConflict,
+	http.StatusGone:                ResourceErrorReasonGone,
+	http.StatusPreconditionFailed:  ResourceErrorReasonPreconditionFailed,
+	http.StatusUnprocessableEntity: ResourceErrorReasonInvalid,
+	http.StatusInternalServerError: ResourceErrorReasonInternalError,
+}
+
+// ResourceErrorResponse is the response format used to render errors from ResourceGroupRouter routes.
+// It has the same code and error fields as JSONErrorResponse, as well as the reason for the error,
+// so clients can distinguish, for example, a Conflict caused by a stale resourceVersion from other failures.
+// If the request body failed validation, the reason is Invalid, and Details contains the invalid fields.
+type ResourceErrorResponse struct {
+	Code    int                 `json:"code"`
+	Reason  ResourceErrorReason `json:"reason"`
+	Error   string              `json:"error"`
+	Details []ValidationCause   `json:"details,omitempty"`
+}
+
+// resourceErrorHandler is the JSONErrorHandler used by ResourceGroupRouter
+func resourceErrorHandler(err plugin.Error) (int, JSONResponse) {
+	reason, ok := resourceErrorReasons[err.Code]
+	if !ok {
+		reason = ResourceErrorReasonUnknown
+	}
+	response := &ResourceErrorResponse{
+		Code:   err.Code,
+		Reason: reason,
+		Error:  err.CleanMessage(),
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		response.Reason = ResourceErrorReasonInvalid
+		response.Details = validationErr.Causes
+	}
+	return err.Code, response
+}
+
+// ResourceGroupRouterOption is an option which can be passed to the ResourceGroupRouter constructors.
+type ResourceGroupRouterOption func(*ResourceGroupRouter)
+
+// WithRequestValidation returns a ResourceGroupRouterOption which validates the body of create and update requests
+// (for both resources and subresources) with validator before the resource is passed to the Store.
+// Requests with an invalid body fail with a 400 Bad Request, and the ResourceErrorResponse lists each invalid field.
+// Patch requests are validated by applying the patch to the resource in the Store and validating the patched spec
+// (or subresource), and the resource is then only patched if it hasn't changed since it was validated.
+// If validator is a SchemaCheckingValidator, the router can't be created unless it can validate every schema in the group.
+//
+// To validate bodies against the OpenAPI schemas of the resource group's kinds, use NewOpenAPISchemaValidator.
+func WithRequestValidation(validator SchemaValidator) ResourceGroupRouterOption {
+	return func(router *ResourceGroupRouter) {
+		router.validator = validator
+	}
+}
+
+// WithSubresources returns a ResourceGroupRouterOption which adds routes for the named subresources of schema,
+// in addition to the subresources found in the schema (see ResourceGroupRouter).
+// It is only needed for schemas whose subresources can't be found, such as a resource.SimpleSchema of
+// resource.SimpleObject created without an OpenAPI schema.
+func WithSubresources(schema resource.Schema, subresources ...string) ResourceGroupRouterOption {
+	return func(router *ResourceGroupRouter) {
+		router.subresources[schema] = append(router.subresources[schema], subresources...)
+	}
+}
+
+// WithOpenAPIInfo returns a ResourceGroupRouterOption which sets the info of the OpenAPI document
+// the router serves at OpenAPIPath. By default, the title is "<groups> API" for the groups of the resource group's kinds,
+// and the version is "1.0.0".
+func WithOpenAPIInfo(info OpenAPIInfo) ResourceGroupRouterOption {
+	return func(router *ResourceGroupRouter) {
+		router.openAPIInfo = &info
+	}
+}
+
+// WithAuthorization returns a ResourceGroupRouterOption which authorizes every request to the router with policy,
+// using the Grafana user of the request, before the request is handled.
+// If policy is nil, ResourceGroupPolicy is used, which requires the Viewer role for reads and the Editor role for writes.
+// Denied requests fail with the code of the policy's error (typically 403 Forbidden).
+// Routes added to the router with their own policy (see RouteHandler.Authorize) use that policy instead.
+func WithAuthorization(policy AuthorizationPolicy) ResourceGroupRouterOption {
+	return func(router *ResourceGroupRouter) {
+		if policy == nil {
+			policy = ResourceGroupPolicy
+		}
+		router.policy = policy
+	}
+}
+
 // ResourceGroupRouter is a Router which exposes generic CRUD routes for every resource contained in a given group.
+// In addition to create, get, list, update, patch, and delete routes for each resource,
+// each subresource of the resource has routes to get, update, and patch only that subresource.
+// The subresources are those returned by the Subresources() method of the schema's ZeroValue(),
+// every property other than apiVersion, kind, metadata, and spec in the schema's OpenAPI schema (if it implements OpenAPISchemaProvider),
+// and any added with WithSubresources.
+// Each resource also has a watch route, which streams changes to the resources as server-sent events
+// if the Store implements WatchStore.
+//
+// The namespace and Store used for each request are resolved with a StoreResolver,
+// so a single router can serve resources in a different namespace (with a different Store) per request.
+//
+// Errors are returned as a ResourceErrorResponse. Errors from the Store which are a resource.APIServerResponseError
+// with a client error status code (such as 404 Not Found, 409 Conflict, or 422 Unprocessable Entity) keep that status code.
+//
+// Admission controllers added with AddValidatingAdmissionController and AddMutatingAdmissionController
+// run in-process before every write, so the same rules apply as when writes are admitted by webhooks.
+//
+// All routes are described in the router's OpenAPI document (see Router.OpenAPI),
+// which the router serves at OpenAPIPath (see WithOpenAPIInfo).
+// If a schema implements OpenAPISchemaProvider, its OpenAPI schema is used for the request and response bodies.
+//
+// Request bodies are only decoded into the schema's ZeroValue(), which drops unknown fields.
+// To validate request bodies before they are passed to the Store, use WithRequestValidation.
 type ResourceGroupRouter struct {
 	*JSONRouter
 	resourceGroup resource.SchemaGroup
-	namespace     string
-	store         Store
+	resolveStore  StoreResolver
+	validator     SchemaValidator
+	admission     resource.InProcessAdmission
+	policy        AuthorizationPolicy
+	subresources  map[resource.Schema][]string
+	openAPIInfo   *OpenAPIInfo
 }
 
 // NewResourceGroupRouter returns a new ResourceGroupRouter,
@@ -33,10 +193,11 @@ func NewResourceGroupRouter(
 	resourceGroup resource.SchemaGroup,
 	namespace string,
 	clientGenerator resource.ClientGenerator,
+	opts ...ResourceGroupRouterOption,
 ) (*ResourceGroupRouter, error) {
 	store := resource.NewStore(clientGenerator, resourceGroup)
 
-	return NewResourceGroupRouterWithStore(resourceGroup, namespace, store)
+	return NewResourceGroupRouterWithStore(resourceGroup, namespace, store, opts...)
 }
 
 // NewResourceGroupRouterWithStore returns a new ResourceGroupRouter with pre-configured Store.
@@ -44,13 +205,42 @@ func NewResourceGroupRouterWithStore(
 	resourceGroup resource.SchemaGroup,
 	namespace string,
 	store Store,
+	opts ...ResourceGroupRouterOption,
 ) (*ResourceGroupRouter, error) {
+	return NewResourceGroupRouterWithStoreResolver(resourceGroup, StaticStoreResolver(namespace, store), opts...)
+}
+
+// NewResourceGroupRouterWithStoreResolver returns a new ResourceGroupRouter which resolves the namespace and Store
+// for each request with resolver. If resolver returns an error, the request fails with that error.
+func NewResourceGroupRouterWithStoreResolver(
+	resourceGroup resource.SchemaGroup,
+	resolver StoreResolver,
+	opts ...ResourceGroupRouterOption,
+) (*ResourceGroupRouter, error) {
+	if resolver == nil {
+		return nil, fmt.Errorf("resolver cannot be nil")
+	}
+
 	router := &ResourceGroupRouter{
-		JSONRouter:    NewJSONRouter(),
+		JSONRouter:    NewJSONRouterWithErrorHandler(resourceErrorHandler),
 		resourceGroup: resourceGroup,
-		store:         store,
-		namespace:     namespace,
+		resolveStore:  resolver,
+		subresources:  make(map[resource.Schema][]string),
+	}
+	for _, opt := range opts {
+		opt(router)
+	}
+	if checker, ok := router.validator.(SchemaCheckingValidator); ok {
+		for _, schema := range router.resourceGroup.Schemas() {
+			if err := checker.CheckSchema(schema); err != nil {
+				return nil, fmt.Errorf("unable to validate requests: %w", err)
+			}
+		}
 	}
+	if router.policy != nil {
+		router.Use(newAuthorizationMiddleware(router.policy, resourceErrorHandler))
+	}
+	router.Use(NewAdmissionUserInfoMiddleware())
 
 	for _, schema := range router.resourceGroup.Schemas() {
 		// TODO: all possible versions for each kind should be handled, address this with SchemaGroup in codegen?
@@ -60,104 +250,342 @@ func NewResourceGroupRouterWithStore(
 			schema.Version(),
 			schema.Plural(),
 		)
+		doc := newResourceOpenAPI(&router.Router, schema)
 
 		router.HandleWithCode(
 			baseRoute, router.createResource(schema), http.StatusAccepted, http.MethodPost,
-		)
+		).OpenAPI(doc.create())
+		router.Router.Handle(
+			fmt.Sprintf("%s/%s/watch/%s", schema.Group(), schema.Version(), schema.Plural()),
+			router.watchResources(schema), http.MethodGet,
+		).OpenAPI(doc.watch()).Timeout(0) // The watch stream is open until the client disconnects
 		router.Handle(
 			baseRoute, router.listResources(schema), http.MethodGet,
-		)
+		).OpenAPI(doc.list())
 		router.Handle(
 			fmt.Sprintf("%s/{name}", baseRoute), router.getResource(schema), http.MethodGet,
-		)
+		).OpenAPI(doc.get())
 		router.HandleWithCode(
 			fmt.Sprintf("%s/{name}", baseRoute), router.updateResource(schema), http.StatusAccepted, http.MethodPut,
-		)
+		).OpenAPI(doc.update())
+		router.HandleWithCode(
+			fmt.Sprintf("%s/{name}", baseRoute), router.patchResource(schema, ""), http.StatusAccepted, http.MethodPatch,
+		).OpenAPI(doc.patch(""))
 		router.Handle(
 			fmt.Sprintf("%s/{name}", baseRoute), router.deleteResource(schema), http.MethodDelete,
-		)
+		).OpenAPI(doc.delete())
+		for _, subresource := range router.subresourcesOf(schema) {
+			subresourceRoute := fmt.Sprintf("%s/{name}/%s", baseRoute, subresource)
+			router.Handle(
+				subresourceRoute, router.getSubresource(schema, subresource), http.MethodGet,
+			).OpenAPI(doc.getSubresource(subresource))
+			router.HandleWithCode(
+				subresourceRoute, router.updateSubresource(schema, subresource), http.StatusAccepted, http.MethodPut,
+			).OpenAPI(doc.updateSubresource(subresource))
+			router.HandleWithCode(
+				subresourceRoute, router.patchResource(schema, subresource), http.StatusAccepted, http.MethodPatch,
+			).OpenAPI(doc.patch(subresource))
+		}
+	}
+	if router.openAPIInfo == nil {
+		router.openAPIInfo = &OpenAPIInfo{
+			Title:   fmt.Sprintf("%s API", strings.Join(router.groups(), ", ")),
+			Version: "1.0.0",
+		}
 	}
+	router.HandleOpenAPI(*router.openAPIInfo)
 
 	return router, nil
 }
 
+// groups returns the sorted groups of the resource group's kinds
+func (router *ResourceGroupRouter) groups() []string {
+	groups := make(map[string]struct{})
+	for _, schema := range router.resourceGroup.Schemas() {
+		groups[schema.Group()] = struct{}{}
+	}
+	return sortedKeys(groups)
+}
+
+// subresourcesOf returns the sorted names of the subresources of schema.
+// A resource.SimpleObject has no subresources until it is unmarshaled, so its ZeroValue() doesn't have any,
+// and they are found in the schema's OpenAPI schema (as in a CRD, every top-level property other than spec is a subresource),
+// or added with WithSubresources.
+func (router *ResourceGroupRouter) subresourcesOf(schema resource.Schema) []string {
+	names := make(map[string]struct{})
+	for subresource := range schema.ZeroValue().Subresources() {
+		names[subresource] = struct{}{}
+	}
+	if provider, ok := schema.(OpenAPISchemaProvider); ok {
+		properties, _ := provider.OpenAPISchema()["properties"].(map[string]any)
+		for property := range properties {
+			switch property {
+			case "apiVersion", "kind", "metadata", "spec":
+			default:
+				names[property] = struct{}{}
+			}
+		}
+	}
+	for _, subresource := range router.subresources[schema] {
+		names[subresource] = struct{}{}
+	}
+	return sortedKeys(names)
+}
+
 func (router *ResourceGroupRouter) createResource(cr resource.Schema) JSONHandlerFunc {
 	return func(ctx context.Context, request JSONRequest) (JSONResponse, error) {
+		namespace, store, err := router.resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := router.readBody(cr, "", request)
+		if err != nil {
+			return nil, err
+		}
+
 		toBeInserted := cr.ZeroValue()
 		// TODO: use Unmarshal() method for version stuff
-		if err := json.NewDecoder(request.Body).Decode(toBeInserted); err != nil {
+		if err := json.Unmarshal(body, toBeInserted); err != nil {
 			return nil, plugin.WrapError(http.StatusBadRequest, err)
 		}
 		// The only bit of static metadata the user can specify here is the name
 		toBeInserted.SetStaticMetadata(resource.StaticMetadata{
 			Name:      toBeInserted.StaticMetadata().Name,
-			Namespace: router.namespace,
+			Namespace: namespace,
 			Group:     cr.Group(),
 			Version:   cr.Version(),
 			Kind:      cr.Kind(),
 		})
 
-		addedResource, err := router.store.Add(ctx, toBeInserted)
+		addedResource, err := store.Add(ctx, toBeInserted)
 		if err != nil {
-			return nil, plugin.WrapError(http.StatusInternalServerError, err)
+			return nil, storeError(err)
 		}
 
 		return addedResource, nil
 	}
 }
 
+// listResources lists resources, accepting the kubernetes-style `labelSelector`, `limit`, and `continue` query parameters.
+// The response contains the list metadata, which includes the continue token and remaining item count if there are more results.
 func (router *ResourceGroupRouter) listResources(cr resource.Schema) JSONHandlerFunc {
 	return func(ctx context.Context, request JSONRequest) (JSONResponse, error) {
-		resources, err := router.store.List(ctx, cr.Kind(), router.namespace) // TODO: support labels
+		namespace, store, err := router.resolve(ctx)
 		if err != nil {
-			return nil, plugin.WrapError(http.StatusInternalServerError, err)
+			return nil, err
 		}
 
-		return resources, nil
+		query := request.URL.Query()
+		options := resource.ListOptions{
+			Continue: query.Get("continue"),
+		}
+		if selector := query.Get("labelSelector"); selector != "" {
+			options.LabelFilters = []string{selector}
+		}
+		if limit := query.Get("limit"); limit != "" {
+			parsed, err := strconv.Atoi(limit)
+			if err != nil || parsed <= 0 {
+				return nil, plugin.NewError(http.StatusBadRequest, "limit must be a positive integer")
+			}
+			options.Limit = parsed
+		}
+
+		resources, err := store.List(ctx, cr.Kind(), namespace, options)
+		if err != nil {
+			return nil, storeError(err)
+		}
+
+		return &resource.SimpleList[resource.Object]{
+			ListMeta: resources.ListMetadata(),
+			Items:    resources.ListItems(),
+		}, nil
 	}
 }
 
 func (router *ResourceGroupRouter) getResource(cr resource.Schema) JSONHandlerFunc {
 	return func(ctx context.Context, request JSONRequest) (JSONResponse, error) {
+		namespace, store, err := router.resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+
 		name, ok := request.Vars.Get("name")
 		if !ok {
 			return nil, plugin.NewError(http.StatusBadRequest, "must provide resource name")
 		}
 
-		obj, err := router.store.Get(ctx, cr.Kind(), resource.Identifier{
-			Namespace: router.namespace,
+		obj, err := store.Get(ctx, cr.Kind(), resource.Identifier{
+			Namespace: namespace,
 			Name:      name,
 		})
 		if err != nil {
-			return nil, plugin.WrapError(http.StatusInternalServerError, err)
+			return nil, storeError(err)
 		}
 
 		return obj, nil
 	}
 }
 
+// updateResource replaces the resource with the request body.
+// The update is conditional on the resourceVersion in the body, or in the If-Match header.
+// If the resourceVersion is stale, the store's 409 Conflict error is returned.
 func (router *ResourceGroupRouter) updateResource(cr resource.Schema) JSONHandlerFunc {
 	return func(ctx context.Context, request JSONRequest) (JSONResponse, error) {
+		namespace, store, err := router.resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+
 		if _, ok := request.Vars.Get("name"); !ok {
 			return nil, plugin.NewError(http.StatusBadRequest, "must provide resource name")
 		}
 
+		body, err := router.readBody(cr, "", request)
+		if err != nil {
+			return nil, err
+		}
+
 		updatedResource := cr.ZeroValue()
-		if err := json.NewDecoder(request.Body).Decode(updatedResource); err != nil {
+		if err := json.Unmarshal(body, updatedResource); err != nil {
 			return nil, plugin.WrapError(http.StatusBadRequest, err)
 		}
 		// The only bit of static metadata the user can specify here is the name
 		updatedResource.SetStaticMetadata(resource.StaticMetadata{
 			Name:      updatedResource.StaticMetadata().Name,
-			Namespace: router.namespace,
+			Namespace: namespace,
 			Group:     cr.Group(),
 			Version:   cr.Version(),
 			Kind:      cr.Kind(),
 		})
+		// An If-Match header sets the resourceVersion the update is conditional on,
+		// and must agree with the resourceVersion in the body if the body has one
+		ifMatch, err := parseIfMatch(request.Headers.Get("If-Match"))
+		if err != nil {
+			return nil, plugin.WrapError(http.StatusBadRequest, err)
+		}
+		if ifMatch != "" {
+			md := updatedResource.CommonMetadata()
+			if md.ResourceVersion != "" && md.ResourceVersion != ifMatch {
+				return nil, plugin.NewError(http.StatusPreconditionFailed,
+					"If-Match header does not match the resourceVersion of the resource")
+			}
+			md.ResourceVersion = ifMatch
+			updatedResource.SetCommonMetadata(md)
+		}
+
+		updated, err := store.Update(ctx, updatedResource)
+		if err != nil {
+			return nil, storeError(err)
+		}
+
+		return updated, nil
+	}
+}
+
+// patchResource patches the resource, or only the subresource if subresource is non-empty.
+// The request body is a JSON Patch if the Content-Type is application/json-patch+json or the body is a JSON array,
+// and a JSON Merge Patch otherwise.
+func (router *ResourceGroupRouter) patchResource(cr resource.Schema, subresource string) JSONHandlerFunc {
+	return func(ctx context.Context, request JSONRequest) (JSONResponse, error) {
+		namespace, store, err := router.resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		name, ok := request.Vars.Get("name")
+		if !ok {
+			return nil, plugin.NewError(http.StatusBadRequest, "must provide resource name")
+		}
+
+		patch, err := parsePatchRequest(request)
+		if err != nil {
+			return nil, plugin.WrapError(http.StatusBadRequest, err)
+		}
+
+		identifier := resource.Identifier{
+			Namespace: namespace,
+			Name:      name,
+		}
+		if router.validator != nil {
+			if patch, err = router.validatePatch(ctx, store, cr, identifier, subresource, patch); err != nil {
+				return nil, err
+			}
+		}
+		var patched resource.Object
+		if subresource != "" {
+			patched, err = store.PatchSubresource(ctx, cr.Kind(), identifier, resource.SubresourceName(subresource), patch)
+		} else {
+			patched, err = store.Patch(ctx, cr.Kind(), identifier, patch)
+		}
+		if err != nil {
+			return nil, storeError(err)
+		}
+
+		return patched, nil
+	}
+}
+
+// getSubresource returns only the subresource of the resource
+func (router *ResourceGroupRouter) getSubresource(cr resource.Schema, subresource string) JSONHandlerFunc {
+	return func(ctx context.Context, request JSONRequest) (JSONResponse, error) {
+		namespace, store, err := router.resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		name, ok := request.Vars.Get("name")
+		if !ok {
+			return nil, plugin.NewError(http.StatusBadRequest, "must provide resource name")
+		}
+
+		obj, err := store.Get(ctx, cr.Kind(), resource.Identifier{
+			Namespace: namespace,
+			Name:      name,
+		})
+		if err != nil {
+			return nil, storeError(err)
+		}
+
+		return obj.Subresources()[subresource], nil
+	}
+}
+
+// updateSubresource replaces the subresource of the resource with the request body, and returns the updated resource.
+// The body is decoded into the type of the subresource in the schema's ZeroValue(), so invalid bodies are rejected.
+func (router *ResourceGroupRouter) updateSubresource(cr resource.Schema, subresource string) JSONHandlerFunc {
+	return func(ctx context.Context, request JSONRequest) (JSONResponse, error) {
+		namespace, store, err := router.resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		name, ok := request.Vars.Get("name")
+		if !ok {
+			return nil, plugin.NewError(http.StatusBadRequest, "must provide resource name")
+		}
+
+		raw, err := router.readBody(cr, subresource, request)
+		if err != nil {
+			return nil, err
+		}
 
-		updated, err := router.store.Update(ctx, updatedResource)
+		var body any
+		if typ := reflect.TypeOf(cr.ZeroValue().Subresources()[subresource]); typ != nil {
+			body = reflect.New(typ).Interface()
+		} else {
+			body = &json.RawMessage{}
+		}
+		if err := json.Unmarshal(raw, body); err != nil {
+			return nil, plugin.WrapError(http.StatusBadRequest, err)
+		}
+
+		updated, err := store.UpdateSubresource(ctx, cr.Kind(), resource.Identifier{
+			Namespace: namespace,
+			Name:      name,
+		}, resource.SubresourceName(subresource), body)
 		if err != nil {
-			return nil, plugin.WrapError(http.StatusInternalServerError, err)
+			return nil, storeError(err)
 		}
 
 		return updated, nil
@@ -166,18 +594,223 @@ func (router *ResourceGroupRouter) updateResource(cr resource.Schema) JSONHandle
 
 func (router *ResourceGroupRouter) deleteResource(cr resource.Schema) JSONHandlerFunc {
 	return func(ctx context.Context, request JSONRequest) (JSONResponse, error) {
+		namespace, store, err := router.resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+
 		name, ok := request.Vars.Get("name")
 		if !ok {
 			return nil, plugin.NewError(http.StatusBadRequest, "must provide resource name")
 		}
 
-		if err := router.store.Delete(ctx, cr.Kind(), resource.Identifier{
-			Namespace: router.namespace,
+		if err := store.Delete(ctx, cr.Kind(), resource.Identifier{
+			Namespace: namespace,
 			Name:      name,
 		}); err != nil {
-			return nil, plugin.WrapError(http.StatusInternalServerError, err)
+			return nil, storeError(err)
 		}
 
 		return nil, nil
 	}
 }
+
+// parseIfMatch returns the resourceVersion in an If-Match header value, which is a single (optionally weak) entity tag.
+// An empty or wildcard value matches any resourceVersion, so an empty string is returned.
+func parseIfMatch(header string) (string, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return "", nil
+	}
+	if strings.Contains(header, ",") {
+		return "", fmt.Errorf("the If-Match header must contain a single resourceVersion")
+	}
+	header = strings.TrimPrefix(header, "W/")
+	return strings.Trim(header, `"`), nil
+}
+
+// storeError converts an error returned by a Store into a plugin.Error.
+// If err is a resource.APIServerResponseError with a client error status code the router can pass on
+// (such as a 404 Not Found, or 409 Conflict for a stale resourceVersion), that status code is used.
+// All other errors are internal server errors.
+func storeError(err error) error {
+	// Admission controllers may deny requests with any status code, as with webhooks
+	var denied *resource.AdmissionDeniedError
+	if errors.As(err, &denied) {
+		return plugin.WrapError(denied.StatusCode(), err)
+	}
+	var cast resource.APIServerResponseError
+	if errors.As(err, &cast) {
+		switch cast.StatusCode() {
+		case http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict,
+			http.StatusGone, http.StatusPreconditionFailed, http.StatusUnprocessableEntity:
+			return plugin.WrapError(cast.StatusCode(), err)
+		}
+	}
+
+	return plugin.WrapError(http.StatusInternalServerError, err)
+}
+
+// parsePatchRequest creates a resource.PatchRequest from the body of the request
+func parsePatchRequest(request JSONRequest) (resource.PatchRequest, error) {
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return resource.PatchRequest{}, err
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return resource.PatchRequest{}, fmt.Errorf("patch body cannot be empty")
+	}
+
+	contentType, _, _ := mime.ParseMediaType(request.Headers.Get("Content-Type"))
+	if contentType == "application/json-patch+json" || (contentType != "application/merge-patch+json" && body[0] == '[') {
+		ops := make([]resource.PatchOperation, 0)
+		if err := json.Unmarshal(body, &ops); err != nil {
+			return resource.PatchRequest{}, err
+		}
+		return resource.PatchRequest{
+			Type:       resource.PatchTypeJSONPatch,
+			Operations: ops,
+		}, nil
+	}
+
+	if body[0] != '{' || !json.Valid(body) {
+		return resource.PatchRequest{}, fmt.Errorf("merge patch body must be a JSON object")
+	}
+	return resource.PatchRequest{
+		Type:       resource.PatchTypeMergePatch,
+		MergePatch: body,
+	}, nil
+}
+
+// readBody reads the body of the request, and validates it with the router's SchemaValidator, if it has one.
+// The body is the whole resource, or only the subresource if subresource is non-empty.
+func (router *ResourceGroupRouter) readBody(cr resource.Schema, subresource string, request JSONRequest) ([]byte, error) {
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, plugin.WrapError(http.StatusBadRequest, err)
+	}
+	if router.validator == nil {
+		return body, nil
+	}
+
+	if err := router.validate(cr, subresource, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// validatePatch applies patch to the existing resource in the Store, and validates the patched spec
+// (or only the patched subresource, if subresource is non-empty) with the router's SchemaValidator.
+// The patch is applied to the resource's document (see resource.ObjectDocument), so fields which the resource's type
+// would drop when unmarshaled are still validated.
+// As the patched resource is validated against the resource in the Store, the returned patch is conditional
+// on the resourceVersion of that resource, so it fails if the resource has changed since it was validated.
+func (router *ResourceGroupRouter) validatePatch(ctx context.Context, store Store, cr resource.Schema,
+	identifier resource.Identifier, subresource string, patch resource.PatchRequest) (resource.PatchRequest, error) {
+	existing, err := store.Get(ctx, cr.Kind(), identifier)
+	if err != nil {
+		return resource.PatchRequest{}, storeError(err)
+	}
+	doc, err := resource.ObjectDocument(existing)
+	if err != nil {
+		return resource.PatchRequest{}, plugin.WrapError(http.StatusInternalServerError, err)
+	}
+	patched, err := resource.PatchDocument(doc, patch)
+	if err != nil {
+		return resource.PatchRequest{}, plugin.WrapError(http.StatusUnprocessableEntity,
+			fmt.Errorf("unable to apply patch: %w", err))
+	}
+	patchedDoc, ok := patched.(map[string]any)
+	if !ok {
+		return resource.PatchRequest{}, plugin.NewError(http.StatusUnprocessableEntity,
+			"patched object must be a JSON object")
+	}
+
+	var body []byte
+	if subresource != "" {
+		body, err = json.Marshal(patchedDoc[subresource])
+	} else {
+		// Only the metadata and spec are patched, and metadata is not validated
+		body, err = json.Marshal(map[string]any{
+			"spec": patchedDoc["spec"],
+		})
+	}
+	if err != nil {
+		return resource.PatchRequest{}, plugin.WrapError(http.StatusInternalServerError, err)
+	}
+	if err = router.validate(cr, subresource, body); err != nil {
+		return resource.PatchRequest{}, err
+	}
+	return conditionalPatch(patch, existing.CommonMetadata().ResourceVersion)
+}
+
+// validate validates body with the router's SchemaValidator.
+// Invalid bodies are a 400 Bad Request, and all other errors are internal server errors.
+func (router *ResourceGroupRouter) validate(cr resource.Schema, subresource string, body []byte) error {
+	if err := router.validator.Validate(cr, subresource, body); err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return plugin.WrapError(http.StatusBadRequest, err)
+		}
+		return plugin.WrapError(http.StatusInternalServerError, err)
+	}
+	return nil
+}
+
+// conditionalPatch returns a copy of patch which fails if the resource no longer has resourceVersion.
+// A JSON Patch gets a "test" of the resourceVersion as its first operation,
+// and a JSON Merge Patch sets the resourceVersion in the metadata (unless it already does),
+// which the API server treats as a precondition.
+func conditionalPatch(patch resource.PatchRequest, resourceVersion string) (resource.PatchRequest, error) {
+	if resourceVersion == "" {
+		return patch, nil
+	}
+	if patch.Type == resource.PatchTypeMergePatch {
+		doc := make(map[string]any)
+		if err := json.Unmarshal(patch.MergePatch, &doc); err != nil {
+			return resource.PatchRequest{}, plugin.WrapError(http.StatusBadRequest, err)
+		}
+		meta, _ := doc["metadata"].(map[string]any)
+		if meta == nil {
+			meta = make(map[string]any)
+		}
+		if _, ok := meta["resourceVersion"]; !ok {
+			meta["resourceVersion"] = resourceVersion
+		}
+		doc["metadata"] = meta
+		mergePatch, err := json.Marshal(doc)
+		if err != nil {
+			return resource.PatchRequest{}, plugin.WrapError(http.StatusInternalServerError, err)
+		}
+		patch.MergePatch = mergePatch
+		return patch, nil
+	}
+	patch.Operations = append([]resource.PatchOperation{{
+		Operation: resource.PatchOpTest,
+		Path:      "/metadata/resourceVersion",
+		Value:     resourceVersion,
+	}}, patch.Operations...)
+	return patch, nil
+}
+
+// resolve resolves the namespace and Store for the request.
+// Errors are returned as a plugin.Error, with a 500 status code unless the resolver returned a plugin.Error.
+func (router *ResourceGroupRouter) resolve(ctx context.Context) (string, Store, error) {
+	namespace, store, err := router.resolveStore(ctx)
+	if err != nil {
+		return "", nil, plugin.FromError(err)
+	}
+	if store == nil {
+		return "", nil, plugin.NewError(http.StatusInternalServerError, "no store resolved for request")
+	}
+	if len(router.resourceGroup.Schemas()) > 0 {
+		store = &admittingStore{
+			Store:     store,
+			admission: &router.admission,
+			group:     router.resourceGroup,
+		}
+	}
+
+	return namespace, store, nil
+}
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/grafana/grafana-app-sdk/plugin"
 	"github.com/grafana/grafana-app-sdk/plugin/router"
 	"github.com/grafana/grafana-app-sdk/resource"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -69,10 +71,16 @@ type TestStatus struct {
 }
 
 type fakeStore struct {
-	addFunc    func(ctx context.Context, obj resource.Object) (resource.Object, error)
-	getFunc    func(ctx context.Context, kind string, identifier resource.Identifier) (resource.Object, error)
-	listFunc   func(ctx context.Context, kind, namespace string, filters ...string) (resource.ListObject, error)
-	updateFunc func(ctx context.Context, obj resource.Object) (resource.Object, error)
+	addFunc               func(ctx context.Context, obj resource.Object) (resource.Object, error)
+	getFunc               func(ctx context.Context, kind string, identifier resource.Identifier) (resource.Object, error)
+	listFunc              func(ctx context.Context, kind, namespace string, options resource.ListOptions) (resource.ListObject, error)
+	updateFunc            func(ctx context.Context, obj resource.Object) (resource.Object, error)
+	updateSubresourceFunc func(ctx context.Context, kind string, identifier resource.Identifier,
+		subresourceName resource.SubresourceName, obj any) (resource.Object, error)
+	patchFunc func(ctx context.Context, kind string, identifier resource.Identifier,
+		patch resource.PatchRequest) (resource.Object, error)
+	patchSubresourceFunc func(ctx context.Context, kind string, identifier resource.Identifier,
+		subresourceName resource.SubresourceName, patch resource.PatchRequest) (resource.Object, error)
 	deleteFunc func(ctx context.Context, kind string, identifier resource.Identifier) error
 }
 
@@ -92,9 +100,9 @@ func (s fakeStore) Get(ctx context.Context, kind string, identifier resource.Ide
 	return nil, nil
 }
 
-func (s fakeStore) List(ctx context.Context, kind, namespace string, filters ...string) (resource.ListObject, error) {
+func (s fakeStore) List(ctx context.Context, kind, namespace string, options resource.ListOptions) (resource.ListObject, error) {
 	if s.listFunc != nil {
-		return s.listFunc(ctx, kind, namespace, filters...)
+		return s.listFunc(ctx, kind, namespace, options)
 	}
 
 	return nil, nil
@@ -108,6 +116,33 @@ func (s fakeStore) Update(ctx context.Context, obj resource.Object) (resource.Ob
 	return nil, nil
 }
 
+func (s fakeStore) UpdateSubresource(ctx context.Context, kind string, identifier resource.Identifier,
+	subresourceName resource.SubresourceName, obj any) (resource.Object, error) {
+	if s.updateSubresourceFunc != nil {
+		return s.updateSubresourceFunc(ctx, kind, identifier, subresourceName, obj)
+	}
+
+	return nil, nil
+}
+
+func (s fakeStore) Patch(ctx context.Context, kind string, identifier resource.Identifier,
+	patch resource.PatchRequest) (resource.Object, error) {
+	if s.patchFunc != nil {
+		return s.patchFunc(ctx, kind, identifier, patch)
+	}
+
+	return nil, nil
+}
+
+func (s fakeStore) PatchSubresource(ctx context.Context, kind string, identifier resource.Identifier,
+	subresourceName resource.SubresourceName, patch resource.PatchRequest) (resource.Object, error) {
+	if s.patchSubresourceFunc != nil {
+		return s.patchSubresourceFunc(ctx, kind, identifier, subresourceName, patch)
+	}
+
+	return nil, nil
+}
+
 func (s fakeStore) Delete(ctx context.Context, kind string, identifier resource.Identifier) error {
 	if s.deleteFunc != nil {
 		return s.deleteFunc(ctx, kind, identifier)
@@ -202,7 +237,7 @@ func TestResourceGroupRouter_Create(t *testing.T) {
 func TestResourceGroupRouter_List(t *testing.T) {
 	t.Run("returns error as store returns error", func(t *testing.T) {
 		router, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
-			listFunc: func(ctx context.Context, kind, namespace string, filters ...string) (resource.ListObject, error) {
+			listFunc: func(ctx context.Context, kind, namespace string, options resource.ListOptions) (resource.ListObject, error) {
 				require.Equal(t, "Test", kind)
 
 				return nil, errors.New("error")
@@ -236,7 +271,7 @@ func TestResourceGroupRouter_List(t *testing.T) {
 		secondResource.Spec.SomeInfo = "second_resource_info"
 
 		router, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
-			listFunc: func(ctx context.Context, kind, namespace string, filters ...string) (resource.ListObject, error) {
+			listFunc: func(ctx context.Context, kind, namespace string, options resource.ListOptions) (resource.ListObject, error) {
 				require.Equal(t, "Test", kind)
 
 				list := resource.SimpleList[*Test]{}
@@ -272,6 +307,87 @@ func TestResourceGroupRouter_List(t *testing.T) {
 	})
 }
 
+func TestResourceGroupRouter_List_Pagination(t *testing.T) {
+	t.Run("returns 400 Bad Request for an invalid limit", func(t *testing.T) {
+		router, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
+			listFunc: func(ctx context.Context, kind, namespace string, options resource.ListOptions) (resource.ListObject, error) {
+				assert.Fail(t, "store should not be called with an invalid limit")
+				return nil, nil
+			},
+		})
+		require.NoError(t, err)
+
+		err = router.CallResource(
+			context.Background(),
+			&backend.CallResourceRequest{
+				Path:   "test.resource/v1/tests",
+				URL:    "test.resource/v1/tests?limit=foo",
+				Method: http.MethodGet,
+			},
+			fakeSender{
+				sendFunc: func(response *backend.CallResourceResponse) error {
+					assert.Equal(t, http.StatusBadRequest, response.Status)
+					return nil
+				},
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("passes query parameters to the store and returns list metadata", func(t *testing.T) {
+		item := testResource.ZeroValue().(*Test)
+		item.Spec.SomeInfo = "info"
+		remaining := int64(3)
+
+		router, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
+			listFunc: func(ctx context.Context, kind, namespace string, options resource.ListOptions) (resource.ListObject, error) {
+				assert.Equal(t, "Test", kind)
+				assert.Equal(t, metav1.NamespaceDefault, namespace)
+				assert.Equal(t, resource.ListOptions{
+					LabelFilters: []string{"a=b,c!=d"},
+					Limit:        1,
+					Continue:     "token",
+				}, options)
+
+				list := resource.SimpleList[*Test]{
+					ListMeta: resource.ListMetadata{
+						ResourceVersion:    "123",
+						Continue:           "next",
+						RemainingItemCount: &remaining,
+					},
+					Items: []*Test{item},
+				}
+				return &list, nil
+			},
+		})
+		require.NoError(t, err)
+
+		err = router.CallResource(
+			context.Background(),
+			&backend.CallResourceRequest{
+				Path:   "test.resource/v1/tests",
+				URL:    "test.resource/v1/tests?labelSelector=a%3Db%2Cc!%3Dd&limit=1&continue=token",
+				Method: http.MethodGet,
+			},
+			fakeSender{
+				sendFunc: func(response *backend.CallResourceResponse) error {
+					assert.Equal(t, http.StatusOK, response.Status)
+
+					var resources resource.SimpleList[*Test]
+					require.NoError(t, json.Unmarshal(response.Body, &resources))
+					assert.Equal(t, "next", resources.ListMeta.Continue)
+					require.NotNil(t, resources.ListMeta.RemainingItemCount)
+					assert.Equal(t, remaining, *resources.ListMeta.RemainingItemCount)
+					assert.Equal(t, []*Test{item}, resources.Items)
+
+					return nil
+				},
+			},
+		)
+		require.NoError(t, err)
+	})
+}
+
 func TestResourceGroupRouter_Get(t *testing.T) {
 	t.Run("returns error as store returns error", func(t *testing.T) {
 		router, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
@@ -412,6 +528,882 @@ func TestResourceGroupRouter_Update(t *testing.T) {
 	})
 }
 
+func TestResourceGroupRouter_Update_ResourceVersion(t *testing.T) {
+	tests := []struct {
+		name               string
+		bodyRV             string
+		ifMatch            string
+		expectedRV         string
+		expectedCode       int
+		expectedStoreCalls int
+	}{
+		{
+			name:               "resourceVersion in body",
+			bodyRV:             "1",
+			expectedRV:         "1",
+			expectedCode:       http.StatusAccepted,
+			expectedStoreCalls: 1,
+		},
+		{
+			name:               "If-Match header",
+			ifMatch:            `"2"`,
+			expectedRV:         "2",
+			expectedCode:       http.StatusAccepted,
+			expectedStoreCalls: 1,
+		},
+		{
+			name:               "weak If-Match header matching body",
+			bodyRV:             "3",
+			ifMatch:            `W/"3"`,
+			expectedRV:         "3",
+			expectedCode:       http.StatusAccepted,
+			expectedStoreCalls: 1,
+		},
+		{
+			name:               "wildcard If-Match header",
+			bodyRV:             "4",
+			ifMatch:            "*",
+			expectedRV:         "4",
+			expectedCode:       http.StatusAccepted,
+			expectedStoreCalls: 1,
+		},
+		{
+			name:         "If-Match header does not match body",
+			bodyRV:       "5",
+			ifMatch:      `"6"`,
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:         "multiple If-Match values",
+			ifMatch:      `"7", "8"`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			storeCalls := 0
+			rgr, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
+				updateFunc: func(ctx context.Context, obj resource.Object) (resource.Object, error) {
+					storeCalls++
+					assert.Equal(t, test.expectedRV, obj.CommonMetadata().ResourceVersion)
+					return obj, nil
+				},
+			})
+			require.NoError(t, err)
+
+			obj := testResource.ZeroValue().(*Test)
+			obj.SetStaticMetadata(resource.StaticMetadata{Name: "some_test"})
+			obj.SetCommonMetadata(resource.CommonMetadata{ResourceVersion: test.bodyRV})
+			b, err := json.Marshal(obj)
+			require.NoError(t, err)
+
+			headers := map[string][]string{}
+			if test.ifMatch != "" {
+				headers["If-Match"] = []string{test.ifMatch}
+			}
+			err = rgr.CallResource(
+				context.Background(),
+				&backend.CallResourceRequest{
+					Path:    "test.resource/v1/tests/some_test",
+					Method:  http.MethodPut,
+					Headers: headers,
+					Body:    b,
+				},
+				fakeSender{
+					sendFunc: func(response *backend.CallResourceResponse) error {
+						assert.Equal(t, test.expectedCode, response.Status)
+						return nil
+					},
+				},
+			)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStoreCalls, storeCalls)
+		})
+	}
+}
+
+type testServerResponseError struct {
+	statusCode int
+}
+
+func (e testServerResponseError) Error() string {
+	return fmt.Sprintf("server responded with %d", e.statusCode)
+}
+
+func (e testServerResponseError) StatusCode() int {
+	return e.statusCode
+}
+
+func TestResourceGroupRouter_StoreErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected router.ResourceErrorResponse
+	}{
+		{
+			name: "not found",
+			err:  testServerResponseError{statusCode: http.StatusNotFound},
+			expected: router.ResourceErrorResponse{
+				Code:   http.StatusNotFound,
+				Reason: router.ResourceErrorReasonNotFound,
+				Error:  "server responded with 404",
+			},
+		},
+		{
+			name: "wrapped conflict",
+			err:  fmt.Errorf("update failed: %w", testServerResponseError{statusCode: http.StatusConflict}),
+			expected: router.ResourceErrorResponse{
+				Code:   http.StatusConflict,
+				Reason: router.ResourceErrorReasonConflict,
+				Error:  "update failed: server responded with 409",
+			},
+		},
+		{
+			name: "invalid",
+			err:  testServerResponseError{statusCode: http.StatusUnprocessableEntity},
+			expected: router.ResourceErrorResponse{
+				Code:   http.StatusUnprocessableEntity,
+				Reason: router.ResourceErrorReasonInvalid,
+				Error:  "server responded with 422",
+			},
+		},
+		{
+			name: "forbidden",
+			err:  testServerResponseError{statusCode: http.StatusForbidden},
+			expected: router.ResourceErrorResponse{
+				Code:   http.StatusForbidden,
+				Reason: router.ResourceErrorReasonForbidden,
+				Error:  "server responded with 403",
+			},
+		},
+		{
+			name: "unauthorized is an internal error",
+			err:  testServerResponseError{statusCode: http.StatusUnauthorized},
+			expected: router.ResourceErrorResponse{
+				Code:   http.StatusInternalServerError,
+				Reason: router.ResourceErrorReasonInternalError,
+				Error:  "internal server error",
+			},
+		},
+		{
+			name: "other error",
+			err:  errors.New("error"),
+			expected: router.ResourceErrorResponse{
+				Code:   http.StatusInternalServerError,
+				Reason: router.ResourceErrorReasonInternalError,
+				Error:  "internal server error",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rgr, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
+				updateFunc: func(ctx context.Context, obj resource.Object) (resource.Object, error) {
+					return nil, test.err
+				},
+			})
+			require.NoError(t, err)
+
+			b, err := json.Marshal(testResource.ZeroValue())
+			require.NoError(t, err)
+
+			var response *backend.CallResourceResponse
+			err = rgr.CallResource(
+				context.Background(),
+				&backend.CallResourceRequest{
+					Path:   "test.resource/v1/tests/some_test",
+					Method: http.MethodPut,
+					Body:   b,
+				},
+				fakeSender{
+					sendFunc: func(r *backend.CallResourceResponse) error {
+						response = r
+						return nil
+					},
+				},
+			)
+			require.NoError(t, err)
+			require.NotNil(t, response)
+			assert.Equal(t, test.expected.Code, response.Status)
+			body := router.ResourceErrorResponse{}
+			require.NoError(t, json.Unmarshal(response.Body, &body))
+			assert.Equal(t, test.expected, body)
+		})
+	}
+}
+
+func TestResourceGroupRouter_ValidationWithoutOpenAPISchema(t *testing.T) {
+	group := resource.NewSimpleSchemaGroup("test.resource", "v1")
+	group.AddSchema(&Test{}, resource.WithKind("Test"), resource.WithOpenAPISchema(validationOpenAPISchema))
+	group.AddSchema(&Test{}, resource.WithKind("Other"), resource.WithPlural("others"))
+
+	_, err := router.NewResourceGroupRouterWithStore(group, metav1.NamespaceDefault, fakeStore{},
+		router.WithRequestValidation(router.NewOpenAPISchemaValidator()))
+	assert.ErrorIs(t, err, router.ErrNoOpenAPISchema)
+}
+
+func TestResourceGroupRouter_Validation(t *testing.T) {
+	group := resource.NewSimpleSchemaGroup("test.resource", "v1")
+	group.AddSchema(&Test{}, resource.WithKind("Test"), resource.WithOpenAPISchema(validationOpenAPISchema))
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		body            string
+		expectedCode    int
+		expectedDetails []router.ValidationCause
+	}{
+		{
+			name:         "valid create",
+			method:       http.MethodPost,
+			path:         "test.resource/v1/tests",
+			body:         `{"staticMetadata":{"name":"some_test"},"spec":{"some_info":"abc"}}`,
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "invalid create",
+			method:       http.MethodPost,
+			path:         "test.resource/v1/tests",
+			body:         `{"staticMetadata":{"name":"some_test"},"spec":{"some_info":"ABC","unknown":1}}`,
+			expectedCode: http.StatusBadRequest,
+			expectedDetails: []router.ValidationCause{{
+				Field:   "spec.some_info",
+				Message: "spec.some_info in body should match '^[a-z]+$'",
+			}, {
+				Field:   "spec.unknown",
+				Message: "spec.unknown in body is an unknown field",
+			}},
+		},
+		{
+			name:         "invalid update",
+			method:       http.MethodPut,
+			path:         "test.resource/v1/tests/some_test",
+			body:         `{"staticMetadata":{"name":"some_test"},"spec":{}}`,
+			expectedCode: http.StatusBadRequest,
+			expectedDetails: []router.ValidationCause{{
+				Field:   "spec.some_info",
+				Message: "spec.some_info in body is required",
+			}},
+		},
+		{
+			name:         "invalid subresource update",
+			method:       http.MethodPut,
+			path:         "test.resource/v1/tests/some_test/status",
+			body:         `{"status":"unknown"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedDetails: []router.ValidationCause{{
+				Field:   "status.status",
+				Message: "status.status in body should be one of [ok failed]",
+			}},
+		},
+		{
+			name:         "valid patch",
+			method:       http.MethodPatch,
+			path:         "test.resource/v1/tests/some_test",
+			body:         `{"spec":{"some_info":"def"}}`,
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "invalid merge patch",
+			method:       http.MethodPatch,
+			path:         "test.resource/v1/tests/some_test",
+			body:         `{"spec":{"unknown":1}}`,
+			expectedCode: http.StatusBadRequest,
+			expectedDetails: []router.ValidationCause{{
+				Field:   "spec.unknown",
+				Message: "spec.unknown in body is an unknown field",
+			}},
+		},
+		{
+			name:         "invalid JSON patch",
+			method:       http.MethodPatch,
+			path:         "test.resource/v1/tests/some_test",
+			body:         `[{"op":"replace","path":"/spec/some_info","value":"ABC"}]`,
+			expectedCode: http.StatusBadRequest,
+			expectedDetails: []router.ValidationCause{{
+				Field:   "spec.some_info",
+				Message: "spec.some_info in body should match '^[a-z]+$'",
+			}},
+		},
+		{
+			name:         "valid subresource patch",
+			method:       http.MethodPatch,
+			path:         "test.resource/v1/tests/some_test/status",
+			body:         `{"status":{"status":"failed"}}`,
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "invalid subresource patch",
+			method:       http.MethodPatch,
+			path:         "test.resource/v1/tests/some_test/status",
+			body:         `{"status":{"status":"unknown"}}`,
+			expectedCode: http.StatusBadRequest,
+			expectedDetails: []router.ValidationCause{{
+				Field:   "status.status",
+				Message: "status.status in body should be one of [ok failed]",
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			storeCalls := 0
+			store := fakeStore{
+				getFunc: func(ctx context.Context, kind string, identifier resource.Identifier) (resource.Object, error) {
+					return &Test{
+						BasicMetadataObject: resource.BasicMetadataObject{
+							StaticMeta: resource.StaticMetadata{
+								Name:      "some_test",
+								Namespace: metav1.NamespaceDefault,
+							},
+							CommonMeta: resource.CommonMetadata{
+								ResourceVersion: "1",
+							},
+						},
+						Spec:   TestModel{SomeInfo: "abc"},
+						Status: TestStatus{Status: "ok"},
+					}, nil
+				},
+				addFunc: func(ctx context.Context, obj resource.Object) (resource.Object, error) {
+					storeCalls++
+					return obj, nil
+				},
+				updateFunc: func(ctx context.Context, obj resource.Object) (resource.Object, error) {
+					storeCalls++
+					return obj, nil
+				},
+				updateSubresourceFunc: func(ctx context.Context, kind string, identifier resource.Identifier,
+					subresourceName resource.SubresourceName, obj any) (resource.Object, error) {
+					storeCalls++
+					return &Test{}, nil
+				},
+				patchFunc: func(ctx context.Context, kind string, identifier resource.Identifier,
+					patch resource.PatchRequest) (resource.Object, error) {
+					storeCalls++
+					// The patch is conditional on the validated resourceVersion
+					assert.JSONEq(t, `{"metadata":{"resourceVersion":"1"},"spec":{"some_info":"def"}}`, string(patch.MergePatch))
+					return &Test{}, nil
+				},
+				patchSubresourceFunc: func(ctx context.Context, kind string, identifier resource.Identifier,
+					subresourceName resource.SubresourceName, patch resource.PatchRequest) (resource.Object, error) {
+					storeCalls++
+					assert.JSONEq(t, `{"metadata":{"resourceVersion":"1"},"status":{"status":"failed"}}`, string(patch.MergePatch))
+					return &Test{}, nil
+				},
+			}
+			rgr, err := router.NewResourceGroupRouterWithStore(group, metav1.NamespaceDefault, store,
+				router.WithRequestValidation(router.NewOpenAPISchemaValidator()))
+			require.NoError(t, err)
+
+			err = rgr.CallResource(
+				context.Background(),
+				&backend.CallResourceRequest{
+					Path:   test.path,
+					Method: test.method,
+					Body:   []byte(test.body),
+				},
+				fakeSender{
+					sendFunc: func(response *backend.CallResourceResponse) error {
+						assert.Equal(t, test.expectedCode, response.Status)
+						if test.expectedDetails == nil {
+							return nil
+						}
+						errResponse := router.ResourceErrorResponse{}
+						require.NoError(t, json.Unmarshal(response.Body, &errResponse))
+						assert.Equal(t, router.ResourceErrorReasonInvalid, errResponse.Reason)
+						assert.Equal(t, test.expectedDetails, errResponse.Details)
+						return nil
+					},
+				},
+			)
+			require.NoError(t, err)
+			if test.expectedDetails == nil {
+				assert.Equal(t, 1, storeCalls)
+			} else {
+				assert.Equal(t, 0, storeCalls)
+			}
+		})
+	}
+}
+
+func TestResourceGroupRouter_Admission(t *testing.T) {
+	existing := &Test{
+		BasicMetadataObject: resource.BasicMetadataObject{
+			StaticMeta: resource.StaticMetadata{
+				Name:      "some_test",
+				Namespace: metav1.NamespaceDefault,
+			},
+			CommonMeta: resource.CommonMetadata{
+				ResourceVersion: "1",
+			},
+		},
+		Spec: TestModel{SomeInfo: "existing"},
+	}
+	var written any
+	store := fakeStore{
+		addFunc: func(ctx context.Context, obj resource.Object) (resource.Object, error) {
+			written = obj
+			return obj, nil
+		},
+		getFunc: func(ctx context.Context, kind string, identifier resource.Identifier) (resource.Object, error) {
+			return existing.Copy(), nil
+		},
+		updateFunc: func(ctx context.Context, obj resource.Object) (resource.Object, error) {
+			written = obj
+			return obj, nil
+		},
+		patchFunc: func(ctx context.Context, kind string, identifier resource.Identifier,
+			patch resource.PatchRequest) (resource.Object, error) {
+			written = patch
+			return existing, nil
+		},
+		deleteFunc: func(ctx context.Context, kind string, identifier resource.Identifier) error {
+			written = identifier
+			return nil
+		},
+	}
+	rgr, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, store)
+	require.NoError(t, err)
+	rgr.AddMutatingAdmissionController(&resource.SimpleMutatingAdmissionController{
+		MutateFunc: func(ctx context.Context, request *resource.AdmissionRequest) (*resource.MutatingResponse, error) {
+			if request.Action == resource.AdmissionActionDelete {
+				return nil, nil
+			}
+			obj := request.Object.(*Test)
+			obj.Spec.SomeInfo = strings.ToLower(obj.Spec.SomeInfo)
+			return &resource.MutatingResponse{UpdatedObject: obj}, nil
+		},
+	}, testResource)
+	rgr.AddValidatingAdmissionController(&resource.SimpleValidatingAdmissionController{
+		ValidateFunc: func(ctx context.Context, request *resource.AdmissionRequest) error {
+			assert.Equal(t, "admin", request.UserInfo.Username)
+			assert.Equal(t, "Admin", request.UserInfo.Extra["role"])
+			assert.Equal(t, "1", request.UserInfo.Extra["orgID"])
+			if request.Action == resource.AdmissionActionDelete {
+				return fmt.Errorf("tests cannot be deleted")
+			}
+			if request.Object.(*Test).Spec.SomeInfo == "" {
+				return fmt.Errorf("some_info is required")
+			}
+			return nil
+		},
+	}, testResource)
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		body            string
+		expectedCode    int
+		expectedError   string
+		expectedWritten any
+	}{
+		{
+			name:         "create mutated",
+			method:       http.MethodPost,
+			path:         "test.resource/v1/tests",
+			body:         `{"staticMetadata":{"name":"some_test"},"spec":{"some_info":"ABC"}}`,
+			expectedCode: http.StatusAccepted,
+			expectedWritten: TestModel{
+				SomeInfo: "abc",
+			},
+		},
+		{
+			name:          "create denied",
+			method:        http.MethodPost,
+			path:          "test.resource/v1/tests",
+			body:          `{"staticMetadata":{"name":"some_test"},"spec":{}}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "admission denied the request: some_info is required",
+		},
+		{
+			name:         "update mutated",
+			method:       http.MethodPut,
+			path:         "test.resource/v1/tests/some_test",
+			body:         `{"staticMetadata":{"name":"some_test"},"spec":{"some_info":"DEF"}}`,
+			expectedCode: http.StatusAccepted,
+			expectedWritten: TestModel{
+				SomeInfo: "def",
+			},
+		},
+		{
+			name:         "patch mutated",
+			method:       http.MethodPatch,
+			path:         "test.resource/v1/tests/some_test",
+			body:         `{"spec":{"some_info":"GHI"}}`,
+			expectedCode: http.StatusAccepted,
+			expectedWritten: resource.PatchRequest{
+				Type: resource.PatchTypeJSONPatch,
+				Operations: []resource.PatchOperation{{
+					Operation: resource.PatchOpTest,
+					Path:      "/metadata/resourceVersion",
+					Value:     "1",
+				}, {
+					Operation: resource.PatchOpReplace,
+					Path:      "/spec/some_info",
+					Value:     "ghi",
+				}},
+			},
+		},
+		{
+			name:          "delete denied",
+			method:        http.MethodDelete,
+			path:          "test.resource/v1/tests/some_test",
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "admission denied the request: tests cannot be deleted",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			written = nil
+			err := rgr.CallResource(
+				context.Background(),
+				&backend.CallResourceRequest{
+					PluginContext: backend.PluginContext{
+						OrgID: 1,
+						User: &backend.User{
+							Login: "admin",
+							Role:  "Admin",
+						},
+					},
+					Path:   test.path,
+					Method: test.method,
+					Body:   []byte(test.body),
+				},
+				fakeSender{
+					sendFunc: func(response *backend.CallResourceResponse) error {
+						assert.Equal(t, test.expectedCode, response.Status)
+						if test.expectedError == "" {
+							return nil
+						}
+						errResponse := router.ResourceErrorResponse{}
+						require.NoError(t, json.Unmarshal(response.Body, &errResponse))
+						assert.Equal(t, test.expectedError, errResponse.Error)
+						return nil
+					},
+				},
+			)
+			require.NoError(t, err)
+			if obj, ok := written.(*Test); ok {
+				assert.Equal(t, test.expectedWritten, obj.Spec)
+			} else {
+				assert.Equal(t, test.expectedWritten, written)
+			}
+		})
+	}
+}
+
+func TestResourceGroupRouter_Patch(t *testing.T) {
+	tests := []struct {
+		name          string
+		contentType   string
+		body          string
+		expectedPatch resource.PatchRequest
+		expectedCode  int
+	}{
+		{
+			name:        "JSON patch",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"replace","path":"/spec/some_info","value":"new"}]`,
+			expectedPatch: resource.PatchRequest{
+				Type: resource.PatchTypeJSONPatch,
+				Operations: []resource.PatchOperation{{
+					Operation: resource.PatchOpReplace,
+					Path:      "/spec/some_info",
+					Value:     "new",
+				}},
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name: "JSON patch without content type",
+			body: `[{"op":"remove","path":"/spec/some_info"}]`,
+			expectedPatch: resource.PatchRequest{
+				Type: resource.PatchTypeJSONPatch,
+				Operations: []resource.PatchOperation{{
+					Operation: resource.PatchOpRemove,
+					Path:      "/spec/some_info",
+				}},
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:        "merge patch",
+			contentType: "application/merge-patch+json; charset=utf-8",
+			body:        `{"spec":{"some_info":"new"}}`,
+			expectedPatch: resource.PatchRequest{
+				Type:       resource.PatchTypeMergePatch,
+				MergePatch: []byte(`{"spec":{"some_info":"new"}}`),
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "invalid merge patch",
+			contentType:  "application/merge-patch+json",
+			body:         `["foo"]`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "empty body",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			patched := testResource.ZeroValue().(*Test)
+			router, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
+				patchFunc: func(ctx context.Context, kind string, identifier resource.Identifier,
+					patch resource.PatchRequest) (resource.Object, error) {
+					assert.Equal(t, "Test", kind)
+					assert.Equal(t, resource.Identifier{Namespace: metav1.NamespaceDefault, Name: "some_test"}, identifier)
+					assert.Equal(t, test.expectedPatch, patch)
+					return patched, nil
+				},
+			})
+			require.NoError(t, err)
+
+			err = router.CallResource(
+				context.Background(),
+				&backend.CallResourceRequest{
+					Path:    "test.resource/v1/tests/some_test",
+					Method:  http.MethodPatch,
+					Headers: map[string][]string{"Content-Type": {test.contentType}},
+					Body:    []byte(test.body),
+				},
+				fakeSender{
+					sendFunc: func(response *backend.CallResourceResponse) error {
+						assert.Equal(t, test.expectedCode, response.Status)
+						return nil
+					},
+				},
+			)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestResourceGroupRouter_Subresources(t *testing.T) {
+	existing := testResource.ZeroValue().(*Test)
+	existing.Spec.SomeInfo = "info"
+	existing.Status.Status = "ok"
+
+	t.Run("get", func(t *testing.T) {
+		router, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
+			getFunc: func(ctx context.Context, kind string, identifier resource.Identifier) (resource.Object, error) {
+				assert.Equal(t, "some_test", identifier.Name)
+				return existing, nil
+			},
+		})
+		require.NoError(t, err)
+
+		err = router.CallResource(
+			context.Background(),
+			&backend.CallResourceRequest{
+				Path:   "test.resource/v1/tests/some_test/status",
+				Method: http.MethodGet,
+			},
+			fakeSender{
+				sendFunc: func(response *backend.CallResourceResponse) error {
+					assert.Equal(t, http.StatusOK, response.Status)
+					assert.JSONEq(t, `{"status":"ok"}`, string(response.Body))
+					return nil
+				},
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		router, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
+			updateSubresourceFunc: func(ctx context.Context, kind string, identifier resource.Identifier,
+				subresourceName resource.SubresourceName, obj any) (resource.Object, error) {
+				assert.Equal(t, "Test", kind)
+				assert.Equal(t, "some_test", identifier.Name)
+				assert.Equal(t, resource.SubresourceStatus, subresourceName)
+				assert.Equal(t, &TestStatus{Status: "updated"}, obj)
+				return existing, nil
+			},
+		})
+		require.NoError(t, err)
+
+		err = router.CallResource(
+			context.Background(),
+			&backend.CallResourceRequest{
+				Path:   "test.resource/v1/tests/some_test/status",
+				Method: http.MethodPut,
+				Body:   []byte(`{"status":"updated"}`),
+			},
+			fakeSender{
+				sendFunc: func(response *backend.CallResourceResponse) error {
+					assert.Equal(t, http.StatusAccepted, response.Status)
+					return nil
+				},
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("update with invalid body", func(t *testing.T) {
+		router, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{})
+		require.NoError(t, err)
+
+		err = router.CallResource(
+			context.Background(),
+			&backend.CallResourceRequest{
+				Path:   "test.resource/v1/tests/some_test/status",
+				Method: http.MethodPut,
+				Body:   []byte(`{"status":1}`),
+			},
+			fakeSender{
+				sendFunc: func(response *backend.CallResourceResponse) error {
+					assert.Equal(t, http.StatusBadRequest, response.Status)
+					return nil
+				},
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		router, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
+			patchFunc: func(ctx context.Context, kind string, identifier resource.Identifier,
+				patch resource.PatchRequest) (resource.Object, error) {
+				assert.Fail(t, "subresource patch should not patch the main resource")
+				return nil, nil
+			},
+			patchSubresourceFunc: func(ctx context.Context, kind string, identifier resource.Identifier,
+				subresourceName resource.SubresourceName, patch resource.PatchRequest) (resource.Object, error) {
+				assert.Equal(t, resource.SubresourceStatus, subresourceName)
+				assert.Equal(t, resource.PatchTypeMergePatch, patch.Type)
+				return existing, nil
+			},
+		})
+		require.NoError(t, err)
+
+		err = router.CallResource(
+			context.Background(),
+			&backend.CallResourceRequest{
+				Path:   "test.resource/v1/tests/some_test/status",
+				Method: http.MethodPatch,
+				Body:   []byte(`{"status":{"status":"patched"}}`),
+			},
+			fakeSender{
+				sendFunc: func(response *backend.CallResourceResponse) error {
+					assert.Equal(t, http.StatusAccepted, response.Status)
+					return nil
+				},
+			},
+		)
+		require.NoError(t, err)
+	})
+}
+
+func TestResourceGroupRouter_SimpleObjectSubresources(t *testing.T) {
+	existing := &resource.SimpleObject[map[string]any]{
+		BasicMetadataObject: resource.BasicMetadataObject{
+			StaticMeta: resource.StaticMetadata{Name: "some_test"},
+		},
+		SubresourceMap: map[string]any{
+			"status": json.RawMessage(`{"status":"ok"}`),
+			"scale":  json.RawMessage(`{"replicas":2}`),
+		},
+	}
+	store := fakeStore{
+		getFunc: func(ctx context.Context, kind string, identifier resource.Identifier) (resource.Object, error) {
+			return existing, nil
+		},
+		updateSubresourceFunc: func(ctx context.Context, kind string, identifier resource.Identifier,
+			subresourceName resource.SubresourceName, obj any) (resource.Object, error) {
+			assert.Equal(t, resource.SubresourceStatus, subresourceName)
+			// There is no type for the subresource, so the body is passed to the store as-is
+			assert.Equal(t, &json.RawMessage{'{', '}'}, obj)
+			return existing, nil
+		},
+	}
+	group := resource.NewSimpleSchemaGroup("test.resource", "v1")
+	// The subresources of a SimpleObject are only known once it has been unmarshaled,
+	// so they are taken from the OpenAPI schema, or added with WithSubresources
+	group.AddSchema(&resource.SimpleObject[map[string]any]{}, resource.WithKind("FromSchema"),
+		resource.WithPlural("fromschemas"), resource.WithOpenAPISchema(validationOpenAPISchema))
+	fromOption := group.AddSchema(&resource.SimpleObject[map[string]any]{}, resource.WithKind("FromOption"),
+		resource.WithPlural("fromoptions"))
+	rgr, err := router.NewResourceGroupRouterWithStore(group, metav1.NamespaceDefault, store,
+		router.WithSubresources(fromOption, "status", "scale"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "get from schema",
+			method:       http.MethodGet,
+			path:         "test.resource/v1/fromschemas/some_test/status",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":"ok"}`,
+		},
+		{
+			name:         "update from schema",
+			method:       http.MethodPut,
+			path:         "test.resource/v1/fromschemas/some_test/status",
+			body:         `{}`,
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "not in schema",
+			method:       http.MethodGet,
+			path:         "test.resource/v1/fromschemas/some_test/scale",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "get from option",
+			method:       http.MethodGet,
+			path:         "test.resource/v1/fromoptions/some_test/scale",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"replicas":2}`,
+		},
+		{
+			name:         "update from option",
+			method:       http.MethodPut,
+			path:         "test.resource/v1/fromoptions/some_test/status",
+			body:         `{}`,
+			expectedCode: http.StatusAccepted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := rgr.CallResource(
+				context.Background(),
+				&backend.CallResourceRequest{
+					Path:   test.path,
+					Method: test.method,
+					Body:   []byte(test.body),
+				},
+				fakeSender{
+					sendFunc: func(response *backend.CallResourceResponse) error {
+						assert.Equal(t, test.expectedCode, response.Status)
+						if test.expectedBody != "" {
+							assert.JSONEq(t, test.expectedBody, string(response.Body))
+						}
+						return nil
+					},
+				},
+			)
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestResourceGroupRouter_Delete(t *testing.T) {
 	t.Run("returns error as store returns error", func(t *testing.T) {
 		router, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{
@@ -469,3 +1461,369 @@ func TestResourceGroupRouter_Delete(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+type fakeWatchStore struct {
+	fakeStore
+	watchFunc func(ctx context.Context, kind, namespace string, options resource.WatchOptions) (resource.WatchResponse, error)
+}
+
+func (s fakeWatchStore) Watch(ctx context.Context, kind, namespace string, options resource.WatchOptions) (
+	resource.WatchResponse, error) {
+	if s.watchFunc != nil {
+		return s.watchFunc(ctx, kind, namespace, options)
+	}
+	return nil, fmt.Errorf("not implemented")
+}
+
+type fakeWatchResponse struct {
+	events  chan resource.WatchEvent
+	stopped bool
+}
+
+func (w *fakeWatchResponse) Stop() {
+	w.stopped = true
+}
+
+func (w *fakeWatchResponse) WatchEvents() <-chan resource.WatchEvent {
+	return w.events
+}
+
+func TestResourceGroupRouter_Watch(t *testing.T) {
+	t.Run("store without watch", func(t *testing.T) {
+		rgr, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeStore{})
+		require.NoError(t, err)
+
+		err = rgr.CallResource(
+			context.Background(),
+			&backend.CallResourceRequest{
+				Path:   "test.resource/v1/watch/tests",
+				URL:    "test.resource/v1/watch/tests",
+				Method: http.MethodGet,
+			},
+			fakeSender{
+				sendFunc: func(response *backend.CallResourceResponse) error {
+					assert.Equal(t, http.StatusNotImplemented, response.Status)
+					return nil
+				},
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("store watch error", func(t *testing.T) {
+		rgr, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeWatchStore{
+			watchFunc: func(ctx context.Context, kind, namespace string, options resource.WatchOptions) (
+				resource.WatchResponse, error) {
+				return nil, testServerResponseError{statusCode: http.StatusGone}
+			},
+		})
+		require.NoError(t, err)
+
+		err = rgr.CallResource(
+			context.Background(),
+			&backend.CallResourceRequest{
+				Path:   "test.resource/v1/watch/tests",
+				URL:    "test.resource/v1/watch/tests?resourceVersion=1",
+				Method: http.MethodGet,
+			},
+			fakeSender{
+				sendFunc: func(response *backend.CallResourceResponse) error {
+					assert.Equal(t, http.StatusGone, response.Status)
+					return nil
+				},
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	for _, test := range []struct {
+		name       string
+		url        string
+		headers    map[string][]string
+		expectedRV string
+	}{
+		{
+			name: "new watch",
+			url:  "test.resource/v1/watch/tests?labelSelector=foo%3Dbar",
+		},
+		{
+			name:       "resume from resourceVersion",
+			url:        "test.resource/v1/watch/tests?labelSelector=foo%3Dbar&resourceVersion=5",
+			expectedRV: "5",
+		},
+		{
+			name:       "resume from Last-Event-ID",
+			url:        "test.resource/v1/watch/tests?labelSelector=foo%3Dbar",
+			headers:    map[string][]string{"Last-Event-Id": {"6"}},
+			expectedRV: "6",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			watch := &fakeWatchResponse{
+				events: make(chan resource.WatchEvent, 2),
+			}
+			obj := testResource.ZeroValue().(*Test)
+			obj.SetStaticMetadata(resource.StaticMetadata{Name: "some_test"})
+			obj.SetCommonMetadata(resource.CommonMetadata{ResourceVersion: "7"})
+			watch.events <- resource.WatchEvent{EventType: "ADDED", Object: obj}
+			watch.events <- resource.WatchEvent{EventType: "DELETED", Object: obj}
+			close(watch.events)
+
+			rgr, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeWatchStore{
+				watchFunc: func(ctx context.Context, kind, namespace string, options resource.WatchOptions) (
+					resource.WatchResponse, error) {
+					assert.Equal(t, "Test", kind)
+					assert.Equal(t, metav1.NamespaceDefault, namespace)
+					assert.Equal(t, resource.WatchOptions{
+						ResourceVersion: test.expectedRV,
+						LabelFilters:    []string{"foo=bar"},
+					}, options)
+					return watch, nil
+				},
+			})
+			require.NoError(t, err)
+
+			responses := make([]*backend.CallResourceResponse, 0)
+			err = rgr.CallResource(
+				context.Background(),
+				&backend.CallResourceRequest{
+					Path:    "test.resource/v1/watch/tests",
+					URL:     test.url,
+					Method:  http.MethodGet,
+					Headers: test.headers,
+				},
+				fakeSender{
+					sendFunc: func(response *backend.CallResourceResponse) error {
+						responses = append(responses, response)
+						return nil
+					},
+				},
+			)
+			require.NoError(t, err)
+			assert.True(t, watch.stopped)
+
+			require.Len(t, responses, 3)
+			assert.Equal(t, http.StatusOK, responses[0].Status)
+			assert.Equal(t, []string{router.ContentTypeEventStream}, responses[0].Headers["Content-Type"])
+			objJSON, err := json.Marshal(obj)
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("id: 7\nevent: ADDED\ndata: {\"type\":\"ADDED\",\"object\":%s}\n\n", objJSON),
+				string(responses[1].Body))
+			assert.Equal(t, fmt.Sprintf("id: 7\nevent: DELETED\ndata: {\"type\":\"DELETED\",\"object\":%s}\n\n", objJSON),
+				string(responses[2].Body))
+		})
+	}
+
+	t.Run("canceled request", func(t *testing.T) {
+		watch := &fakeWatchResponse{
+			events: make(chan resource.WatchEvent),
+		}
+		rgr, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, fakeWatchStore{
+			watchFunc: func(ctx context.Context, kind, namespace string, options resource.WatchOptions) (
+				resource.WatchResponse, error) {
+				return watch, nil
+			},
+		})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		err = rgr.CallResource(
+			ctx,
+			&backend.CallResourceRequest{
+				Path:   "test.resource/v1/watch/tests",
+				URL:    "test.resource/v1/watch/tests",
+				Method: http.MethodGet,
+			},
+			fakeSender{
+				sendFunc: func(response *backend.CallResourceResponse) error {
+					// Cancel the request once the stream has started
+					cancel()
+					return nil
+				},
+			},
+		)
+		require.NoError(t, err)
+		assert.True(t, watch.stopped)
+	})
+}
+
+func TestResourceGroupRouter_Authorization(t *testing.T) {
+	store := fakeStore{
+		getFunc: func(ctx context.Context, kind string, identifier resource.Identifier) (resource.Object, error) {
+			return &Test{}, nil
+		},
+		deleteFunc: func(ctx context.Context, kind string, identifier resource.Identifier) error {
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		policy         router.AuthorizationPolicy
+		method         string
+		user           *backend.User
+		expectedCode   int
+		expectedReason router.ResourceErrorReason
+	}{
+		{
+			name:         "default policy viewer read",
+			method:       http.MethodGet,
+			user:         &backend.User{Login: "viewer", Role: router.RoleViewer},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:           "default policy viewer write",
+			method:         http.MethodDelete,
+			user:           &backend.User{Login: "viewer", Role: router.RoleViewer},
+			expectedCode:   http.StatusForbidden,
+			expectedReason: router.ResourceErrorReasonForbidden,
+		},
+		{
+			name:         "default policy editor write",
+			method:       http.MethodDelete,
+			user:         &backend.User{Login: "editor", Role: router.RoleEditor},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:           "default policy no user",
+			method:         http.MethodGet,
+			expectedCode:   http.StatusUnauthorized,
+			expectedReason: router.ResourceErrorReasonUnauthorized,
+		},
+		{
+			name:           "custom policy",
+			policy:         router.RequireRole(router.RoleAdmin),
+			method:         http.MethodGet,
+			user:           &backend.User{Login: "editor", Role: router.RoleEditor},
+			expectedCode:   http.StatusForbidden,
+			expectedReason: router.ResourceErrorReasonForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rgr, err := router.NewResourceGroupRouterWithStore(testResourceGroup, metav1.NamespaceDefault, store,
+				router.WithAuthorization(test.policy))
+			require.NoError(t, err)
+
+			err = rgr.CallResource(
+				context.Background(),
+				&backend.CallResourceRequest{
+					PluginContext: backend.PluginContext{
+						User: test.user,
+					},
+					Path:   "test.resource/v1/tests/some_test",
+					Method: test.method,
+				},
+				fakeSender{
+					sendFunc: func(response *backend.CallResourceResponse) error {
+						assert.Equal(t, test.expectedCode, response.Status)
+						if test.expectedReason == "" {
+							return nil
+						}
+						errResponse := router.ResourceErrorResponse{}
+						require.NoError(t, json.Unmarshal(response.Body, &errResponse))
+						assert.Equal(t, test.expectedReason, errResponse.Reason)
+						return nil
+					},
+				},
+			)
+			require.NoError(t, err)
+		})
+	}
+}
+
+type tenantCtxKey struct{}
+
+func TestResourceGroupRouter_StoreResolver(t *testing.T) {
+	t.Run("nil resolver", func(t *testing.T) {
+		_, err := router.NewResourceGroupRouterWithStoreResolver(testResourceGroup, nil)
+		assert.Equal(t, errors.New("resolver cannot be nil"), err)
+	})
+
+	stores := map[string]fakeStore{}
+	for _, tenant := range []string{"org-1", "org-2"} {
+		tenant := tenant
+		stores[tenant] = fakeStore{
+			getFunc: func(ctx context.Context, kind string, identifier resource.Identifier) (resource.Object, error) {
+				// Each tenant's store must only be used for that tenant's namespace
+				require.Equal(t, tenant, identifier.Namespace)
+				test := testResource.ZeroValue().(*Test)
+				test.SetStaticMetadata(resource.StaticMetadata{
+					Name:      identifier.Name,
+					Namespace: identifier.Namespace,
+				})
+				return test, nil
+			},
+		}
+	}
+	rgr, err := router.NewResourceGroupRouterWithStoreResolver(testResourceGroup,
+		func(ctx context.Context) (string, router.Store, error) {
+			tenant, _ := ctx.Value(tenantCtxKey{}).(string)
+			switch tenant {
+			case "":
+				return "", nil, errors.New("no tenant")
+			case "forbidden":
+				return "", nil, plugin.NewError(http.StatusForbidden, "forbidden")
+			}
+			return tenant, stores[tenant], nil
+		})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		tenant            string
+		expectedStatus    int
+		expectedNamespace string
+	}{
+		{
+			name:           "resolver error",
+			tenant:         "",
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "resolver plugin error",
+			tenant:         "forbidden",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:              "first tenant",
+			tenant:            "org-1",
+			expectedStatus:    http.StatusOK,
+			expectedNamespace: "org-1",
+		},
+		{
+			name:              "second tenant",
+			tenant:            "org-2",
+			expectedStatus:    http.StatusOK,
+			expectedNamespace: "org-2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var response *backend.CallResourceResponse
+			err := rgr.CallResource(
+				context.WithValue(context.Background(), tenantCtxKey{}, test.tenant),
+				&backend.CallResourceRequest{
+					Path:   "test.resource/v1/tests/some_test",
+					Method: http.MethodGet,
+				},
+				fakeSender{
+					sendFunc: func(r *backend.CallResourceResponse) error {
+						response = r
+						return nil
+					},
+				},
+			)
+			require.NoError(t, err)
+			require.NotNil(t, response)
+			assert.Equal(t, test.expectedStatus, response.Status)
+			if test.expectedNamespace != "" {
+				obj := Test{}
+				require.NoError(t, json.Unmarshal(response.Body, &obj))
+				assert.Equal(t, test.expectedNamespace, obj.StaticMetadata().Namespace)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana-app-sdk/logging"
+	"github.com/grafana/grafana-app-sdk/plugin"
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+const (
+	// ContentTypeEventStream is the content-type header value for server-sent events.
+	ContentTypeEventStream = "text/event-stream"
+)
+
+// WatchStore is a Store which can also watch resources.
+// The watch routes of a ResourceGroupRouter require the Store resolved for the request to implement WatchStore,
+// and fail with a 501 Not Implemented otherwise. resource.Store implements WatchStore.
+type WatchStore interface {
+	Store
+	Watch(ctx context.Context, kind, namespace string, options resource.WatchOptions) (resource.WatchResponse, error)
+}
+
+// ResourceWatchEvent is the data of each server-sent event sent by the watch routes of a ResourceGroupRouter.
+type ResourceWatchEvent struct {
+	// Type is the type of the event, such as ADDED, MODIFIED, or DELETED
+	Type string `json:"type"`
+	// Object is the affected object
+	Object resource.Object `json:"object"`
+}
+
+// watchResources streams watch events for resources of the kind as server-sent events, until the request is canceled.
+// Each event has the event type as its name, a ResourceWatchEvent as its data,
+// and the resourceVersion of the object as its ID, so the watch can be resumed
+// with the `resourceVersion` query parameter or the Last-Event-ID header (which EventSource sets when it reconnects).
+// Events are filtered by the `labelSelector` query parameter, if present.
+func (router *ResourceGroupRouter) watchResources(cr resource.Schema) HandlerFunc {
+	return func(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) {
+		namespace, store, err := router.resolve(ctx)
+		if err != nil {
+			router.sendErr(ctx, sender, err)
+			return
+		}
+		if admitting, ok := store.(*admittingStore); ok {
+			store = admitting.Store
+		}
+		watcher, ok := store.(WatchStore)
+		if !ok {
+			router.sendErr(ctx, sender, plugin.NewError(http.StatusNotImplemented, "store does not support watch"))
+			return
+		}
+
+		u, err := url.Parse(req.URL)
+		if err != nil {
+			router.sendErr(ctx, sender, plugin.WrapError(http.StatusBadRequest, err))
+			return
+		}
+		query := u.Query()
+		options := resource.WatchOptions{
+			ResourceVersion: query.Get("resourceVersion"),
+		}
+		if options.ResourceVersion == "" {
+			options.ResourceVersion = http.Header(req.Headers).Get("Last-Event-ID")
+		}
+		if selector := query.Get("labelSelector"); selector != "" {
+			options.LabelFilters = []string{selector}
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		watch, err := watcher.Watch(ctx, cr.Kind(), namespace, options)
+		if err != nil {
+			router.sendErr(ctx, sender, storeError(err))
+			return
+		}
+		defer watch.Stop()
+
+		// The first response sets the status and headers of the stream, and each subsequent response is a chunk of the body
+		if err := sender.Send(&backend.CallResourceResponse{
+			Status: http.StatusOK,
+			Headers: map[string][]string{
+				HeaderContentType: {ContentTypeEventStream},
+				"Cache-Control":   {"no-cache"},
+			},
+		}); err != nil {
+			logging.FromContext(ctx).Error("error sending backend response", "error", err)
+			return
+		}
+
+		events := watch.WatchEvents()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt, ok := <-events:
+				if !ok {
+					return
+				}
+				body, err := encodeWatchEvent(evt)
+				if err != nil {
+					logging.FromContext(ctx).Error("error encoding watch event", "error", err)
+					continue
+				}
+				if err := sender.Send(&backend.CallResourceResponse{
+					Body: body,
+				}); err != nil {
+					// The client has most likely gone away
+					logging.FromContext(ctx).Debug("error sending watch event, stopping watch", "error", err)
+					return
+				}
+			}
+		}
+	}
+}
+
+// encodeWatchEvent encodes a WatchEvent as a server-sent event
+func encodeWatchEvent(evt resource.WatchEvent) ([]byte, error) {
+	data, err := json.Marshal(ResourceWatchEvent{
+		Type:   evt.EventType,
+		Object: evt.Object,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if evt.Object != nil && evt.Object.CommonMetadata().ResourceVersion != "" {
+		fmt.Fprintf(buf, "id: %s\n", evt.Object.CommonMetadata().ResourceVersion)
+	}
+	fmt.Fprintf(buf, "event: %s\ndata: %s\n\n", evt.EventType, data)
+	return buf.Bytes(), nil
+}
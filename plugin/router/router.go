@@ -5,9 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"path/filepath"
+	pathpkg "path"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 )
@@ -26,12 +27,30 @@ var DefaultNotFoundHandler HandlerFunc = func(
 	})
 }
 
+// DefaultMethodNotAllowedHandler is the handler that is used for handling requests when the path matches
+// one or more routes, but none of them handle the request method. It responds with a 405 Method Not Allowed,
+// and an Allow header listing the methods that are handled (see AllowedMethodsFromContext).
+// This can be overridden in the Router.
+var DefaultMethodNotAllowedHandler HandlerFunc = func(
+	ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender,
+) {
+	_ = sender.Send(&backend.CallResourceResponse{
+		Status: http.StatusMethodNotAllowed,
+		Headers: map[string][]string{
+			"Allow": {strings.Join(AllowedMethodsFromContext(ctx), ", ")},
+		},
+		Body: []byte(fmt.Sprintf("method %s not allowed for path %s", req.Method, req.Path)),
+	})
+}
+
 // NewRouter returns a new Router
 func NewRouter() *Router {
 	return &Router{
-		NotFoundHandler: DefaultNotFoundHandler,
-		subrouters:      make([]*Subrouter, 0),
-		routes:          make([]*RouteHandler, 0),
+		NotFoundHandler:         DefaultNotFoundHandler,
+		MethodNotAllowedHandler: DefaultMethodNotAllowedHandler,
+		subrouters:              make([]*Subrouter, 0),
+		routes:                  make([]*RouteHandler, 0),
+		tree:                    &routeTree{},
 	}
 }
 
@@ -46,10 +65,20 @@ type HandlerFunc func(context.Context, *backend.CallResourceRequest, backend.Cal
 // Router is a simple request router specific to the grafana plugin SDK backend.CallResourceRequest HTTP calls.
 // It allows the user to treat the grafana plugin backend as a traditional HTTP server,
 // registering routes and using path parameters as normal.
+//
+// Routes are matched with a radix tree, so the time to match a request doesn't grow with the number of routes.
+// When more than one route matches a path, static path segments take precedence over path variables
+// (so a route for `foo/bar` is matched before a route for `foo/{name}`, regardless of the order they were added in).
+// Registering a route which would match the same requests as an existing route (the same method and path,
+// or a path which only differs in the names of path variables) panics.
 type Router struct {
 	// Handler called when there's no route match.
 	NotFoundHandler HandlerFunc
 
+	// Handler called when there's a route which matches the path, but not the method.
+	// If nil, NotFoundHandler is called instead.
+	MethodNotAllowedHandler HandlerFunc
+
 	// Nested routers with a matching path.
 	subrouters []*Subrouter
 
@@ -58,55 +87,44 @@ type Router struct {
 
 	// Slice of middlewares to be called after a match is found.
 	middlewares []middleware
+
+	// Schemas added to the components of the Router's OpenAPI document.
+	openAPISchemas map[string]map[string]any
+
+	// parent is the router this router is a subrouter of, nil if the router is not a subrouter
+	parent *Router
+
+	// pattern is the user-provided path prefix of the router relative to its parent, if it is a subrouter
+	pattern string
+
+	// tree is used to match requests to the routes registered with the router and all of its subrouters
+	tree *routeTree
 }
 
 // Subrouter is a slightly-extended router
 // meant for being registered a with Subrouter() on either a Router or Subrouter.
 type Subrouter struct {
 	Router
-	matcher      *regexp.Regexp
-	pathArgNames []string
-	path         string
 }
 
 // Subrouter creates and returns a Subrouter for the given path prefix.
 // All handlers registered with the Subrouter will have the prefix added implicitly.
+// It panics if the path has an invalid match expression for a path variable.
 func (r *Router) Subrouter(path string) *Subrouter {
-	pathArgNames := make([]string, 0)
-	// Look for path replacement vars ({...})
-	matches := replArgRegex.FindAllStringSubmatch(path, -1)
-	if len(matches) > 0 {
-		// Check each match, replace it with regex, and add the match to our list of pathArgNames
-		for _, match := range matches {
-			if len(match) != 3 {
-				continue
-			}
-			// Element 0 is the whole matched string which needs replacing in the path
-			// Element 1 is the variable name
-			// Element 2 is the match expression (may be empty, in which case it should be `([^\/]+)`)
-			repl := `([^\/]+)`
-			if match[2] != "" {
-				repl = fmt.Sprintf("(%s)", match[2])
-			}
-			path = strings.ReplaceAll(path, match[0], repl)
-			pathArgNames = append(pathArgNames, match[1])
-		}
-	}
-	regex, err := regexp.Compile(fmt.Sprintf(`^%s`, path))
-	if err != nil {
-		// TODO
-		return nil
+	if _, err := parsePath(path); err != nil {
+		panic(fmt.Errorf("invalid subrouter path '%s': %w", path, err))
 	}
 
 	sr := &Subrouter{
 		Router: Router{
-			NotFoundHandler: r.NotFoundHandler,
-			subrouters:      make([]*Subrouter, 0),
-			routes:          make([]*RouteHandler, 0),
+			NotFoundHandler:         r.NotFoundHandler,
+			MethodNotAllowedHandler: r.MethodNotAllowedHandler,
+			subrouters:              make([]*Subrouter, 0),
+			routes:                  make([]*RouteHandler, 0),
+			parent:                  r,
+			pattern:                 path,
+			tree:                    &routeTree{},
 		},
-		pathArgNames: pathArgNames,
-		matcher:      regex,
-		path:         path,
 	}
 
 	r.subrouters = append(r.subrouters, sr)
@@ -114,6 +132,8 @@ func (r *Router) Subrouter(path string) *Subrouter {
 }
 
 // Handle registers a handler to a given path and method(s). If no method(s) are specified, GET is implicitly used.
+// It panics if the path has an invalid match expression for a path variable,
+// or if the route conflicts with an existing route of the router, its subrouters, or its parent routers.
 func (r *Router) Handle(path string, handler HandlerFunc, methods ...string) *RouteHandler {
 	providedPath := path
 	// Normalize empty path to root.
@@ -121,32 +141,6 @@ func (r *Router) Handle(path string, handler HandlerFunc, methods ...string) *Ro
 		path = "/"
 	}
 
-	pathArgNames := make([]string, 0)
-	// Look for path replacement vars ({...})
-	matches := replArgRegex.FindAllStringSubmatch(path, -1)
-	if len(matches) > 0 {
-		// Check each match, replace it with regex, and add the match to our list of pathArgNames
-		for _, match := range matches {
-			if len(match) != 3 {
-				continue
-			}
-			// Element 0 is the whole matched string which needs replacing in the path
-			// Element 1 is the variable name
-			// Element 2 is the match expression (may be empty, in which case it should be `([^\/]+)`)
-			repl := `([^\/]+)`
-			if match[2] != "" {
-				repl = fmt.Sprintf("(%s)", match[2])
-			}
-			path = strings.ReplaceAll(path, match[0], repl)
-			pathArgNames = append(pathArgNames, match[1])
-		}
-	}
-	regex, err := regexp.Compile(fmt.Sprintf(`^%s$`, path))
-	if err != nil {
-		// TODO
-		return nil
-	}
-
 	// Methods
 	m := make(map[string]struct{})
 	if len(methods) == 0 {
@@ -157,12 +151,39 @@ func (r *Router) Handle(path string, handler HandlerFunc, methods ...string) *Ro
 	}
 
 	h := &RouteHandler{
-		matcher:      regex,
-		handleFunc:   handler,
-		pathArgNames: pathArgNames,
-		methods:      m,
-		path:         providedPath,
+		handleFunc: handler,
+		methods:    m,
+		path:       providedPath,
+	}
+
+	// Add the route to the tree of this router, and the trees of all parent routers (with their path prefixes),
+	// so that it can be matched by any of them
+	entries := make([]*routeEntry, 0)
+	routePath := providedPath
+	for router := r; router != nil; router = router.parent {
+		tokens, err := parsePath(path)
+		if err != nil {
+			panic(fmt.Errorf("invalid route path '%s': %w", providedPath, err))
+		}
+		entries = append(entries, &routeEntry{
+			tree:   router.getTree(),
+			route:  h,
+			owner:  r,
+			path:   routePath,
+			tokens: tokens,
+		})
+		path = router.pattern + path
+		routePath = pathpkg.Join(router.pattern, routePath)
+	}
+	for _, entry := range entries {
+		if err := entry.tree.conflict(entry); err != nil {
+			panic(err)
+		}
 	}
+	for _, entry := range entries {
+		entry.tree.insert(entry)
+	}
+	h.entries = entries
 
 	r.routes = append(r.routes, h)
 
@@ -180,69 +201,50 @@ func (r *Router) RouteByName(name string) *RouteHandler {
 	return nil
 }
 
-//nolint:lll
-func (r *Router) getHandler(ctx context.Context, path string, method string, matchedPath string, applyMiddlewares ...middleware) (context.Context, HandlerFunc) {
-	// Check subrouters
-	for _, h := range r.subrouters {
-		if matches := h.matcher.FindStringSubmatch(path); len(matches) > 0 {
-			for i := 1; i < len(matches); i++ {
-				if i > len(h.pathArgNames) {
-					break
-				}
-
-				ctx = CtxWithVar(ctx, h.pathArgNames[i-1], matches[i])
-			}
-
-			// Matched path
-			mPath := h.path
-			if matchedPath != "" {
-				mPath = filepath.Join(matchedPath, mPath)
-			}
-			return h.getHandler(ctx, path[len(matches[0]):], method, mPath, append(applyMiddlewares, r.middlewares...)...)
-		}
+func (r *Router) getTree() *routeTree {
+	if r.tree == nil {
+		r.tree = &routeTree{}
 	}
+	return r.tree
+}
 
-	// Look for a matching handler
-	for _, routeHandler := range r.routes {
-		if _, ok := routeHandler.methods[method]; !ok {
-			continue
+// getHandler returns the handler for the route which matches path and method, wrapped with the middlewares of
+// the router and any subrouters the route was registered with, and the context with the route's path variables.
+// If no route matches, it returns a nil handler, and the methods allowed for the path (if any routes match the path).
+func (r *Router) getHandler(ctx context.Context, path string, method string) (context.Context, HandlerFunc, []string) {
+	match, allowed := r.getTree().match(path, method)
+	if match == nil {
+		return ctx, nil, allowed
+	}
+
+	i := 0
+	for _, token := range match.entry.tokens {
+		if token.isVar() {
+			ctx = CtxWithVar(ctx, token.name, match.values[i])
+			i++
 		}
+	}
 
-		if matches := routeHandler.matcher.FindStringSubmatch(path); len(matches) > 0 {
-			for i := 1; i < len(matches); i++ {
-				if i > len(routeHandler.pathArgNames) {
-					break
-				}
-
-				ctx = CtxWithVar(ctx, routeHandler.pathArgNames[i-1], matches[i])
-			}
-
-			// handler found, apply middleware chain
-			var handler HandlerFunc = routeHandler.handleFunc
-			// middlewares attached to this router first
-			for i := len(r.middlewares) - 1; i >= 0; i-- {
-				handler = r.middlewares[i].Middleware(handler)
-			}
-			// middlewares from parent routers next
-			for i := len(applyMiddlewares) - 1; i >= 0; i-- {
-				handler = applyMiddlewares[i].Middleware(handler)
-			}
-
-			// Add the matched route info to the context
-			mPath := routeHandler.path
-			if matchedPath != "" {
-				mPath = filepath.Join(matchedPath, mPath)
-			}
-			ctx = context.WithValue(ctx, ctxMatchedRouteKey{}, RouteInfo{
-				Name:   routeHandler.name,
-				Path:   mPath,
-				Method: method,
-			})
-			return ctx, handler
+	// handler found, apply middleware chain, from the router which registered the route up to this router,
+	// so that the middlewares of parent routers are called first
+	var handler HandlerFunc = match.entry.route.handleFunc
+	for router := match.entry.owner; router != nil; router = router.parent {
+		for i := len(router.middlewares) - 1; i >= 0; i-- {
+			handler = router.middlewares[i].Middleware(handler)
+		}
+		if router == r {
+			break
 		}
 	}
 
-	return ctx, nil
+	// Add the matched route info to the context
+	ctx = context.WithValue(ctx, ctxMatchedRouteKey{}, RouteInfo{
+		Name:   match.entry.route.name,
+		Path:   match.entry.path,
+		Method: method,
+	})
+	ctx = context.WithValue(ctx, ctxMatchedRouteHandlerKey{}, match.entry.route)
+	return ctx, handler, nil
 }
 
 // CallResource implements backend.CallResourceHandler, allowing the Router to route resource API requests
@@ -250,19 +252,25 @@ func (r *Router) CallResource(
 	ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender,
 ) error {
 	// Get the appropriate handler (if one exists)
-	ctx, handler := r.getHandler(ctx, req.Path, strings.ToUpper(req.Method), "")
+	ctx, handler, allowed := r.getHandler(ctx, req.Path, strings.ToUpper(req.Method))
 	if handler == nil {
-		if r.NotFoundHandler == nil {
+		// Return method not allowed if the path matches a route, and not found otherwise
+		name := "NotFoundHandler"
+		handler = r.NotFoundHandler
+		if len(allowed) > 0 && r.MethodNotAllowedHandler != nil {
+			name = "MethodNotAllowedHandler"
+			handler = r.MethodNotAllowedHandler
+			ctx = context.WithValue(ctx, ctxAllowedMethodsKey{}, allowed)
+		}
+		if handler == nil {
 			return errors.New("no handler found for the request")
 		}
 
-		// Return not found
-		handler = r.NotFoundHandler
 		for i := len(r.middlewares) - 1; i >= 0; i-- {
 			handler = r.middlewares[i].Middleware(handler)
 		}
 		ctx = context.WithValue(ctx, ctxMatchedRouteKey{}, RouteInfo{
-			Name:   "NotFoundHandler",
+			Name:   name,
 			Path:   req.Path,
 			Method: req.Method,
 		})
@@ -281,27 +289,39 @@ func (r *Router) ListenAndServe() error {
 
 // RouteHandler is a Handler function assigned to a route
 type RouteHandler struct {
-	// matcher is the regexp matcher for the route, built from the path
-	matcher *regexp.Regexp
 	// name is a user-provided name for the route, may be empty
 	name string
 	// path is the user-provided path when registering the route, used to build the matcher expression
 	path string
 	// handleFunc is the function called to handle the route
 	handleFunc func(ctx context.Context, req *backend.CallResourceRequest, res backend.CallResourceResponseSender)
-	// pathArgNames is a list of names of path arguments, ordered so that they correspond to the match order in matcher
-	pathArgNames []string
 	// methods is the list of HTTP methods that this RouteHandler should handle
 	methods map[string]struct{}
+	// operation is the user-provided description of the route for OpenAPI documents, may be nil
+	operation *OpenAPIOperation
+	// policy is the user-provided AuthorizationPolicy for the route, may be nil
+	policy AuthorizationPolicy
+	// timeout is the user-provided timeout for the route, used by the timeout middleware if non-nil
+	timeout *time.Duration
+	// maxBodySize is the user-provided maximum body size for the route, used by the body size middleware if non-nil
+	maxBodySize *int64
+	// entries are the entries for the route in the routeTree of the router it was registered with and its parents
+	entries []*routeEntry
 }
 
-// Methods sets the methods the handler function will be called for
+// Methods sets the methods the handler function will be called for.
+// It panics if the route would then conflict with another route.
 func (h *RouteHandler) Methods(methods []string) *RouteHandler {
 	m := make(map[string]struct{})
 	for _, method := range methods {
 		m[strings.ToUpper(method)] = struct{}{}
 	}
 	h.methods = m
+	for _, entry := range h.entries {
+		if err := entry.tree.conflict(entry); err != nil {
+			panic(err)
+		}
+	}
 	return h
 }
 
@@ -314,6 +334,26 @@ func (h *RouteHandler) Name(name string) *RouteHandler {
 
 type ctxMatchedRouteKey struct{}
 
+type ctxAllowedMethodsKey struct{}
+
+// AllowedMethodsFromContext returns the methods allowed for the request path, set in the context by the router
+// when the request path matches one or more routes, but none of them handle the request method.
+// It is used by MethodNotAllowedHandlers to set the Allow header.
+func AllowedMethodsFromContext(ctx context.Context) []string {
+	allowed, _ := ctx.Value(ctxAllowedMethodsKey{}).([]string)
+	return allowed
+}
+
+// ctxMatchedRouteHandlerKey is the context key for the matched *RouteHandler,
+// used by middlewares which have per-route configuration
+type ctxMatchedRouteHandlerKey struct{}
+
+// matchedRouteHandler returns the *RouteHandler matched by the router, or nil if no route was matched
+func matchedRouteHandler(ctx context.Context) *RouteHandler {
+	handler, _ := ctx.Value(ctxMatchedRouteHandlerKey{}).(*RouteHandler)
+	return handler
+}
+
 // RouteInfo stores information about a matched route
 type RouteInfo struct {
 	// Name is the user-provided name on the route, if a name was provided
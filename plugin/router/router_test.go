@@ -127,3 +127,186 @@ func TestRouter_RouteByName(t *testing.T) {
 		assert.Equal(t, handler, h)
 	})
 }
+
+func TestRouter_MethodNotAllowed(t *testing.T) {
+	noop := func(context.Context, *backend.CallResourceRequest, backend.CallResourceResponseSender) {}
+	r := NewRouter()
+	r.Handle("/foo/{id}", noop, http.MethodGet, http.MethodPut)
+	r.Subrouter("/foo").Handle("/{name}", noop, http.MethodDelete)
+	r.Handle("/bar", noop, http.MethodPost)
+
+	t.Run("path matches", func(t *testing.T) {
+		res := &CapturingSender{}
+		err := r.CallResource(context.Background(), &backend.CallResourceRequest{
+			Path:   "/foo/1",
+			Method: http.MethodPost,
+		}, res)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.Response.Status)
+		assert.Equal(t, []string{"DELETE, GET, PUT"}, res.Response.Headers["Allow"])
+	})
+
+	t.Run("path doesn't match", func(t *testing.T) {
+		res := &CapturingSender{}
+		err := r.CallResource(context.Background(), &backend.CallResourceRequest{
+			Path:   "/foo/1/2",
+			Method: http.MethodPost,
+		}, res)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.Response.Status)
+	})
+
+	t.Run("no method not allowed handler", func(t *testing.T) {
+		r := NewRouter()
+		r.Handle("/bar", noop, http.MethodPost)
+		r.MethodNotAllowedHandler = nil
+		res := &CapturingSender{}
+		err := r.CallResource(context.Background(), &backend.CallResourceRequest{
+			Path:   "/bar",
+			Method: http.MethodGet,
+		}, res)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.Response.Status)
+	})
+}
+
+func TestRouter_Handle_Conflicts(t *testing.T) {
+	noop := func(context.Context, *backend.CallResourceRequest, backend.CallResourceResponseSender) {}
+
+	t.Run("duplicate route", func(t *testing.T) {
+		r := NewRouter()
+		r.Handle("/foo/{id}", noop)
+		assert.PanicsWithError(t, "route 'GET /foo/{id}' conflicts with existing route 'GET /foo/{id}'", func() {
+			r.Handle("/foo/{id}", noop, http.MethodGet, http.MethodPost)
+		})
+	})
+
+	t.Run("different variable names", func(t *testing.T) {
+		r := NewRouter()
+		r.Handle("/foo/{id:[0-9]+}", noop, http.MethodDelete)
+		assert.PanicsWithError(t,
+			"route 'DELETE /foo/{num:[0-9]+}' conflicts with existing route 'DELETE /foo/{id:[0-9]+}'", func() {
+				r.Handle("/foo/{num:[0-9]+}", noop, http.MethodDelete)
+			})
+	})
+
+	t.Run("subrouter route", func(t *testing.T) {
+		r := NewRouter()
+		r.Handle("/foo/{id}", noop)
+		assert.PanicsWithError(t, "route 'GET /foo/{name}' conflicts with existing route 'GET /foo/{id}'", func() {
+			r.Subrouter("/foo").Handle("/{name}", noop)
+		})
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		r := NewRouter()
+		assert.PanicsWithError(t, "invalid route path '/foo/{id:[0-9}': invalid match expression for path variable 'id': "+
+			"error parsing regexp: missing closing ]: `[0-9)`", func() {
+			r.Handle("/foo/{id:[0-9}", noop)
+		})
+		assert.Panics(t, func() {
+			r.Subrouter("/foo/{id:[0-9}")
+		})
+	})
+
+	t.Run("changed methods", func(t *testing.T) {
+		r := NewRouter()
+		r.Handle("/foo", noop, http.MethodGet)
+		h := r.Handle("/foo", noop, http.MethodPost)
+		assert.Panics(t, func() {
+			h.Methods([]string{http.MethodPost, http.MethodGet})
+		})
+	})
+
+	t.Run("no conflict", func(t *testing.T) {
+		r := NewRouter()
+		assert.NotPanics(t, func() {
+			r.Handle("/foo/{id}", noop, http.MethodGet)
+			r.Handle("/foo/{id}", noop, http.MethodPost)
+			r.Handle("/foo/{id:[0-9]+}", noop, http.MethodGet)
+			r.Handle("/foo/bar", noop, http.MethodGet)
+			r.Subrouter("/foo").Handle("/{id}", noop, http.MethodDelete)
+		})
+	})
+}
+
+func TestRouter_CallResource_Precedence(t *testing.T) {
+	called := ""
+	vars := Vars{}
+	handler := func(name string) HandlerFunc {
+		return func(ctx context.Context, request *backend.CallResourceRequest, response backend.CallResourceResponseSender) {
+			called = name
+			vars = VarsFromCtx(ctx)
+		}
+	}
+	r := NewRouter()
+	r.Handle("/foo/{name}", handler("var"))
+	r.Handle("/foo/bar", handler("static"))
+	r.Handle("/files/{path:.+}/raw", handler("regex"))
+	r.Subrouter("/foo/").Handle("{a}/{b}", handler("sub"))
+	r.Handle("/docs/{name}.json", handler("json"))
+	r.Handle("/docs/{name}", handler("doc"))
+
+	tests := []struct {
+		path         string
+		expected     string
+		expectedVars Vars
+	}{
+		{"/foo/bar", "static", Vars{}},
+		{"/foo/baz", "var", Vars{"name": "baz"}},
+		{"/files/a/b/c/raw", "regex", Vars{"path": "a/b/c"}},
+		{"/foo/x/y", "sub", Vars{"a": "x", "b": "y"}},
+		{"/docs/a.b.json", "json", Vars{"name": "a.b"}},
+		{"/docs/a.jsonx", "doc", Vars{"name": "a.jsonx"}},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			called = ""
+			err := r.CallResource(context.Background(), &backend.CallResourceRequest{
+				Path:   test.path,
+				Method: http.MethodGet,
+			}, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, called)
+			assert.Equal(t, test.expectedVars, vars)
+		})
+	}
+}
+
+func TestSubrouter_CallResource(t *testing.T) {
+	var info RouteInfo
+	calls := make([]string, 0)
+	middleware := func(name string) MiddlewareFunc {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, request *backend.CallResourceRequest, sender backend.CallResourceResponseSender) {
+				calls = append(calls, name)
+				next(ctx, request, sender)
+			}
+		}
+	}
+	r := NewRouter()
+	r.Use(middleware("root"))
+	sr := r.Subrouter("/foo/{id}")
+	sr.Use(middleware("sub"))
+	sr.Handle("/bar", func(ctx context.Context, _ *backend.CallResourceRequest, _ backend.CallResourceResponseSender) {
+		info = MatchedRouteFromContext(ctx)
+		calls = append(calls, "handler")
+	})
+
+	// Requests to the parent router include the subrouter's prefix
+	assert.Nil(t, r.CallResource(context.Background(), &backend.CallResourceRequest{
+		Path:   "/foo/1/bar",
+		Method: http.MethodGet,
+	}, nil))
+	assert.Equal(t, []string{"root", "sub", "handler"}, calls)
+	assert.Equal(t, RouteInfo{Path: "/foo/{id}/bar", Method: http.MethodGet}, info)
+
+	// Requests to the subrouter are relative to the subrouter, and only use its middlewares
+	calls = make([]string, 0)
+	assert.Nil(t, sr.CallResource(context.Background(), &backend.CallResourceRequest{
+		Path:   "/bar",
+		Method: http.MethodGet,
+	}, nil))
+	assert.Equal(t, []string{"sub", "handler"}, calls)
+	assert.Equal(t, RouteInfo{Path: "/bar", Method: http.MethodGet}, info)
+}
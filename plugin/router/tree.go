@@ -0,0 +1,305 @@
+package router
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// pathToken is either a static string or a path variable in a route path
+type pathToken struct {
+	// static is the static string matched by the token, if it is not a variable
+	static string
+	// name is the name of the path variable, empty for static tokens
+	name string
+	// expr is the user-provided match expression of the path variable, empty for variables without one
+	expr string
+	// matcher matches the longest value of the path variable at the start of a path
+	matcher *regexp.Regexp
+}
+
+// valueExpr returns the regular expression matched by values of the path variable,
+// which is `[^/]+` for variables without a match expression
+func (t pathToken) valueExpr() string {
+	if t.expr == "" {
+		return "[^/]+"
+	}
+	return fmt.Sprintf("(?:%s)", t.expr)
+}
+
+func (t pathToken) isVar() bool {
+	return t.name != ""
+}
+
+// parsePath parses a path into static and variable tokens.
+// Variables are either `{name}`, which matches any non-empty string without a slash,
+// or `{name:expr}`, which matches any string matched by the regular expression expr.
+func parsePath(path string) ([]pathToken, error) {
+	tokens := make([]pathToken, 0)
+	last := 0
+	for _, match := range replArgRegex.FindAllStringSubmatchIndex(path, -1) {
+		if match[0] > last {
+			tokens = append(tokens, pathToken{static: path[last:match[0]]})
+		}
+		last = match[1]
+		token := pathToken{
+			name: path[match[2]:match[3]],
+			expr: path[match[4]:match[5]],
+		}
+		matcher, err := regexp.Compile("^" + token.valueExpr())
+		if err != nil {
+			return nil, fmt.Errorf("invalid match expression for path variable '%s': %w", token.name, err)
+		}
+		matcher.Longest()
+		token.matcher = matcher
+		tokens = append(tokens, token)
+	}
+	if last < len(path) {
+		tokens = append(tokens, pathToken{static: path[last:]})
+	}
+	return tokens, nil
+}
+
+// tokensOverlap returns true if the paths described by a and b match exactly the same request paths,
+// which is the case if they have the same static strings, and each pair of variables has the same match expression
+// (so they differ at most in the names of the variables).
+// Variables with different match expressions don't overlap, as they are tried in the order they were added,
+// so that a variable with a more specific expression (such as `{id:[0-9]+}`) can be added before a variable
+// which matches any value (such as `{name}`).
+func tokensOverlap(a, b []pathToken) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].isVar() != b[i].isVar() {
+			return false
+		}
+		if !a[i].isVar() && a[i].static != b[i].static {
+			return false
+		}
+		if a[i].isVar() && a[i].expr != b[i].expr {
+			return false
+		}
+	}
+	return true
+}
+
+// routeEntry is a route registered in a routeTree
+type routeEntry struct {
+	// tree is the routeTree the entry is registered in
+	tree *routeTree
+	// route is the registered route
+	route *RouteHandler
+	// owner is the router the route was registered with, which is either the router of the tree, or one of its subrouters
+	owner *Router
+	// path is the path of the route relative to the router of the tree, used for RouteInfo
+	path string
+	// tokens are the parsed tokens of the path of the route relative to the router of the tree
+	tokens []pathToken
+}
+
+// routeTree is a radix tree of the routes registered with a router and all of its subrouters,
+// used to match request paths to routes.
+//
+// Static strings are stored in radix nodes (so that common prefixes are only compared once),
+// and each path variable is a separate node. When matching a path, static children are tried before variables,
+// so a route such as `foo/bar` takes precedence over `foo/{name}`, and variables are tried in the order they were added.
+// A variable matches the longest value it can, which ends either before the label of one of the static children
+// of its node, or (if that doesn't match a route) at the end of the longest value of the variable,
+// so at most two values of each variable are tried, rather than every prefix of the path.
+type routeTree struct {
+	root    routeNode
+	entries []*routeEntry
+}
+
+// routeNode is a node in a routeTree, which matches either a static string or a path variable
+type routeNode struct {
+	// label is the static string matched by the node, empty for variable nodes and the root
+	label string
+	// variable is the path variable matched by the node, nil for static nodes and the root
+	variable *pathToken
+	// beforeStatic matches the longest value of the variable at the start of a path which is followed by
+	// the label of one of the static children, as its first submatch. It is nil if there are no static children.
+	beforeStatic *regexp.Regexp
+	// static are the static children, each of which has a label beginning with a different byte
+	static []*routeNode
+	// variables are the variable children, each of which has a different match expression
+	variables []*routeNode
+	// entries are the routes which end at the node
+	entries []*routeEntry
+}
+
+// routeMatch is a route matched by a routeTree
+type routeMatch struct {
+	entry *routeEntry
+	// values are the values of the path variables of the entry, in the order of the variables in entry.tokens
+	values []string
+}
+
+// conflict returns an error if entry matches the same requests (paths and methods) as another route in the tree
+func (t *routeTree) conflict(entry *routeEntry) error {
+	for _, other := range t.entries {
+		if other.route == entry.route || !tokensOverlap(entry.tokens, other.tokens) {
+			continue
+		}
+		for _, method := range entry.route.sortedMethods() {
+			if _, ok := other.route.methods[method]; ok {
+				return fmt.Errorf("route '%s %s' conflicts with existing route '%s %s'", method, entry.path, method, other.path)
+			}
+		}
+	}
+	return nil
+}
+
+// insert adds entry to the tree. It does not check whether entry conflicts with an existing route.
+func (t *routeTree) insert(entry *routeEntry) {
+	entry.tree = t
+	t.entries = append(t.entries, entry)
+	t.root.insert(entry.tokens, entry)
+}
+
+// match returns the route which matches path and method.
+// If no route matches, it returns the sorted methods of all routes which match path (if any),
+// which are the methods which are allowed for the path.
+func (t *routeTree) match(path, method string) (*routeMatch, []string) {
+	allowed := make(map[string]struct{})
+	if m := t.root.match(path, method, make([]string, 0), allowed); m != nil {
+		return m, nil
+	}
+	methods := make([]string, 0, len(allowed))
+	for m := range allowed {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return nil, methods
+}
+
+func (n *routeNode) insert(tokens []pathToken, entry *routeEntry) {
+	if len(tokens) == 0 {
+		n.entries = append(n.entries, entry)
+		return
+	}
+	token := tokens[0]
+	if !token.isVar() {
+		n.insertStatic(token.static, tokens[1:], entry)
+		return
+	}
+	for _, child := range n.variables {
+		if child.variable.expr == token.expr {
+			child.insert(tokens[1:], entry)
+			return
+		}
+	}
+	child := &routeNode{
+		variable: &token,
+	}
+	n.variables = append(n.variables, child)
+	child.insert(tokens[1:], entry)
+}
+
+func (n *routeNode) insertStatic(label string, rest []pathToken, entry *routeEntry) {
+	if n.variable != nil {
+		// The labels of the static children change, so beforeStatic must be compiled again
+		defer n.compileBeforeStatic()
+	}
+	for _, child := range n.static {
+		common := commonPrefixLength(child.label, label)
+		if common == 0 {
+			continue
+		}
+		if common < len(child.label) {
+			// Split the child, so that it only matches the common prefix
+			split := &routeNode{
+				label:     child.label[common:],
+				static:    child.static,
+				variables: child.variables,
+				entries:   child.entries,
+			}
+			child.label = child.label[:common]
+			child.static = []*routeNode{split}
+			child.variables = nil
+			child.entries = nil
+		}
+		if common == len(label) {
+			child.insert(rest, entry)
+		} else {
+			child.insertStatic(label[common:], rest, entry)
+		}
+		return
+	}
+	child := &routeNode{
+		label: label,
+	}
+	n.static = append(n.static, child)
+	child.insert(rest, entry)
+}
+
+// compileBeforeStatic compiles the beforeStatic expression of a variable node from the labels of its static children
+func (n *routeNode) compileBeforeStatic() {
+	labels := make([]string, len(n.static))
+	for i, child := range n.static {
+		labels[i] = regexp.QuoteMeta(child.label)
+	}
+	// The variable's expression has already been compiled, and the labels are quoted, so this can't fail
+	n.beforeStatic = regexp.MustCompile(fmt.Sprintf("^(%s)(?:%s)", n.variable.valueExpr(), strings.Join(labels, "|")))
+	n.beforeStatic.Longest()
+}
+
+// valueEnds returns the lengths of the values of the variable of the node at the start of path to try,
+// which are the longest value followed by the label of a static child, and the longest value.
+func (n *routeNode) valueEnds(path string) []int {
+	ends := make([]int, 0, 2)
+	if n.beforeStatic != nil {
+		if loc := n.beforeStatic.FindStringSubmatchIndex(path); loc != nil {
+			ends = append(ends, loc[3])
+		}
+	}
+	if loc := n.variable.matcher.FindStringIndex(path); loc != nil && (len(ends) == 0 || ends[0] != loc[1]) {
+		ends = append(ends, loc[1])
+	}
+	return ends
+}
+
+// match returns the first route under the node which matches path and method, where values are the values of
+// path variables matched before the node. The methods of routes which match path but not method are added to allowed.
+func (n *routeNode) match(path, method string, values []string, allowed map[string]struct{}) *routeMatch {
+	if path == "" {
+		for _, entry := range n.entries {
+			if _, ok := entry.route.methods[method]; ok {
+				return &routeMatch{
+					entry:  entry,
+					values: append([]string(nil), values...),
+				}
+			}
+			for m := range entry.route.methods {
+				allowed[m] = struct{}{}
+			}
+		}
+	}
+	for _, child := range n.static {
+		if strings.HasPrefix(path, child.label) {
+			if m := child.match(path[len(child.label):], method, values, allowed); m != nil {
+				return m
+			}
+			// Static children begin with different bytes, so no other static child can match
+			break
+		}
+	}
+	for _, child := range n.variables {
+		for _, end := range child.valueEnds(path) {
+			if m := child.match(path[end:], method, append(values, path[:end]), allowed); m != nil {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+func commonPrefixLength(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,363 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+
+	openapierrors "k8s.io/kube-openapi/pkg/validation/errors"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// ErrNoOpenAPISchema is the error returned by OpenAPISchemaValidator for a resource.Schema
+// which does not provide an OpenAPI schema.
+var ErrNoOpenAPISchema = errors.New("schema does not provide an OpenAPI schema")
+
+// SchemaValidator validates the body of a request to create or replace a resource (or one of its subresources)
+// before the resource is passed to the Store.
+type SchemaValidator interface {
+	// Validate validates body, which is the JSON of an object of schema,
+	// or only the JSON of the object's subresource if subresource is non-empty.
+	// It returns a *ValidationError if the body is invalid.
+	Validate(schema resource.Schema, subresource string, body []byte) error
+}
+
+// SchemaCheckingValidator is a SchemaValidator which can check whether it is able to validate bodies for a resource.Schema.
+// The ResourceGroupRouter constructors call CheckSchema for every schema in the resource group when used with
+// WithRequestValidation, and fail if it returns an error, rather than failing requests later.
+type SchemaCheckingValidator interface {
+	SchemaValidator
+	CheckSchema(schema resource.Schema) error
+}
+
+// ValidationCause is a single reason a request body is invalid.
+type ValidationCause struct {
+	// Field is the path of the invalid field in the body (such as "spec.foo.bar"), or empty if the whole body is invalid
+	Field string `json:"field,omitempty"`
+	// Message is a description of why the field is invalid
+	Message string `json:"message"`
+}
+
+// ValidationError is an error returned by a SchemaValidator if a request body is invalid.
+type ValidationError struct {
+	Causes []ValidationCause
+}
+
+// Error returns a message listing all causes of the error
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Causes))
+	for i, c := range e.Causes {
+		msgs[i] = c.Message
+	}
+	return fmt.Sprintf("request body is invalid: %s", strings.Join(msgs, "; "))
+}
+
+// OpenAPISchemaValidator is a SchemaValidator which validates bodies against the OpenAPI schema of the resource.Schema,
+// which must implement OpenAPISchemaProvider. Bodies for other schemas fail validation with ErrNoOpenAPISchema,
+// and a ResourceGroupRouter can't be created with an OpenAPISchemaValidator for a resource group which contains them.
+//
+// The spec and subresources of an object are validated against the corresponding properties of the OpenAPI schema,
+// including any constraints (such as enums, patterns, and minimums or maximums) on the fields.
+// Fields which are not in the OpenAPI schema are invalid, unless the schema for the parent object allows
+// additional properties, or has x-kubernetes-preserve-unknown-fields set. This includes top-level fields of the body,
+// other than apiVersion, kind, and the metadata fields of the object's type.
+// Metadata is not validated, as the JSON format of an object's metadata depends on the object's type.
+// The body for a subresource which is not a property of the OpenAPI schema is always invalid.
+//
+// It is safe for concurrent use.
+type OpenAPISchemaValidator struct {
+	// validators is a cache of *fieldValidator, keyed by openAPIValidatorKey
+	validators sync.Map
+	// metadataKeys is a cache of the metadata keys of bodies (see getMetadataKeys), keyed by resource.Schema
+	metadataKeys sync.Map
+}
+
+type openAPIValidatorKey struct {
+	schema resource.Schema
+	field  string
+}
+
+// fieldValidator validates a top-level field (such as spec) of an object
+type fieldValidator struct {
+	schema    map[string]any
+	validator *validate.SchemaValidator
+}
+
+// NewOpenAPISchemaValidator returns a new OpenAPISchemaValidator
+func NewOpenAPISchemaValidator() *OpenAPISchemaValidator {
+	return &OpenAPISchemaValidator{}
+}
+
+// Compile-time interface compliance check
+var _ SchemaCheckingValidator = &OpenAPISchemaValidator{}
+
+// CheckSchema returns an error wrapping ErrNoOpenAPISchema if sch does not implement OpenAPISchemaProvider,
+// or its OpenAPI schema is nil.
+func (*OpenAPISchemaValidator) CheckSchema(sch resource.Schema) error {
+	if provider, ok := sch.(OpenAPISchemaProvider); ok && provider.OpenAPISchema() != nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %s.%s.%s", ErrNoOpenAPISchema, sch.Group(), sch.Version(), sch.Kind())
+}
+
+// Validate validates body against the OpenAPI schema of schema.
+// It returns an error wrapping ErrNoOpenAPISchema if schema does not provide an OpenAPI schema (see CheckSchema).
+func (v *OpenAPISchemaValidator) Validate(sch resource.Schema, subresource string, body []byte) error {
+	if err := v.CheckSchema(sch); err != nil {
+		return err
+	}
+	provider, _ := sch.(OpenAPISchemaProvider)
+	properties, _ := provider.OpenAPISchema()["properties"].(map[string]any)
+
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return &ValidationError{
+			Causes: []ValidationCause{{
+				Message: fmt.Sprintf("body is not valid JSON: %s", err.Error()),
+			}},
+		}
+	}
+
+	causes := make([]ValidationCause, 0)
+	if subresource != "" {
+		if _, ok := properties[subresource].(map[string]any); !ok {
+			return &ValidationError{
+				Causes: []ValidationCause{{
+					Field:   subresource,
+					Message: fmt.Sprintf("%s is not in the OpenAPI schema", subresource),
+				}},
+			}
+		}
+		validator, err := v.getValidator(sch, properties, subresource)
+		if err != nil {
+			return err
+		}
+		causes = append(causes, validator.validate(subresource, doc)...)
+	} else {
+		obj, ok := doc.(map[string]any)
+		if !ok {
+			return &ValidationError{
+				Causes: []ValidationCause{{
+					Message: "body must be a JSON object",
+				}},
+			}
+		}
+		required := make(map[string]struct{})
+		if req, ok := provider.OpenAPISchema()["required"].([]any); ok {
+			for _, r := range req {
+				if s, ok := r.(string); ok {
+					required[s] = struct{}{}
+				}
+			}
+		} else if req, ok := provider.OpenAPISchema()["required"].([]string); ok {
+			for _, r := range req {
+				required[r] = struct{}{}
+			}
+		}
+		for _, field := range sortedKeys(properties) {
+			if field == "metadata" {
+				continue
+			}
+			value, ok := obj[field]
+			if !ok {
+				if _, isRequired := required[field]; isRequired {
+					causes = append(causes, ValidationCause{
+						Field:   field,
+						Message: fmt.Sprintf("%s in body is required", field),
+					})
+				}
+				continue
+			}
+			validator, err := v.getValidator(sch, properties, field)
+			if err != nil {
+				return err
+			}
+			causes = append(causes, validator.validate(field, value)...)
+		}
+		if preserve, _ := provider.OpenAPISchema()["x-kubernetes-preserve-unknown-fields"].(bool); !preserve {
+			metadataKeys := v.getMetadataKeys(sch)
+			for _, field := range sortedKeys(obj) {
+				_, isProperty := properties[field]
+				_, isMetadata := metadataKeys[field]
+				if !isProperty && !isMetadata {
+					causes = append(causes, ValidationCause{
+						Field:   field,
+						Message: fmt.Sprintf("%s in body is an unknown field", field),
+					})
+				}
+			}
+			sort.SliceStable(causes, func(i, j int) bool {
+				return causes[i].Field < causes[j].Field
+			})
+		}
+	}
+
+	if len(causes) > 0 {
+		return &ValidationError{
+			Causes: causes,
+		}
+	}
+	return nil
+}
+
+// getMetadataKeys returns the top-level keys of a body which hold an object's metadata,
+// which are apiVersion, kind, and metadata, and the keys other than spec and subresources
+// in the JSON of the schema's ZeroValue (such as the keys of a resource.BasicMetadataObject).
+func (v *OpenAPISchemaValidator) getMetadataKeys(sch resource.Schema) map[string]struct{} {
+	if cached, ok := v.metadataKeys.Load(sch); ok {
+		return cached.(map[string]struct{})
+	}
+
+	keys := map[string]struct{}{
+		"apiVersion": {},
+		"kind":       {},
+		"metadata":   {},
+	}
+	zero := sch.ZeroValue()
+	subresources := zero.Subresources()
+	if raw, err := json.Marshal(zero); err == nil {
+		fields := make(map[string]json.RawMessage)
+		if json.Unmarshal(raw, &fields) == nil {
+			for k := range fields {
+				if _, isSubresource := subresources[k]; k != "spec" && !isSubresource {
+					keys[k] = struct{}{}
+				}
+			}
+		}
+	}
+	v.metadataKeys.Store(sch, keys)
+	return keys
+}
+
+func (v *OpenAPISchemaValidator) getValidator(sch resource.Schema, properties map[string]any, field string) (
+	*fieldValidator, error) {
+	key := openAPIValidatorKey{
+		schema: sch,
+		field:  field,
+	}
+	if cached, ok := v.validators.Load(key); ok {
+		return cached.(*fieldValidator), nil
+	}
+
+	fieldSchema, _ := properties[field].(map[string]any)
+	// Convert the schema into a spec.Schema by way of JSON
+	raw, err := json.Marshal(fieldSchema)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal OpenAPI schema for '%s': %w", field, err)
+	}
+	parsed := spec.Schema{}
+	if err = json.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("unable to parse OpenAPI schema for '%s': %w", field, err)
+	}
+	validator := &fieldValidator{
+		schema:    fieldSchema,
+		validator: validate.NewSchemaValidator(&parsed, nil, field, strfmt.Default),
+	}
+	v.validators.Store(key, validator)
+	return validator, nil
+}
+
+func (f *fieldValidator) validate(field string, value any) []ValidationCause {
+	causes := make([]ValidationCause, 0)
+	result := f.validator.Validate(value)
+	for _, err := range result.Errors {
+		cause := ValidationCause{
+			Message: err.Error(),
+		}
+		var cast *openapierrors.Validation
+		if errors.As(err, &cast) {
+			cause.Field = cast.Name
+		}
+		causes = append(causes, cause)
+	}
+	causes = append(causes, unknownFields(field, value, f.schema)...)
+	// Errors from the SchemaValidator aren't in a consistent order, so sort all causes by field
+	sort.SliceStable(causes, func(i, j int) bool {
+		return causes[i].Field < causes[j].Field
+	})
+	return causes
+}
+
+// unknownFields returns a ValidationCause for each field in value which is not allowed by schema
+func unknownFields(path string, value any, schema map[string]any) []ValidationCause {
+	if preserve, _ := schema["x-kubernetes-preserve-unknown-fields"].(bool); preserve {
+		return nil
+	}
+	causes := make([]ValidationCause, 0)
+	switch cast := value.(type) {
+	case map[string]any:
+		properties := make(map[string]any)
+		hasProperties := false
+		for _, s := range append([]map[string]any{schema}, subschemas(schema)...) {
+			if props, ok := s["properties"].(map[string]any); ok {
+				hasProperties = true
+				for k, v := range props {
+					properties[k] = v
+				}
+			}
+		}
+		additional, hasAdditional := schema["additionalProperties"]
+		for _, k := range sortedKeys(cast) {
+			fieldPath := fmt.Sprintf("%s.%s", path, k)
+			if propSchema, ok := properties[k].(map[string]any); ok {
+				causes = append(causes, unknownFields(fieldPath, cast[k], propSchema)...)
+				continue
+			}
+			switch additionalSchema := additional.(type) {
+			case map[string]any:
+				causes = append(causes, unknownFields(fieldPath, cast[k], additionalSchema)...)
+				continue
+			case bool:
+				if additionalSchema {
+					continue
+				}
+			}
+			if !hasProperties && !hasAdditional {
+				// An object schema with no properties allows any fields
+				continue
+			}
+			causes = append(causes, ValidationCause{
+				Field:   fieldPath,
+				Message: fmt.Sprintf("%s in body is an unknown field", fieldPath),
+			})
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range cast {
+				causes = append(causes, unknownFields(fmt.Sprintf("%s[%d]", path, i), item, items)...)
+			}
+		}
+	}
+	return causes
+}
+
+// subschemas returns the allOf, anyOf, and oneOf subschemas of schema
+func subschemas(schema map[string]any) []map[string]any {
+	subs := make([]map[string]any, 0)
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		list, _ := schema[key].([]any)
+		for _, item := range list {
+			if s, ok := item.(map[string]any); ok {
+				subs = append(subs, s)
+			}
+		}
+	}
+	return subs
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,199 @@
+package router_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana-app-sdk/plugin/router"
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+var validationOpenAPISchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"metadata": map[string]any{"type": "object"},
+		"spec": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"some_info": map[string]any{"type": "string", "pattern": "^[a-z]+$"},
+				"mode":      map[string]any{"type": "string", "enum": []any{"on", "off"}},
+				"count":     map[string]any{"type": "integer", "minimum": 1, "maximum": 10},
+				"labels": map[string]any{
+					"type":                 "object",
+					"additionalProperties": map[string]any{"type": "string"},
+				},
+				"extra": map[string]any{
+					"type":                                 "object",
+					"x-kubernetes-preserve-unknown-fields": true,
+				},
+				"items": map[string]any{
+					"type": "array",
+					"items": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"name": map[string]any{"type": "string"},
+						},
+					},
+				},
+			},
+			"required": []any{"some_info"},
+		},
+		"status": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"status": map[string]any{"type": "string", "enum": []any{"ok", "failed"}},
+			},
+		},
+	},
+	"required": []string{"spec"},
+}
+
+func TestOpenAPISchemaValidator_Validate(t *testing.T) {
+	group := resource.NewSimpleSchemaGroup("test.resource", "v1")
+	schema := group.AddSchema(&Test{}, resource.WithKind("Test"), resource.WithOpenAPISchema(validationOpenAPISchema))
+	validator := router.NewOpenAPISchemaValidator()
+
+	tests := []struct {
+		name           string
+		schema         resource.Schema
+		subresource    string
+		body           string
+		expectedCauses []router.ValidationCause
+		expectedErr    error
+	}{
+		{
+			name:   "valid",
+			schema: schema,
+			body: `{"metadata":{"name":"foo","anything":"goes"},"spec":{"some_info":"abc","mode":"on","count":2,` +
+				`"labels":{"a":"b"},"extra":{"x":{"y":1}},"items":[{"name":"a"}]},"status":{"status":"ok"}}`,
+		},
+		{
+			name:   "missing spec",
+			schema: schema,
+			body:   `{"metadata":{"name":"foo"}}`,
+			expectedCauses: []router.ValidationCause{{
+				Field:   "spec",
+				Message: "spec in body is required",
+			}},
+		},
+		{
+			name:   "constraints",
+			schema: schema,
+			body:   `{"spec":{"some_info":"ABC","mode":"maybe","count":11}}`,
+			expectedCauses: []router.ValidationCause{{
+				Field:   "spec.count",
+				Message: "spec.count in body should be less than or equal to 10",
+			}, {
+				Field:   "spec.mode",
+				Message: "spec.mode in body should be one of [on off]",
+			}, {
+				Field:   "spec.some_info",
+				Message: "spec.some_info in body should match '^[a-z]+$'",
+			}},
+		},
+		{
+			name:   "unknown fields",
+			schema: schema,
+			body:   `{"spec":{"some_info":"abc","unknown":true,"items":[{"name":"a","other":"b"}]}}`,
+			expectedCauses: []router.ValidationCause{{
+				Field:   "spec.items[0].other",
+				Message: "spec.items[0].other in body is an unknown field",
+			}, {
+				Field:   "spec.unknown",
+				Message: "spec.unknown in body is an unknown field",
+			}},
+		},
+		{
+			name:   "unknown top-level fields",
+			schema: schema,
+			body: `{"apiVersion":"test.resource/v1","kind":"Test","metadata":{},"staticMetadata":{"name":"foo"},` +
+				`"spec":{"some_info":"abc"},"other":{},"another":1}`,
+			expectedCauses: []router.ValidationCause{{
+				Field:   "another",
+				Message: "another in body is an unknown field",
+			}, {
+				Field:   "other",
+				Message: "other in body is an unknown field",
+			}},
+		},
+		{
+			name:   "wrong type",
+			schema: schema,
+			body:   `{"spec":{"some_info":"abc","labels":{"a":1}}}`,
+			expectedCauses: []router.ValidationCause{{
+				Field:   "spec.labels.a",
+				Message: "spec.labels.a in body must be of type string: \"number\"",
+			}},
+		},
+		{
+			name:        "valid subresource",
+			schema:      schema,
+			subresource: "status",
+			body:        `{"status":"ok"}`,
+		},
+		{
+			name:        "invalid subresource",
+			schema:      schema,
+			subresource: "status",
+			body:        `{"status":"unknown","foo":"bar"}`,
+			expectedCauses: []router.ValidationCause{{
+				Field:   "status.foo",
+				Message: "status.foo in body is an unknown field",
+			}, {
+				Field:   "status.status",
+				Message: "status.status in body should be one of [ok failed]",
+			}},
+		},
+		{
+			name:        "subresource not in schema",
+			schema:      schema,
+			subresource: "other",
+			body:        `{"status":"ok"}`,
+			expectedCauses: []router.ValidationCause{{
+				Field:   "other",
+				Message: "other is not in the OpenAPI schema",
+			}},
+		},
+		{
+			name:   "not JSON",
+			schema: schema,
+			body:   `{`,
+			expectedCauses: []router.ValidationCause{{
+				Message: "body is not valid JSON: unexpected end of JSON input",
+			}},
+		},
+		{
+			name:        "schema without OpenAPI",
+			schema:      testResource,
+			body:        `{"spec":{"unknown":true}}`,
+			expectedErr: router.ErrNoOpenAPISchema,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validator.Validate(test.schema, test.subresource, []byte(test.body))
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			if len(test.expectedCauses) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			cast, ok := err.(*router.ValidationError)
+			require.True(t, ok, "error should be a *ValidationError")
+			assert.Equal(t, test.expectedCauses, cast.Causes)
+		})
+	}
+}
+
+func TestOpenAPISchemaValidator_CheckSchema(t *testing.T) {
+	group := resource.NewSimpleSchemaGroup("test.resource", "v1")
+	validator := router.NewOpenAPISchemaValidator()
+	assert.NoError(t, validator.CheckSchema(group.AddSchema(&Test{}, resource.WithKind("Test"),
+		resource.WithOpenAPISchema(validationOpenAPISchema))))
+	assert.ErrorIs(t, validator.CheckSchema(group.AddSchema(&Test{}, resource.WithKind("Other"))), router.ErrNoOpenAPISchema)
+}
@@ -1,6 +1,10 @@
 package resource
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
 
 type AdmissionAction string
 
@@ -113,3 +117,211 @@ func (sm *SimpleMutatingAdmissionController) Mutate(ctx context.Context, request
 
 // Interface compliance compile-time check
 var _ MutatingAdmissionController = &SimpleMutatingAdmissionController{}
+
+type admissionUserInfoKey struct{}
+
+// ContextWithAdmissionUserInfo returns a copy of ctx which carries userInfo.
+// InProcessAdmission uses the AdmissionUserInfo in the context as the UserInfo of its AdmissionRequests.
+func ContextWithAdmissionUserInfo(ctx context.Context, userInfo AdmissionUserInfo) context.Context {
+	return context.WithValue(ctx, admissionUserInfoKey{}, userInfo)
+}
+
+// AdmissionUserInfoFromContext returns the AdmissionUserInfo set in ctx by ContextWithAdmissionUserInfo,
+// or an empty AdmissionUserInfo if there is none.
+func AdmissionUserInfoFromContext(ctx context.Context) AdmissionUserInfo {
+	info, _ := ctx.Value(admissionUserInfoKey{}).(AdmissionUserInfo)
+	return info
+}
+
+// AdmissionDeniedError is the error returned by InProcessAdmission when an admission controller denies a request.
+// It implements AdmissionError, as well as APIServerResponseError, so it can be handled like an API server rejection.
+type AdmissionDeniedError struct {
+	// Err is the error returned by the admission controller
+	Err error
+	// Code is the HTTP status code of the rejection, which is always a client or server error (>= 400)
+	Code int
+	// DenyReason is the machine-readable reason for the rejection, if the admission controller provided one
+	DenyReason string
+}
+
+// Error returns the message of the error
+func (e *AdmissionDeniedError) Error() string {
+	return fmt.Sprintf("admission denied the request: %s", e.Err.Error())
+}
+
+// StatusCode returns the HTTP status code of the rejection
+func (e *AdmissionDeniedError) StatusCode() int {
+	return e.Code
+}
+
+// Reason returns the machine-readable reason for the rejection
+func (e *AdmissionDeniedError) Reason() string {
+	return e.DenyReason
+}
+
+// Unwrap returns the error returned by the admission controller
+func (e *AdmissionDeniedError) Unwrap() error {
+	return e.Err
+}
+
+// InProcessAdmission runs ValidatingAdmissionControllers and MutatingAdmissionControllers in-process,
+// for writes which are not made through an API server which calls admission webhooks (such as a k8s.WebhookServer).
+// Controllers are associated with a Schema, and only run for objects with the same group and kind.
+//
+// For each request, the MutatingAdmissionController (if any) runs first, and the ValidatingAdmissionController (if any)
+// validates the mutated object. As with webhooks, an error from either controller denies the request.
+//
+// The zero value is ready to use, but an InProcessAdmission must not be modified while it is in use.
+type InProcessAdmission struct {
+	validatingControllers map[string]ValidatingAdmissionController
+	mutatingControllers   map[string]MutatingAdmissionController
+}
+
+// AddValidatingAdmissionController adds a ValidatingAdmissionController for objects of schema's group and kind,
+// replacing any existing ValidatingAdmissionController for the group and kind.
+func (a *InProcessAdmission) AddValidatingAdmissionController(controller ValidatingAdmissionController, schema Schema) {
+	if a.validatingControllers == nil {
+		a.validatingControllers = make(map[string]ValidatingAdmissionController)
+	}
+	a.validatingControllers[admissionKey(schema.Group(), schema.Kind())] = controller
+}
+
+// AddMutatingAdmissionController adds a MutatingAdmissionController for objects of schema's group and kind,
+// replacing any existing MutatingAdmissionController for the group and kind.
+func (a *InProcessAdmission) AddMutatingAdmissionController(controller MutatingAdmissionController, schema Schema) {
+	if a.mutatingControllers == nil {
+		a.mutatingControllers = make(map[string]MutatingAdmissionController)
+	}
+	a.mutatingControllers[admissionKey(schema.Group(), schema.Kind())] = controller
+}
+
+// Handles returns true if there are any admission controllers for the group and kind.
+// Callers can use it to avoid fetching the existing object for a request which will not be admitted.
+func (a *InProcessAdmission) Handles(group, kind string) bool {
+	if a == nil {
+		return false
+	}
+	key := admissionKey(group, kind)
+	_, validating := a.validatingControllers[key]
+	_, mutating := a.mutatingControllers[key]
+	return validating || mutating
+}
+
+// Admit runs the admission controllers for the request's group and kind, and returns the admitted object,
+// which is the request's Object after any mutation. If the request is denied, the error is an *AdmissionDeniedError.
+func (a *InProcessAdmission) Admit(ctx context.Context, request *AdmissionRequest) (Object, error) {
+	if !a.Handles(request.Group, request.Kind) {
+		return request.Object, nil
+	}
+	key := admissionKey(request.Group, request.Kind)
+	if controller, ok := a.mutatingControllers[key]; ok {
+		resp, err := controller.Mutate(ctx, request)
+		if err != nil {
+			return nil, newAdmissionDeniedError(err)
+		}
+		if resp != nil && resp.UpdatedObject != nil {
+			request.Object = resp.UpdatedObject
+		}
+	}
+	if controller, ok := a.validatingControllers[key]; ok {
+		if err := controller.Validate(ctx, request); err != nil {
+			return nil, newAdmissionDeniedError(err)
+		}
+	}
+	return request.Object, nil
+}
+
+// AdmitCreate admits the creation of obj, returning the admitted object
+func (a *InProcessAdmission) AdmitCreate(ctx context.Context, obj Object) (Object, error) {
+	return a.Admit(ctx, newAdmissionRequest(ctx, AdmissionActionCreate, obj.StaticMetadata(), obj, nil))
+}
+
+// AdmitUpdate admits the update of oldObj to obj, returning the admitted object
+func (a *InProcessAdmission) AdmitUpdate(ctx context.Context, oldObj, obj Object) (Object, error) {
+	return a.Admit(ctx, newAdmissionRequest(ctx, AdmissionActionUpdate, obj.StaticMetadata(), obj, oldObj))
+}
+
+// AdmitDelete admits the deletion of oldObj
+func (a *InProcessAdmission) AdmitDelete(ctx context.Context, oldObj Object) error {
+	_, err := a.Admit(ctx, newAdmissionRequest(ctx, AdmissionActionDelete, oldObj.StaticMetadata(), nil, oldObj))
+	return err
+}
+
+// AdmitPatch admits the patch of oldObj (or only its subresource, if options.Subresource is set),
+// by applying the patch to oldObj with ApplyPatch and admitting the update to the patched object.
+// It returns a patch which makes the admitted changes (including any mutations) to oldObj, created with NewPatchFromDiff.
+// The returned patch is conditional on the resourceVersion of oldObj, so the write fails
+// if the object has changed since it was admitted.
+func (a *InProcessAdmission) AdmitPatch(ctx context.Context, oldObj Object, patch PatchRequest, options PatchOptions) (
+	PatchRequest, error) {
+	patched, err := ApplyPatch(oldObj, patch, options)
+	if err != nil {
+		return PatchRequest{}, &AdmissionDeniedError{
+			Err:  fmt.Errorf("unable to apply patch: %w", err),
+			Code: http.StatusUnprocessableEntity,
+		}
+	}
+	admitted, err := a.AdmitUpdate(ctx, oldObj, patched)
+	if err != nil {
+		return PatchRequest{}, err
+	}
+	return NewPatchFromDiff(oldObj, admitted)
+}
+
+// AdmitSubresourceUpdate admits the update of the subresource of oldObj to obj,
+// returning the admitted subresource object.
+func (a *InProcessAdmission) AdmitSubresourceUpdate(ctx context.Context, oldObj Object, subresource SubresourceName,
+	obj any) (any, error) {
+	updated, err := ApplyPatch(oldObj, PatchRequest{
+		Operations: []PatchOperation{{
+			Operation: PatchOpAdd,
+			Path:      "/" + EscapePointerToken(string(subresource)),
+			Value:     obj,
+		}},
+	}, PatchOptions{
+		Subresource: string(subresource),
+	})
+	if err != nil {
+		return nil, &AdmissionDeniedError{
+			Err:  fmt.Errorf("unable to update subresource: %w", err),
+			Code: http.StatusUnprocessableEntity,
+		}
+	}
+	admitted, err := a.AdmitUpdate(ctx, oldObj, updated)
+	if err != nil {
+		return nil, err
+	}
+	return admitted.Subresources()[string(subresource)], nil
+}
+
+func newAdmissionRequest(ctx context.Context, action AdmissionAction, meta StaticMetadata, obj, oldObj Object) *AdmissionRequest {
+	return &AdmissionRequest{
+		Action:    action,
+		Kind:      meta.Kind,
+		Group:     meta.Group,
+		Version:   meta.Version,
+		UserInfo:  AdmissionUserInfoFromContext(ctx),
+		Object:    obj,
+		OldObject: oldObj,
+	}
+}
+
+// newAdmissionDeniedError converts an error from an admission controller into an *AdmissionDeniedError.
+// As with webhooks, the status code of an AdmissionError is used if it is an error code, and 400 otherwise.
+func newAdmissionDeniedError(err error) *AdmissionDeniedError {
+	denied := &AdmissionDeniedError{
+		Err:  err,
+		Code: http.StatusBadRequest,
+	}
+	if cast, ok := err.(AdmissionError); ok {
+		if cast.StatusCode() >= http.StatusBadRequest {
+			denied.Code = cast.StatusCode()
+		}
+		denied.DenyReason = cast.Reason()
+	}
+	return denied
+}
+
+func admissionKey(group, kind string) string {
+	return fmt.Sprintf("%s.%s", kind, group)
+}
@@ -0,0 +1,216 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAdmissionError struct {
+	error
+	code   int
+	reason string
+}
+
+func (e testAdmissionError) StatusCode() int {
+	return e.code
+}
+
+func (e testAdmissionError) Reason() string {
+	return e.reason
+}
+
+func TestInProcessAdmission_Admit(t *testing.T) {
+	schema := NewSimpleSchema("g1", "v1", &SimpleObject[map[string]any]{}, WithKind("test"))
+	newObj := func(spec map[string]any) *SimpleObject[map[string]any] {
+		return &SimpleObject[map[string]any]{
+			BasicMetadataObject: BasicMetadataObject{
+				StaticMeta: StaticMetadata{
+					Group:     schema.Group(),
+					Version:   schema.Version(),
+					Kind:      schema.Kind(),
+					Namespace: "ns",
+					Name:      "test",
+				},
+			},
+			Spec: spec,
+		}
+	}
+	ctx := ContextWithAdmissionUserInfo(context.Background(), AdmissionUserInfo{
+		Username: "admin",
+	})
+
+	t.Run("no controllers", func(t *testing.T) {
+		admission := InProcessAdmission{}
+		obj := newObj(nil)
+		assert.False(t, admission.Handles(schema.Group(), schema.Kind()))
+		ret, err := admission.AdmitCreate(ctx, obj)
+		require.NoError(t, err)
+		assert.Equal(t, obj, ret)
+	})
+
+	t.Run("nil InProcessAdmission", func(t *testing.T) {
+		var admission *InProcessAdmission
+		assert.False(t, admission.Handles(schema.Group(), schema.Kind()))
+	})
+
+	t.Run("other kind", func(t *testing.T) {
+		admission := InProcessAdmission{}
+		admission.AddValidatingAdmissionController(&SimpleValidatingAdmissionController{
+			ValidateFunc: func(context.Context, *AdmissionRequest) error {
+				return fmt.Errorf("I AM ERROR")
+			},
+		}, NewSimpleSchema("g2", "v1", &SimpleObject[any]{}, WithKind("test")))
+		_, err := admission.AdmitCreate(ctx, newObj(nil))
+		assert.NoError(t, err)
+	})
+
+	t.Run("mutate then validate", func(t *testing.T) {
+		admission := InProcessAdmission{}
+		admission.AddMutatingAdmissionController(&SimpleMutatingAdmissionController{
+			MutateFunc: func(_ context.Context, request *AdmissionRequest) (*MutatingResponse, error) {
+				assert.Equal(t, AdmissionActionUpdate, request.Action)
+				assert.Equal(t, "admin", request.UserInfo.Username)
+				assert.Equal(t, map[string]any{"foo": "old"}, request.OldObject.SpecObject())
+				mutated := request.Object.Copy()
+				mutated.(*SimpleObject[map[string]any]).Spec["mutated"] = true
+				return &MutatingResponse{UpdatedObject: mutated}, nil
+			},
+		}, schema)
+		admission.AddValidatingAdmissionController(&SimpleValidatingAdmissionController{
+			ValidateFunc: func(_ context.Context, request *AdmissionRequest) error {
+				assert.Equal(t, map[string]any{"foo": "new", "mutated": true}, request.Object.SpecObject())
+				return nil
+			},
+		}, schema)
+		ret, err := admission.AdmitUpdate(ctx, newObj(map[string]any{"foo": "old"}), newObj(map[string]any{"foo": "new"}))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"foo": "new", "mutated": true}, ret.SpecObject())
+	})
+
+	t.Run("denied with simple error", func(t *testing.T) {
+		admission := InProcessAdmission{}
+		cerr := fmt.Errorf("I AM ERROR")
+		admission.AddValidatingAdmissionController(&SimpleValidatingAdmissionController{
+			ValidateFunc: func(context.Context, *AdmissionRequest) error {
+				return cerr
+			},
+		}, schema)
+		_, err := admission.AdmitCreate(ctx, newObj(nil))
+		var denied *AdmissionDeniedError
+		require.True(t, errors.As(err, &denied))
+		assert.Equal(t, http.StatusBadRequest, denied.StatusCode())
+		assert.Equal(t, "", denied.Reason())
+		assert.ErrorIs(t, err, cerr)
+	})
+
+	t.Run("denied with AdmissionError", func(t *testing.T) {
+		admission := InProcessAdmission{}
+		admission.AddMutatingAdmissionController(&SimpleMutatingAdmissionController{
+			MutateFunc: func(context.Context, *AdmissionRequest) (*MutatingResponse, error) {
+				return nil, testAdmissionError{
+					error:  fmt.Errorf("JE SUIS ERROR"),
+					code:   http.StatusForbidden,
+					reason: "Forbidden",
+				}
+			},
+		}, schema)
+		err := admission.AdmitDelete(ctx, newObj(nil))
+		var denied *AdmissionDeniedError
+		require.True(t, errors.As(err, &denied))
+		assert.Equal(t, http.StatusForbidden, denied.StatusCode())
+		assert.Equal(t, "Forbidden", denied.Reason())
+		assert.Equal(t, "admission denied the request: JE SUIS ERROR", err.Error())
+	})
+}
+
+func TestInProcessAdmission_AdmitPatch(t *testing.T) {
+	schema := NewSimpleSchema("g1", "v1", &SimpleObject[map[string]any]{}, WithKind("test"))
+	old := &SimpleObject[map[string]any]{
+		BasicMetadataObject: BasicMetadataObject{
+			StaticMeta: StaticMetadata{
+				Group:     schema.Group(),
+				Version:   schema.Version(),
+				Kind:      schema.Kind(),
+				Namespace: "ns",
+				Name:      "test",
+			},
+			CommonMeta: CommonMetadata{
+				ResourceVersion: "1",
+			},
+		},
+		Spec: map[string]any{"foo": "bar"},
+	}
+	admission := InProcessAdmission{}
+	admission.AddMutatingAdmissionController(&SimpleMutatingAdmissionController{
+		MutateFunc: func(_ context.Context, request *AdmissionRequest) (*MutatingResponse, error) {
+			mutated := request.Object.Copy()
+			mutated.(*SimpleObject[map[string]any]).Spec["mutated"] = true
+			return &MutatingResponse{UpdatedObject: mutated}, nil
+		},
+	}, schema)
+	admission.AddValidatingAdmissionController(&SimpleValidatingAdmissionController{
+		ValidateFunc: func(_ context.Context, request *AdmissionRequest) error {
+			if request.Object.SpecObject().(map[string]any)["foo"] == "invalid" {
+				return fmt.Errorf("foo cannot be invalid")
+			}
+			return nil
+		},
+	}, schema)
+
+	t.Run("admitted", func(t *testing.T) {
+		patch, err := admission.AdmitPatch(context.Background(), old, PatchRequest{
+			Operations: []PatchOperation{{
+				Operation: PatchOpReplace,
+				Path:      "/spec/foo",
+				Value:     "baz",
+			}},
+		}, PatchOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, PatchRequest{
+			Type: PatchTypeJSONPatch,
+			Operations: []PatchOperation{{
+				Operation: PatchOpTest,
+				Path:      "/metadata/resourceVersion",
+				Value:     "1",
+			}, {
+				Operation: PatchOpReplace,
+				Path:      "/spec/foo",
+				Value:     "baz",
+			}, {
+				Operation: PatchOpAdd,
+				Path:      "/spec/mutated",
+				Value:     true,
+			}},
+		}, patch)
+		// The original object is unchanged
+		assert.Equal(t, map[string]any{"foo": "bar"}, old.Spec)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		_, err := admission.AdmitPatch(context.Background(), old, PatchRequest{
+			Type:       PatchTypeMergePatch,
+			MergePatch: []byte(`{"spec":{"foo":"invalid"}}`),
+		}, PatchOptions{})
+		var denied *AdmissionDeniedError
+		require.True(t, errors.As(err, &denied))
+		assert.Equal(t, http.StatusBadRequest, denied.StatusCode())
+	})
+
+	t.Run("patch cannot be applied", func(t *testing.T) {
+		_, err := admission.AdmitPatch(context.Background(), old, PatchRequest{
+			Operations: []PatchOperation{{
+				Operation: PatchOpRemove,
+				Path:      "/spec/nope",
+			}},
+		}, PatchOptions{})
+		var denied *AdmissionDeniedError
+		require.True(t, errors.As(err, &denied))
+		assert.Equal(t, http.StatusUnprocessableEntity, denied.StatusCode())
+	})
+}
@@ -2,6 +2,10 @@ package resource
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 )
 
 const NamespaceAll = ""
@@ -51,11 +55,31 @@ type ListOptions struct {
 	Continue string
 }
 
-// PatchRequest represents a JSON patch request, which can contain multiple operations.
-// Patch request operations are expected to adhere to the JSON Patch specification laid out by RFC6902,
+// PatchType is the type of patch document in a PatchRequest
+type PatchType string
+
+const (
+	// PatchTypeJSONPatch is an RFC6902 JSON Patch (https://www.rfc-editor.org/rfc/rfc6902),
+	// which uses PatchRequest.Operations. It is the default PatchType.
+	PatchTypeJSONPatch = PatchType("json")
+	// PatchTypeMergePatch is an RFC7386 JSON Merge Patch (https://www.rfc-editor.org/rfc/rfc7386),
+	// which uses PatchRequest.MergePatch.
+	PatchTypeMergePatch = PatchType("merge")
+)
+
+// PatchRequest represents a patch request, which is either a JSON patch containing multiple operations,
+// or a JSON merge patch document, depending on Type.
+// JSON Patch request operations are expected to adhere to the JSON Patch specification laid out by RFC6902,
 // which can be found at https://www.rfc-editor.org/rfc/rfc6902
 type PatchRequest struct {
+	// Type is the type of the patch. If empty, it is treated as PatchTypeJSONPatch.
+	Type PatchType
+	// Operations are the JSON Patch operations, used when Type is PatchTypeJSONPatch
 	Operations []PatchOperation
+	// MergePatch is the raw JSON merge patch document, used when Type is PatchTypeMergePatch.
+	// As with Operations' paths, the document is of the form {"metadata":{...},"spec":{...},"<subresource>":{...}},
+	// where metadata keys are the JSON keys of CommonMetadata and CustomMetadata.
+	MergePatch json.RawMessage
 }
 
 // PatchOp represents an RFC6902 Patch "op" value
@@ -81,7 +105,52 @@ type PatchOperation struct {
 
 // PatchOptions are the options passed to a Client.Patch call
 type PatchOptions struct {
-	// TODO: nothing at-present
+	// Subresource can be set to a non-empty subresource field name to patch that subresource,
+	// instead of the main object
+	Subresource string
+}
+
+// ApplyOptions are the options passed to a Client.Apply call
+type ApplyOptions struct {
+	// FieldManager is the name of the actor applying the object, and is required.
+	// The fields set in the applied object are owned by the FieldManager,
+	// and fields it previously owned which are absent from the applied object are removed.
+	FieldManager string
+	// Force forces the apply to succeed when it sets fields owned by other field managers to different values,
+	// transferring ownership of those fields to FieldManager. If Force is false, such an apply fails with an ApplyConflictError.
+	Force bool
+	// Subresource can be set to a non-empty subresource field name to apply that subresource,
+	// instead of the main object. Only that subresource of the applied object is used, and the applier only owns its fields.
+	Subresource string
+}
+
+// ApplyConflict is a single field in an applied object which is owned by another field manager
+type ApplyConflict struct {
+	// Manager is the field manager which owns the field
+	Manager string
+	// Field is the path of the field, such as ".spec.foo"
+	Field string
+}
+
+// ApplyConflictError is the error returned by a Client.Apply call when the applied object sets fields owned
+// by other field managers to different values, and ApplyOptions.Force is false.
+// It implements APIServerResponseError with a 409 Conflict status code.
+type ApplyConflictError struct {
+	Conflicts []ApplyConflict
+}
+
+// Error returns a message listing all conflicts
+func (e *ApplyConflictError) Error() string {
+	conflicts := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		conflicts[i] = fmt.Sprintf("conflict with \"%s\": %s", c.Manager, c.Field)
+	}
+	return fmt.Sprintf("apply failed with %d conflict(s): %s", len(e.Conflicts), strings.Join(conflicts, "; "))
+}
+
+// StatusCode returns http.StatusConflict
+func (*ApplyConflictError) StatusCode() int {
+	return http.StatusConflict
 }
 
 // WatchOptions are the options passed to a Client.Watch call
@@ -138,13 +207,24 @@ type Client interface {
 	// UpdateInto updates a response, and marshals the updated version into the `into` field
 	UpdateInto(ctx context.Context, identifier Identifier, obj Object, options UpdateOptions, into Object) error
 
-	// Patch performs a JSON Patch on an object, using the content of the PatchRequest
+	// Patch performs a JSON Patch or JSON Merge Patch on an object, using the content of the PatchRequest
 	Patch(ctx context.Context, identifier Identifier, patch PatchRequest, options PatchOptions) (Object, error)
 
-	// PatchInto performs a JSON Patch on an object, using the content of the PatchRequest,
+	// PatchInto performs a JSON Patch or JSON Merge Patch on an object, using the content of the PatchRequest,
 	// marshaling the returned (full) object into `into`
 	PatchInto(ctx context.Context, identifier Identifier, patch PatchRequest, options PatchOptions, into Object) error
 
+	// Apply performs a server-side apply of the object, in which the fields set in obj are merged into the existing object
+	// (or used to create it if it doesn't exist) and owned by options.FieldManager. It returns the resulting object.
+	// Only the set metadata and the spec of obj are applied (or only the subresource in options.Subresource),
+	// and spec fields are applied as they marshal to JSON, so fields the applier doesn't own should be omitted with omitempty.
+	// If obj sets fields owned by another field manager to different values and options.Force is false,
+	// an *ApplyConflictError is returned.
+	Apply(ctx context.Context, identifier Identifier, obj Object, options ApplyOptions) (Object, error)
+
+	// ApplyInto performs a server-side apply of the object, and marshals the resulting object into the `into` field.
+	ApplyInto(ctx context.Context, identifier Identifier, obj Object, options ApplyOptions, into Object) error
+
 	// Delete deletes an exiting resource
 	Delete(ctx context.Context, identifier Identifier) error
 
@@ -174,10 +254,15 @@ type SchemalessClient interface {
 	// Update updates an existing resource, and marshals the updated version into the `into` field
 	Update(ctx context.Context, identifier FullIdentifier, obj Object, options UpdateOptions, into Object) error
 
-	// Patch performs a JSON Patch on an object, using the content of the PatchRequest,
+	// Patch performs a JSON Patch or JSON Merge Patch on an object, using the content of the PatchRequest,
 	// marshaling the returned (full) object into `into`
 	Patch(ctx context.Context, identifier FullIdentifier, path PatchRequest, options PatchOptions, into Object) error
 
+	// Apply performs a server-side apply of the object, and marshals the resulting object into the `into` field.
+	// If obj sets fields owned by another field manager to different values and options.Force is false,
+	// an *ApplyConflictError is returned.
+	Apply(ctx context.Context, identifier FullIdentifier, obj Object, options ApplyOptions, into Object) error
+
 	// Delete deletes a resource identified by identifier
 	Delete(ctx context.Context, identifier FullIdentifier) error
 
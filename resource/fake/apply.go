@@ -0,0 +1,247 @@
+package fake
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// managedFields is a map of field path (as a JSON pointer) to the field managers which own the field
+type managedFields map[string][]string
+
+// apply performs a server-side apply of doc.
+// This is a simplified version of kubernetes server-side apply: ownership is tracked for every leaf field
+// (lists are treated as a single atomic field) of the spec, or of the subresource if options.Subresource is set.
+// Fields set by create, update, or patch requests have no owner, and never conflict.
+// For the main object, labels and finalizers in the applied metadata are merged into the existing metadata.
+func (s *storage) apply(identifier resource.Identifier, doc map[string]any, options resource.ApplyOptions) (
+	[]byte, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	section := specKey
+	if options.Subresource != "" {
+		section = options.Subresource
+	}
+	applied := make(map[string]any)
+	flattenFields(doc[section], []string{section}, applied)
+
+	if _, ok := s.objects[identifier]; !ok {
+		if options.Subresource != "" {
+			return nil, newNotFoundError(s.schema.Plural(), identifier)
+		}
+		data, err := s.insert(identifier, doc)
+		if err != nil {
+			return nil, err
+		}
+		owners := make(managedFields)
+		for path := range applied {
+			owners[path] = []string{options.FieldManager}
+		}
+		s.fieldManagers[identifier] = owners
+		return data, nil
+	}
+
+	existing, err := s.getDocument(identifier)
+	if err != nil {
+		return nil, err
+	}
+	owners := s.fieldManagers[identifier].copy()
+
+	// Check for conflicts: fields owned by other managers which would be set to a different value
+	conflicts := make([]resource.ApplyConflict, 0)
+	for path, value := range applied {
+		current, exists := getField(existing, pointerTokens(path))
+		if exists && reflect.DeepEqual(current, value) {
+			continue
+		}
+		for _, manager := range owners[path] {
+			if manager != options.FieldManager {
+				conflicts = append(conflicts, resource.ApplyConflict{
+					Manager: manager,
+					Field:   "." + strings.Join(pointerTokens(path), "."),
+				})
+			}
+		}
+	}
+	if len(conflicts) > 0 && !options.Force {
+		sort.Slice(conflicts, func(i, j int) bool {
+			if conflicts[i].Field != conflicts[j].Field {
+				return conflicts[i].Field < conflicts[j].Field
+			}
+			return conflicts[i].Manager < conflicts[j].Manager
+		})
+		return nil, &resource.ApplyConflictError{
+			Conflicts: conflicts,
+		}
+	}
+
+	updated, err := s.getDocument(identifier)
+	if err != nil {
+		return nil, err
+	}
+	// Fields which the manager no longer applies are removed, unless another manager also owns them
+	for path, managers := range owners {
+		if _, ok := applied[path]; ok || !containsString(managers, options.FieldManager) {
+			continue
+		}
+		owners[path] = removeString(managers, options.FieldManager)
+		if len(owners[path]) == 0 {
+			delete(owners, path)
+			removeField(updated, pointerTokens(path))
+		}
+	}
+	for path, value := range applied {
+		setField(updated, pointerTokens(path), value)
+		current, exists := getField(existing, pointerTokens(path))
+		if options.Force || !exists || !reflect.DeepEqual(current, value) {
+			// The value changed (or was forced), so the manager is now the sole owner
+			owners[path] = []string{options.FieldManager}
+		} else if !containsString(owners[path], options.FieldManager) {
+			owners[path] = append(owners[path], options.FieldManager)
+		}
+	}
+	if options.Subresource == "" {
+		mergeAppliedMetadata(metadataOf(updated), metadataOf(doc))
+	}
+
+	data, err := s.commit(identifier, existing, updated)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.objects[identifier]; ok {
+		s.fieldManagers[identifier] = owners
+	}
+	return data, nil
+}
+
+// mergeAppliedMetadata merges the labels and finalizers of applied metadata into the existing metadata
+func mergeAppliedMetadata(existing, applied map[string]any) {
+	if labels, ok := applied["labels"].(map[string]any); ok && len(labels) > 0 {
+		merged, ok := existing["labels"].(map[string]any)
+		if !ok {
+			merged = make(map[string]any)
+		}
+		for k, v := range labels {
+			merged[k] = v
+		}
+		existing["labels"] = merged
+	}
+	if finalizers, ok := applied["finalizers"].([]any); ok && len(finalizers) > 0 {
+		merged, _ := existing["finalizers"].([]any)
+		for _, f := range finalizers {
+			found := false
+			for _, e := range merged {
+				if e == f {
+					found = true
+					break
+				}
+			}
+			if !found {
+				merged = append(merged, f)
+			}
+		}
+		existing["finalizers"] = merged
+	}
+}
+
+// flattenFields adds every leaf field of value to fields, keyed by JSON pointer.
+// Objects with no fields, lists, and scalar values are all considered leaves.
+func flattenFields(value any, path []string, fields map[string]any) {
+	if obj, ok := value.(map[string]any); ok && len(obj) > 0 {
+		for k, v := range obj {
+			flattenFields(v, append(append(make([]string, 0, len(path)+1), path...), k), fields)
+		}
+		return
+	}
+	if value == nil && len(path) == 1 {
+		// An absent or null section has no fields
+		return
+	}
+	fields[toPointer(path)] = value
+}
+
+func getField(doc map[string]any, path []string) (any, bool) {
+	var current any = doc
+	for _, key := range path {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setField sets the value at path, creating (or replacing non-object values with) intermediate objects as required
+func setField(doc map[string]any, path []string, value any) {
+	current := doc
+	for _, key := range path[:len(path)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			current[key] = next
+		}
+		current = next
+	}
+	current[path[len(path)-1]] = value
+}
+
+func removeField(doc map[string]any, path []string) {
+	current := doc
+	for _, key := range path[:len(path)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			return
+		}
+		current = next
+	}
+	delete(current, path[len(path)-1])
+}
+
+func toPointer(path []string) string {
+	escaped := make([]string, len(path))
+	for i, p := range path {
+		escaped[i] = strings.ReplaceAll(strings.ReplaceAll(p, "~", "~0"), "/", "~1")
+	}
+	return "/" + strings.Join(escaped, "/")
+}
+
+// pointerTokens is the inverse of toPointer
+func pointerTokens(pointer string) []string {
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
+
+func (m managedFields) copy() managedFields {
+	cpy := make(managedFields, len(m))
+	for k, v := range m {
+		cpy[k] = append(make([]string, 0, len(v)), v...)
+	}
+	return cpy
+}
+
+func containsString(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(list []string, str string) []string {
+	result := make([]string, 0, len(list))
+	for _, s := range list {
+		if s != str {
+			result = append(result, s)
+		}
+	}
+	return result
+}
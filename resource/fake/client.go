@@ -0,0 +1,355 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// Client is an in-memory implementation of resource.Client.
+// A Client is specific to the Schema it was created with, and all Clients created by the same ClientGenerator
+// for the same Schema share the same underlying storage.
+// New Clients should only be created via the ClientGenerator.ClientFor method.
+type Client struct {
+	schema resource.Schema
+	store  *storage
+}
+
+// Get gets a resource of the client's internal Schema-derived kind, with the provided identifier
+func (c *Client) Get(ctx context.Context, identifier resource.Identifier) (resource.Object, error) {
+	into := c.schema.ZeroValue()
+	err := c.GetInto(ctx, identifier, into)
+	if err != nil {
+		return nil, err
+	}
+	return into, nil
+}
+
+// GetInto gets a resource of the client's internal Schema-derived kind, with the provided identifier,
+// and marshals it into `into`
+func (c *Client) GetInto(_ context.Context, identifier resource.Identifier, into resource.Object) error {
+	if into == nil {
+		return fmt.Errorf("into cannot be nil")
+	}
+	data, err := c.store.get(identifier)
+	if err != nil {
+		return err
+	}
+	return documentToObject(data, identifier, c.schema, into)
+}
+
+// Create creates a new resource, and returns the resulting created resource
+func (c *Client) Create(ctx context.Context, identifier resource.Identifier, obj resource.Object,
+	options resource.CreateOptions) (resource.Object, error) {
+	into := c.schema.ZeroValue()
+	err := c.CreateInto(ctx, identifier, obj, options, into)
+	if err != nil {
+		return nil, err
+	}
+	return into, nil
+}
+
+// CreateInto creates a new resource, and marshals the resulting created resource into `into`.
+// Unlike a kubernetes API server, subresources present in `obj` are stored along with the rest of the object,
+// which allows for easier seeding of test data.
+func (c *Client) CreateInto(_ context.Context, identifier resource.Identifier, obj resource.Object,
+	_ resource.CreateOptions, into resource.Object) error {
+	if obj == nil {
+		return fmt.Errorf("obj cannot be nil")
+	}
+	if into == nil {
+		return fmt.Errorf("into cannot be nil")
+	}
+	if err := c.validateScope(identifier.Namespace, "create"); err != nil {
+		return err
+	}
+	doc, err := objectToDocument(obj)
+	if err != nil {
+		return err
+	}
+	data, err := c.store.create(identifier, doc)
+	if err != nil {
+		return err
+	}
+	return documentToObject(data, identifier, c.schema, into)
+}
+
+// Update updates the provided resource, and returns the updated resource
+func (c *Client) Update(ctx context.Context, identifier resource.Identifier, obj resource.Object,
+	options resource.UpdateOptions) (resource.Object, error) {
+	into := c.schema.ZeroValue()
+	err := c.UpdateInto(ctx, identifier, obj, options, into)
+	if err != nil {
+		return nil, err
+	}
+	return into, nil
+}
+
+// UpdateInto updates the provided resource, and marshals the updated resource into `into`.
+// If options.ResourceVersion is non-empty and does not match the stored object's ResourceVersion,
+// the update is rejected with a 409 Conflict ServerResponseError.
+// Subresources are only updated if options.Subresource is set, in which case only that subresource is updated.
+func (c *Client) UpdateInto(_ context.Context, identifier resource.Identifier, obj resource.Object,
+	options resource.UpdateOptions, into resource.Object) error {
+	if obj == nil {
+		return fmt.Errorf("obj cannot be nil")
+	}
+	if into == nil {
+		return fmt.Errorf("into cannot be nil")
+	}
+	doc, err := objectToDocument(obj)
+	if err != nil {
+		return err
+	}
+	data, err := c.store.update(identifier, doc, options)
+	if err != nil {
+		return err
+	}
+	return documentToObject(data, identifier, c.schema, into)
+}
+
+// Patch performs a JSON Patch or JSON Merge Patch on the provided resource, and returns the updated object
+func (c *Client) Patch(ctx context.Context, identifier resource.Identifier, patch resource.PatchRequest,
+	options resource.PatchOptions) (resource.Object, error) {
+	into := c.schema.ZeroValue()
+	err := c.PatchInto(ctx, identifier, patch, options, into)
+	if err != nil {
+		return nil, err
+	}
+	return into, nil
+}
+
+// PatchInto performs a JSON Patch or JSON Merge Patch on the provided resource, and marshals the updated version into the `into` field.
+// Patch paths (or merge patches) are applied to a document of the form {"metadata":{...},"spec":{...},"<subresource>":{...}},
+// where metadata contains the JSON representation of resource.CommonMetadata along with all CustomMetadata fields.
+// Changes to subresources are only kept if options.Subresource is set, in which case only that subresource is changed.
+func (c *Client) PatchInto(_ context.Context, identifier resource.Identifier, patch resource.PatchRequest,
+	options resource.PatchOptions, into resource.Object) error {
+	if into == nil {
+		return fmt.Errorf("into cannot be nil")
+	}
+	data, err := c.store.patch(identifier, patch, options)
+	if err != nil {
+		return err
+	}
+	return documentToObject(data, identifier, c.schema, into)
+}
+
+// Apply performs a server-side apply of the provided resource, and returns the resulting object
+func (c *Client) Apply(ctx context.Context, identifier resource.Identifier, obj resource.Object,
+	options resource.ApplyOptions) (resource.Object, error) {
+	into := c.schema.ZeroValue()
+	err := c.ApplyInto(ctx, identifier, obj, options, into)
+	if err != nil {
+		return nil, err
+	}
+	return into, nil
+}
+
+// ApplyInto performs a server-side apply of the provided resource, and marshals the resulting object into `into`.
+// Field ownership is tracked for each leaf field of the spec (or of options.Subresource, if set), with lists
+// treated as a single field. Applying a field owned by another field manager with a different value results in a
+// *resource.ApplyConflictError, unless options.Force is set. Fields set by Create, Update, or Patch calls have no owner.
+// Labels and finalizers in the applied object are merged into the existing object's metadata.
+func (c *Client) ApplyInto(_ context.Context, identifier resource.Identifier, obj resource.Object,
+	options resource.ApplyOptions, into resource.Object) error {
+	if obj == nil {
+		return fmt.Errorf("obj cannot be nil")
+	}
+	if into == nil {
+		return fmt.Errorf("into cannot be nil")
+	}
+	if options.FieldManager == "" {
+		return newBadRequestError(fmt.Errorf("options.FieldManager is required"))
+	}
+	if err := c.validateScope(identifier.Namespace, "apply"); err != nil {
+		return err
+	}
+	doc, err := objectToDocument(obj)
+	if err != nil {
+		return err
+	}
+	data, err := c.store.apply(identifier, doc, options)
+	if err != nil {
+		return err
+	}
+	return documentToObject(data, identifier, c.schema, into)
+}
+
+// Delete deletes the specified resource. If the resource has finalizers, it is instead marked for deletion
+// by setting its DeletionTimestamp, and will be removed once an update or patch removes all finalizers.
+func (c *Client) Delete(_ context.Context, identifier resource.Identifier) error {
+	return c.store.delete(identifier)
+}
+
+// List lists resources in the provided namespace.
+// For resources with a schema.Scope() of ClusterScope, `namespace` must be resource.NamespaceAll
+func (c *Client) List(ctx context.Context, namespace string, options resource.ListOptions) (
+	resource.ListObject, error) {
+	into := &resource.SimpleList[resource.Object]{}
+	err := c.ListInto(ctx, namespace, options, into)
+	if err != nil {
+		return nil, err
+	}
+	return into, nil
+}
+
+// ListInto lists resources in the provided namespace, and unmarshals the response into the provided resource.ListObject
+func (c *Client) ListInto(_ context.Context, namespace string, options resource.ListOptions,
+	into resource.ListObject) error {
+	if into == nil {
+		return fmt.Errorf("into cannot be nil")
+	}
+	if c.schema.Scope() == resource.ClusterScope && namespace != resource.NamespaceAll {
+		return fmt.Errorf("cannot list resources with schema scope \"%s\" in namespace \"%s\", must be NamespaceAll (\"%s\")",
+			resource.ClusterScope, namespace, resource.NamespaceAll)
+	}
+	result, err := c.store.list(namespace, options)
+	if err != nil {
+		return err
+	}
+	items := make([]resource.Object, len(result.items))
+	for idx, data := range result.items {
+		items[idx] = c.schema.ZeroValue()
+		err = documentToObject(data, result.identifiers[idx], c.schema, items[idx])
+		if err != nil {
+			return err
+		}
+	}
+	into.SetListMetadata(result.metadata)
+	into.SetItems(items)
+	return nil
+}
+
+// Watch makes a watch request for the namespace, and returns a WatchResponse which will receive events
+// for all changes made to matching resources until it is stopped or the context is canceled.
+// If options.ResourceVersion is empty or "0", the watch begins with an ADDED event for each existing resource.
+// Otherwise, all events which occurred after the provided ResourceVersion are replayed.
+func (c *Client) Watch(ctx context.Context, namespace string, options resource.WatchOptions) (
+	resource.WatchResponse, error) {
+	if c.schema.Scope() == resource.ClusterScope && namespace != resource.NamespaceAll {
+		return nil, fmt.Errorf("cannot watch resources with schema scope \"%s\" in namespace \"%s\", must be NamespaceAll (\"%s\")",
+			resource.ClusterScope, namespace, resource.NamespaceAll)
+	}
+	w, err := c.store.watch(namespace, options)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.Stop()
+		case <-w.stopCh:
+		}
+	}()
+	return w, nil
+}
+
+func (c *Client) validateScope(namespace string, action string) error {
+	if c.schema.Scope() == resource.NamespacedScope && namespace == resource.NamespaceAll {
+		return fmt.Errorf("cannot %s a resource with schema scope \"%s\" in NamespaceAll (\"%s\")",
+			action, resource.NamespacedScope, resource.NamespaceAll)
+	} else if c.schema.Scope() == resource.ClusterScope && namespace != resource.NamespaceAll {
+		return fmt.Errorf("cannot %s a resource with schema scope \"%s\" in namespace \"%s\", must be NamespaceAll (\"%s\")",
+			action, resource.ClusterScope, namespace, resource.NamespaceAll)
+	}
+	return nil
+}
+
+// objectToDocument converts a resource.Object into the generic JSON document used by storage
+func objectToDocument(obj resource.Object) (map[string]any, error) {
+	meta, err := toGeneric(obj.CommonMetadata())
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal metadata: %w", err)
+	}
+	metaMap, _ := meta.(map[string]any)
+	if metaMap == nil {
+		metaMap = make(map[string]any)
+	}
+	if obj.CustomMetadata() != nil {
+		for k, v := range obj.CustomMetadata().MapFields() {
+			if metaMap[k], err = toGeneric(v); err != nil {
+				return nil, fmt.Errorf("unable to marshal custom metadata field '%s': %w", k, err)
+			}
+		}
+	}
+	spec, err := toGeneric(obj.SpecObject())
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal spec: %w", err)
+	}
+	doc := map[string]any{
+		metadataKey: metaMap,
+		specKey:     spec,
+	}
+	for k, v := range obj.Subresources() {
+		if k == metadataKey || k == specKey {
+			continue
+		}
+		if doc[k], err = toGeneric(v); err != nil {
+			return nil, fmt.Errorf("unable to marshal subresource '%s': %w", k, err)
+		}
+	}
+	return doc, nil
+}
+
+// documentToObject unmarshals a stored JSON document into a resource.Object
+func documentToObject(data []byte, identifier resource.Identifier, sch resource.Schema, into resource.Object) error {
+	raw := make(map[string]json.RawMessage)
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	subresources := make(map[string][]byte)
+	for k, v := range raw {
+		if k == metadataKey || k == specKey || len(v) == 0 || string(v) == "null" {
+			continue
+		}
+		subresources[k] = v
+	}
+	spec := raw[specKey]
+	if len(spec) == 0 {
+		spec = []byte("null")
+	}
+	err = into.Unmarshal(resource.ObjectBytes{
+		Spec:         spec,
+		Metadata:     raw[metadataKey],
+		Subresources: subresources,
+	}, resource.UnmarshalConfig{
+		WireFormat:  resource.WireFormatJSON,
+		VersionHint: sch.Version(),
+	})
+	if err != nil {
+		return err
+	}
+	into.SetStaticMetadata(resource.StaticMetadata{
+		Namespace: identifier.Namespace,
+		Name:      identifier.Name,
+		Group:     sch.Group(),
+		Version:   sch.Version(),
+		Kind:      sch.Kind(),
+	})
+	cmd := resource.CommonMetadata{}
+	err = json.Unmarshal(raw[metadataKey], &cmd)
+	if err != nil {
+		return err
+	}
+	into.SetCommonMetadata(cmd)
+	return nil
+}
+
+// Interface compliance compile-time check
+var _ resource.Client = &Client{}
+
+// toGeneric converts an arbitrary go value into its generic JSON representation
+// (map[string]any, []any, string, float64, bool, or nil)
+func toGeneric(v any) (any, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	err = json.Unmarshal(bytes, &generic)
+	return generic, err
+}
@@ -0,0 +1,561 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+type testSpec struct {
+	Foo string `json:"foo"`
+	Bar int    `json:"bar"`
+}
+
+var testSchema = resource.NewSimpleSchema("test.grafana.com", "v1", &resource.SimpleObject[testSpec]{}, resource.WithKind("Test"))
+
+func newTestObject(foo string, labels map[string]string) *resource.SimpleObject[testSpec] {
+	obj := &resource.SimpleObject[testSpec]{
+		Spec: testSpec{Foo: foo},
+	}
+	obj.CommonMeta.Labels = labels
+	return obj
+}
+
+func getTestClient(t *testing.T) *Client {
+	client, err := NewClientGenerator().ClientFor(testSchema)
+	require.Nil(t, err)
+	return client.(*Client)
+}
+
+func assertStatusCode(t *testing.T, code int, err error) {
+	cast, ok := err.(resource.APIServerResponseError)
+	require.True(t, ok, "error is not an APIServerResponseError: %v", err)
+	assert.Equal(t, code, cast.StatusCode())
+}
+
+func TestClientGenerator_ClientFor(t *testing.T) {
+	generator := NewClientGenerator()
+	c1, err := generator.ClientFor(testSchema)
+	require.Nil(t, err)
+	c2, err := generator.ClientFor(testSchema)
+	require.Nil(t, err)
+	other, err := generator.ClientFor(resource.NewSimpleSchema("test.grafana.com", "v1", &resource.SimpleObject[testSpec]{}, resource.WithKind("Other")))
+	require.Nil(t, err)
+
+	id := resource.Identifier{Namespace: "ns", Name: "foo"}
+	_, err = c1.Create(context.Background(), id, newTestObject("bar", nil), resource.CreateOptions{})
+	require.Nil(t, err)
+
+	// Same schema shares storage
+	obj, err := c2.Get(context.Background(), id)
+	require.Nil(t, err)
+	assert.Equal(t, "bar", obj.SpecObject().(testSpec).Foo)
+	// Different schema does not
+	_, err = other.Get(context.Background(), id)
+	assertStatusCode(t, http.StatusNotFound, err)
+}
+
+func TestClient_Create(t *testing.T) {
+	client := getTestClient(t)
+	ctx := context.Background()
+	id := resource.Identifier{Namespace: "ns", Name: "foo"}
+
+	t.Run("success", func(t *testing.T) {
+		created, err := client.Create(ctx, id, newTestObject("bar", map[string]string{"a": "b"}), resource.CreateOptions{})
+		require.Nil(t, err)
+		assert.Equal(t, resource.StaticMetadata{
+			Namespace: "ns",
+			Name:      "foo",
+			Group:     testSchema.Group(),
+			Version:   testSchema.Version(),
+			Kind:      testSchema.Kind(),
+		}, created.StaticMetadata())
+		assert.Equal(t, testSpec{Foo: "bar"}, created.SpecObject())
+		assert.NotEmpty(t, created.CommonMetadata().UID)
+		assert.NotEmpty(t, created.CommonMetadata().ResourceVersion)
+		assert.Equal(t, int64(1), created.CommonMetadata().Generation)
+		assert.False(t, created.CommonMetadata().CreationTimestamp.IsZero())
+		assert.Equal(t, map[string]string{"a": "b"}, created.CommonMetadata().Labels)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		_, err := client.Create(ctx, id, newTestObject("bar", nil), resource.CreateOptions{})
+		assertStatusCode(t, http.StatusConflict, err)
+	})
+
+	t.Run("namespaced in NamespaceAll", func(t *testing.T) {
+		_, err := client.Create(ctx, resource.Identifier{Name: "foo"}, newTestObject("bar", nil), resource.CreateOptions{})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestClient_Update(t *testing.T) {
+	client := getTestClient(t)
+	ctx := context.Background()
+	id := resource.Identifier{Namespace: "ns", Name: "foo"}
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.Update(ctx, id, newTestObject("bar", nil), resource.UpdateOptions{})
+		assertStatusCode(t, http.StatusNotFound, err)
+	})
+
+	seed := newTestObject("bar", nil)
+	seed.SubresourceMap = map[string]any{"status": map[string]any{"state": "ok"}}
+	created, err := client.Create(ctx, id, seed, resource.CreateOptions{})
+	require.Nil(t, err)
+
+	t.Run("resource version conflict", func(t *testing.T) {
+		_, err := client.Update(ctx, id, newTestObject("baz", nil), resource.UpdateOptions{
+			ResourceVersion: "not-" + created.CommonMetadata().ResourceVersion,
+		})
+		assertStatusCode(t, http.StatusConflict, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		obj := created.Copy().(*resource.SimpleObject[testSpec])
+		obj.Spec.Foo = "baz"
+		obj.SubresourceMap = map[string]any{"status": map[string]any{"state": "ignored"}}
+		updated, err := client.Update(ctx, id, obj, resource.UpdateOptions{
+			ResourceVersion: created.CommonMetadata().ResourceVersion,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, "baz", updated.SpecObject().(testSpec).Foo)
+		assert.Equal(t, created.CommonMetadata().UID, updated.CommonMetadata().UID)
+		assert.NotEqual(t, created.CommonMetadata().ResourceVersion, updated.CommonMetadata().ResourceVersion)
+		assert.Equal(t, int64(2), updated.CommonMetadata().Generation)
+		// Subresources are not updated by a non-subresource update
+		assert.JSONEq(t, `{"state":"ok"}`, string(updated.Subresources()["status"].(json.RawMessage)))
+	})
+
+	t.Run("subresource", func(t *testing.T) {
+		current, err := client.Get(ctx, id)
+		require.Nil(t, err)
+		updated, err := client.Update(ctx, id, &resource.SimpleObject[testSpec]{
+			SubresourceMap: map[string]any{"status": map[string]any{"state": "new"}},
+		}, resource.UpdateOptions{
+			Subresource: "status",
+		})
+		require.Nil(t, err)
+		assert.Equal(t, current.SpecObject(), updated.SpecObject())
+		assert.Equal(t, current.CommonMetadata().Generation, updated.CommonMetadata().Generation)
+		assert.JSONEq(t, `{"state":"new"}`, string(updated.Subresources()["status"].(json.RawMessage)))
+	})
+}
+
+func TestClient_Patch(t *testing.T) {
+	client := getTestClient(t)
+	ctx := context.Background()
+	id := resource.Identifier{Namespace: "ns", Name: "foo"}
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.Patch(ctx, id, resource.PatchRequest{}, resource.PatchOptions{})
+		assertStatusCode(t, http.StatusNotFound, err)
+	})
+
+	created, err := client.Create(ctx, id, newTestObject("bar", nil), resource.CreateOptions{})
+	require.Nil(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		patched, err := client.Patch(ctx, id, resource.PatchRequest{
+			Operations: []resource.PatchOperation{{
+				Operation: resource.PatchOpReplace,
+				Path:      "/spec/foo",
+				Value:     "baz",
+			}, {
+				Operation: resource.PatchOpAdd,
+				Path:      "/metadata/labels",
+				Value:     map[string]string{"a": "b"},
+			}, {
+				Operation: resource.PatchOpAdd,
+				Path:      "/metadata/finalizers",
+				Value:     []string{"f1"},
+			}, {
+				Operation: resource.PatchOpAdd,
+				Path:      "/metadata/finalizers/-",
+				Value:     "f2",
+			}},
+		}, resource.PatchOptions{})
+		require.Nil(t, err)
+		assert.Equal(t, testSpec{Foo: "baz"}, patched.SpecObject())
+		assert.Equal(t, map[string]string{"a": "b"}, patched.CommonMetadata().Labels)
+		assert.Equal(t, []string{"f1", "f2"}, patched.CommonMetadata().Finalizers)
+		assert.Equal(t, int64(2), patched.CommonMetadata().Generation)
+		assert.Equal(t, created.CommonMetadata().UID, patched.CommonMetadata().UID)
+	})
+
+	t.Run("merge patch", func(t *testing.T) {
+		patch, err := resource.NewMergePatchFromDiff("spec", testSpec{Foo: "baz"}, testSpec{Foo: "merged", Bar: 2})
+		require.Nil(t, err)
+		patched, err := client.Patch(ctx, id, patch, resource.PatchOptions{})
+		require.Nil(t, err)
+		assert.Equal(t, testSpec{Foo: "merged", Bar: 2}, patched.SpecObject())
+		assert.Equal(t, map[string]string{"a": "b"}, patched.CommonMetadata().Labels)
+
+		patched, err = client.Patch(ctx, id, resource.PatchRequest{
+			Type:       resource.PatchTypeMergePatch,
+			MergePatch: []byte(`{"metadata":{"labels":{"a":null,"c":"d"}},"spec":{"foo":"baz","bar":0}}`),
+		}, resource.PatchOptions{})
+		require.Nil(t, err)
+		assert.Equal(t, testSpec{Foo: "baz"}, patched.SpecObject())
+		assert.Equal(t, map[string]string{"c": "d"}, patched.CommonMetadata().Labels)
+	})
+
+	t.Run("unknown patch type", func(t *testing.T) {
+		_, err := client.Patch(ctx, id, resource.PatchRequest{Type: "foo"}, resource.PatchOptions{})
+		assertStatusCode(t, http.StatusBadRequest, err)
+	})
+
+	t.Run("failed test", func(t *testing.T) {
+		_, err := client.Patch(ctx, id, resource.PatchRequest{
+			Operations: []resource.PatchOperation{{
+				Operation: resource.PatchOpTest,
+				Path:      "/spec/foo",
+				Value:     "bar",
+			}},
+		}, resource.PatchOptions{})
+		assertStatusCode(t, http.StatusUnprocessableEntity, err)
+	})
+
+	t.Run("subresource", func(t *testing.T) {
+		patched, err := client.Patch(ctx, id, resource.PatchRequest{
+			Operations: []resource.PatchOperation{{
+				Operation: resource.PatchOpAdd,
+				Path:      "/status",
+				Value:     map[string]any{"state": "patched"},
+			}, {
+				Operation: resource.PatchOpReplace,
+				Path:      "/spec/foo",
+				Value:     "ignored",
+			}},
+		}, resource.PatchOptions{Subresource: "status"})
+		require.Nil(t, err)
+		assert.Equal(t, testSpec{Foo: "baz"}, patched.SpecObject())
+		assert.JSONEq(t, `{"state":"patched"}`, string(patched.Subresources()["status"].(json.RawMessage)))
+
+		// Patches to the main object don't change subresources
+		patched, err = client.Patch(ctx, id, resource.PatchRequest{
+			Operations: []resource.PatchOperation{{
+				Operation: resource.PatchOpRemove,
+				Path:      "/status",
+			}},
+		}, resource.PatchOptions{})
+		require.Nil(t, err)
+		assert.JSONEq(t, `{"state":"patched"}`, string(patched.Subresources()["status"].(json.RawMessage)))
+	})
+
+	t.Run("patch from diff", func(t *testing.T) {
+		original, err := client.Get(ctx, id)
+		require.Nil(t, err)
+		modified := original.Copy().(*resource.SimpleObject[testSpec])
+		modified.Spec.Bar = 5
+		modified.CommonMeta.Labels = map[string]string{"c": "d", "e": "f"}
+		patch, err := resource.NewPatchFromDiff(original, modified)
+		require.Nil(t, err)
+		patched, err := client.Patch(ctx, id, patch, resource.PatchOptions{})
+		require.Nil(t, err)
+		assert.Equal(t, testSpec{Foo: "baz", Bar: 5}, patched.SpecObject())
+		assert.Equal(t, map[string]string{"c": "d", "e": "f"}, patched.CommonMetadata().Labels)
+
+		// The object has changed since original was retrieved, so the resourceVersion test fails
+		_, err = client.Patch(ctx, id, patch, resource.PatchOptions{})
+		assertStatusCode(t, http.StatusUnprocessableEntity, err)
+	})
+
+	t.Run("stale resource version", func(t *testing.T) {
+		_, err := client.Patch(ctx, id, resource.PatchRequest{
+			Operations: []resource.PatchOperation{{
+				Operation: resource.PatchOpReplace,
+				Path:      "/metadata/resourceVersion",
+				Value:     created.CommonMetadata().ResourceVersion,
+			}},
+		}, resource.PatchOptions{})
+		assertStatusCode(t, http.StatusConflict, err)
+	})
+}
+
+func TestClient_Apply(t *testing.T) {
+	client := getTestClient(t)
+	ctx := context.Background()
+	id := resource.Identifier{Namespace: "ns", Name: "foo"}
+
+	t.Run("no field manager", func(t *testing.T) {
+		_, err := client.Apply(ctx, id, newTestObject("bar", nil), resource.ApplyOptions{})
+		assertStatusCode(t, http.StatusBadRequest, err)
+	})
+
+	t.Run("subresource of missing object", func(t *testing.T) {
+		_, err := client.Apply(ctx, id, newTestObject("bar", nil), resource.ApplyOptions{
+			FieldManager: "a",
+			Subresource:  "status",
+		})
+		assertStatusCode(t, http.StatusNotFound, err)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		applied, err := client.Apply(ctx, id, newTestObject("bar", map[string]string{"a": "b"}), resource.ApplyOptions{
+			FieldManager: "a",
+		})
+		require.Nil(t, err)
+		assert.Equal(t, testSpec{Foo: "bar"}, applied.SpecObject())
+		assert.Equal(t, map[string]string{"a": "b"}, applied.CommonMetadata().Labels)
+	})
+
+	t.Run("same value from another manager", func(t *testing.T) {
+		applied, err := client.Apply(ctx, id, newTestObject("bar", map[string]string{"c": "d"}), resource.ApplyOptions{
+			FieldManager: "b",
+		})
+		require.Nil(t, err)
+		assert.Equal(t, testSpec{Foo: "bar"}, applied.SpecObject())
+		assert.Equal(t, map[string]string{"a": "b", "c": "d"}, applied.CommonMetadata().Labels)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := client.Apply(ctx, id, newTestObject("baz", nil), resource.ApplyOptions{
+			FieldManager: "c",
+		})
+		require.NotNil(t, err)
+		cast, ok := err.(*resource.ApplyConflictError)
+		require.True(t, ok, "error is not an *ApplyConflictError: %v", err)
+		// Both managers own foo, as they applied the same value. bar is unchanged, so doesn't conflict.
+		assert.Equal(t, []resource.ApplyConflict{
+			{Manager: "a", Field: ".spec.foo"},
+			{Manager: "b", Field: ".spec.foo"},
+		}, cast.Conflicts)
+		assertStatusCode(t, http.StatusConflict, err)
+	})
+
+	t.Run("force", func(t *testing.T) {
+		applied, err := client.Apply(ctx, id, newTestObject("baz", nil), resource.ApplyOptions{
+			FieldManager: "c",
+			Force:        true,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, testSpec{Foo: "baz"}, applied.SpecObject())
+
+		// "c" is now the sole owner, so "a" conflicts with it
+		_, err = client.Apply(ctx, id, newTestObject("bar", nil), resource.ApplyOptions{
+			FieldManager: "a",
+		})
+		cast, ok := err.(*resource.ApplyConflictError)
+		require.True(t, ok, "error is not an *ApplyConflictError: %v", err)
+		assert.Equal(t, []resource.ApplyConflict{{Manager: "c", Field: ".spec.foo"}}, cast.Conflicts)
+	})
+
+	t.Run("removed field", func(t *testing.T) {
+		obj := &resource.SimpleObject[map[string]any]{
+			Spec: map[string]any{"foo": "baz", "bar": 5},
+		}
+		applied, err := client.Apply(ctx, id, obj, resource.ApplyOptions{FieldManager: "d", Force: true})
+		require.Nil(t, err)
+		assert.Equal(t, testSpec{Foo: "baz", Bar: 5}, applied.SpecObject())
+		// When "d" no longer applies "bar", the field is removed, as "d" was the sole owner
+		obj.Spec = map[string]any{"foo": "baz"}
+		applied, err = client.Apply(ctx, id, obj, resource.ApplyOptions{FieldManager: "d"})
+		require.Nil(t, err)
+		assert.Equal(t, testSpec{Foo: "baz"}, applied.SpecObject())
+	})
+
+	t.Run("subresource", func(t *testing.T) {
+		obj := newTestObject("ignored", nil)
+		obj.SubresourceMap = map[string]any{"status": map[string]any{"state": "applied"}}
+		applied, err := client.Apply(ctx, id, obj, resource.ApplyOptions{
+			FieldManager: "a",
+			Subresource:  "status",
+		})
+		require.Nil(t, err)
+		assert.Equal(t, testSpec{Foo: "baz"}, applied.SpecObject())
+		assert.JSONEq(t, `{"state":"applied"}`, string(applied.Subresources()["status"].(json.RawMessage)))
+	})
+}
+
+func TestClient_Delete(t *testing.T) {
+	client := getTestClient(t)
+	ctx := context.Background()
+	id := resource.Identifier{Namespace: "ns", Name: "foo"}
+
+	t.Run("not found", func(t *testing.T) {
+		err := client.Delete(ctx, id)
+		assertStatusCode(t, http.StatusNotFound, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		_, err := client.Create(ctx, id, newTestObject("bar", nil), resource.CreateOptions{})
+		require.Nil(t, err)
+		require.Nil(t, client.Delete(ctx, id))
+		_, err = client.Get(ctx, id)
+		assertStatusCode(t, http.StatusNotFound, err)
+	})
+
+	t.Run("with finalizers", func(t *testing.T) {
+		obj := newTestObject("bar", nil)
+		obj.CommonMeta.Finalizers = []string{"foo"}
+		_, err := client.Create(ctx, id, obj, resource.CreateOptions{})
+		require.Nil(t, err)
+		require.Nil(t, client.Delete(ctx, id))
+		current, err := client.Get(ctx, id)
+		require.Nil(t, err)
+		assert.NotNil(t, current.CommonMetadata().DeletionTimestamp)
+
+		// Removing the finalizers removes the object
+		_, err = client.Patch(ctx, id, resource.PatchRequest{
+			Operations: []resource.PatchOperation{{
+				Operation: resource.PatchOpRemove,
+				Path:      "/metadata/finalizers/0",
+			}},
+		}, resource.PatchOptions{})
+		require.Nil(t, err)
+		_, err = client.Get(ctx, id)
+		assertStatusCode(t, http.StatusNotFound, err)
+	})
+}
+
+func TestClient_List(t *testing.T) {
+	client := getTestClient(t)
+	ctx := context.Background()
+	for _, id := range []resource.Identifier{{Namespace: "ns1", Name: "a"}, {Namespace: "ns1", Name: "b"}, {Namespace: "ns1", Name: "c"}, {Namespace: "ns2", Name: "d"}} {
+		_, err := client.Create(ctx, id, newTestObject(id.Name, map[string]string{"ns": id.Namespace}), resource.CreateOptions{})
+		require.Nil(t, err)
+	}
+	names := func(list resource.ListObject) []string {
+		n := make([]string, 0)
+		for _, item := range list.ListItems() {
+			n = append(n, item.StaticMetadata().Name)
+		}
+		return n
+	}
+
+	t.Run("all namespaces", func(t *testing.T) {
+		list, err := client.List(ctx, resource.NamespaceAll, resource.ListOptions{})
+		require.Nil(t, err)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, names(list))
+		assert.Empty(t, list.ListMetadata().Continue)
+	})
+
+	t.Run("namespace", func(t *testing.T) {
+		list, err := client.List(ctx, "ns2", resource.ListOptions{})
+		require.Nil(t, err)
+		assert.Equal(t, []string{"d"}, names(list))
+	})
+
+	t.Run("label filters", func(t *testing.T) {
+		list, err := client.List(ctx, resource.NamespaceAll, resource.ListOptions{
+			LabelFilters: []string{"ns!=ns1"},
+		})
+		require.Nil(t, err)
+		assert.Equal(t, []string{"d"}, names(list))
+	})
+
+	t.Run("invalid label filter", func(t *testing.T) {
+		_, err := client.List(ctx, resource.NamespaceAll, resource.ListOptions{
+			LabelFilters: []string{"ns in (ns1"},
+		})
+		assertStatusCode(t, http.StatusBadRequest, err)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		list, err := client.List(ctx, resource.NamespaceAll, resource.ListOptions{Limit: 3})
+		require.Nil(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, names(list))
+		require.NotEmpty(t, list.ListMetadata().Continue)
+		require.NotNil(t, list.ListMetadata().RemainingItemCount)
+		assert.Equal(t, int64(1), *list.ListMetadata().RemainingItemCount)
+
+		list, err = client.List(ctx, resource.NamespaceAll, resource.ListOptions{
+			Limit:    3,
+			Continue: list.ListMetadata().Continue,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, []string{"d"}, names(list))
+		assert.Empty(t, list.ListMetadata().Continue)
+		assert.Nil(t, list.ListMetadata().RemainingItemCount)
+	})
+}
+
+func TestClient_Watch(t *testing.T) {
+	client := getTestClient(t)
+	ctx := context.Background()
+	id := resource.Identifier{Namespace: "ns", Name: "foo"}
+	existing, err := client.Create(ctx, resource.Identifier{Namespace: "ns", Name: "existing"}, newTestObject("bar", nil), resource.CreateOptions{})
+	require.Nil(t, err)
+
+	next := func(t *testing.T, w resource.WatchResponse) resource.WatchEvent {
+		select {
+		case evt := <-w.WatchEvents():
+			return evt
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out waiting for watch event")
+		}
+		return resource.WatchEvent{}
+	}
+
+	t.Run("events", func(t *testing.T) {
+		w, err := client.Watch(ctx, "ns", resource.WatchOptions{})
+		require.Nil(t, err)
+		defer w.Stop()
+		evt := next(t, w)
+		assert.Equal(t, WatchEventTypeAdded, evt.EventType)
+		assert.Equal(t, "existing", evt.Object.StaticMetadata().Name)
+
+		_, err = client.Create(ctx, id, newTestObject("bar", nil), resource.CreateOptions{})
+		require.Nil(t, err)
+		evt = next(t, w)
+		assert.Equal(t, WatchEventTypeAdded, evt.EventType)
+		assert.Equal(t, "foo", evt.Object.StaticMetadata().Name)
+
+		_, err = client.Update(ctx, id, newTestObject("baz", nil), resource.UpdateOptions{})
+		require.Nil(t, err)
+		evt = next(t, w)
+		assert.Equal(t, WatchEventTypeModified, evt.EventType)
+		assert.Equal(t, testSpec{Foo: "baz"}, evt.Object.SpecObject())
+
+		require.Nil(t, client.Delete(ctx, id))
+		evt = next(t, w)
+		assert.Equal(t, WatchEventTypeDeleted, evt.EventType)
+	})
+
+	t.Run("resume from resource version", func(t *testing.T) {
+		w, err := client.Watch(ctx, "ns", resource.WatchOptions{
+			ResourceVersion: existing.CommonMetadata().ResourceVersion,
+		})
+		require.Nil(t, err)
+		defer w.Stop()
+		// Replays the events from the previous test
+		assert.Equal(t, WatchEventTypeAdded, next(t, w).EventType)
+		assert.Equal(t, WatchEventTypeModified, next(t, w).EventType)
+		assert.Equal(t, WatchEventTypeDeleted, next(t, w).EventType)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		w, err := client.Watch(ctx, resource.NamespaceAll, resource.WatchOptions{
+			LabelFilters: []string{"watched=true"},
+		})
+		require.Nil(t, err)
+		defer w.Stop()
+		_, err = client.Create(ctx, resource.Identifier{Namespace: "ns", Name: "ignored"}, newTestObject("bar", nil), resource.CreateOptions{})
+		require.Nil(t, err)
+		_, err = client.Create(ctx, resource.Identifier{Namespace: "ns2", Name: "watched"}, newTestObject("bar", map[string]string{"watched": "true"}), resource.CreateOptions{})
+		require.Nil(t, err)
+		evt := next(t, w)
+		assert.Equal(t, "watched", evt.Object.StaticMetadata().Name)
+	})
+
+	t.Run("stop on context cancel", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		w, err := client.Watch(cctx, "empty", resource.WatchOptions{})
+		require.Nil(t, err)
+		cancel()
+		select {
+		case _, ok := <-w.WatchEvents():
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			assert.Fail(t, "watch channel was not closed")
+		}
+	})
+}
@@ -0,0 +1,13 @@
+/*
+Package fake contains an in-memory implementation of the resource.Client and resource.ClientGenerator interfaces,
+intended for testing code which uses a resource.Client, resource.Store, resource.TypedStore, or resource.SimpleStore
+without a running storage system (such as a kubernetes API server).
+
+The in-memory Client mimics the behavior of a kubernetes API server where it is relevant to callers:
+objects are namespaced by their resource.Identifier, updates honor ResourceVersion optimistic concurrency,
+patches use RFC6902 JSON Patch or RFC7386 JSON Merge Patch semantics, lists can be filtered by label selectors and paginated,
+deletes of objects with finalizers only mark the object for deletion, and watches receive events for all changes.
+Errors which would be HTTP errors from a storage system are returned as *ServerResponseError,
+which implements resource.APIServerResponseError.
+*/
+package fake
@@ -0,0 +1,65 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// NewServerResponseError creates a new instance of ServerResponseError
+func NewServerResponseError(err error, statusCode int) *ServerResponseError {
+	return &ServerResponseError{
+		err:        err,
+		statusCode: statusCode,
+	}
+}
+
+// ServerResponseError is the error returned by the in-memory Client for any request that a real storage system
+// would reject with an HTTP error. It implements resource.APIServerResponseError, so callers can treat it
+// the same way they would treat an error returned from a remote storage system.
+type ServerResponseError struct {
+	err        error
+	statusCode int
+}
+
+// Error returns the error message
+func (s *ServerResponseError) Error() string {
+	return s.err.Error()
+}
+
+// StatusCode returns the HTTP status code a remote storage system would have responded with
+func (s *ServerResponseError) StatusCode() int {
+	return s.statusCode
+}
+
+// Unwrap returns the underlying error
+func (s *ServerResponseError) Unwrap() error {
+	return s.err
+}
+
+// Interface compliance compile-time check
+var _ resource.APIServerResponseError = &ServerResponseError{}
+
+func newNotFoundError(plural string, identifier resource.Identifier) *ServerResponseError {
+	return NewServerResponseError(fmt.Errorf("%s \"%s\" not found", plural, identifier.Name), http.StatusNotFound)
+}
+
+func newAlreadyExistsError(plural string, identifier resource.Identifier) *ServerResponseError {
+	return NewServerResponseError(fmt.Errorf("%s \"%s\" already exists", plural, identifier.Name), http.StatusConflict)
+}
+
+func newConflictError(plural string, identifier resource.Identifier) *ServerResponseError {
+	return NewServerResponseError(fmt.Errorf(
+		"operation cannot be fulfilled on %s \"%s\": the object has been modified; "+
+			"please apply your changes to the latest version and try again", plural, identifier.Name),
+		http.StatusConflict)
+}
+
+func newBadRequestError(err error) *ServerResponseError {
+	return NewServerResponseError(err, http.StatusBadRequest)
+}
+
+func newInvalidError(err error) *ServerResponseError {
+	return NewServerResponseError(err, http.StatusUnprocessableEntity)
+}
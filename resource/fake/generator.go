@@ -0,0 +1,55 @@
+package fake
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// NewClientGenerator returns a new ClientGenerator with empty storage
+func NewClientGenerator() *ClientGenerator {
+	return &ClientGenerator{
+		stores: make(map[schemaKey]*storage),
+	}
+}
+
+// ClientGenerator implements resource.ClientGenerator, and keeps in-memory storage for each Schema
+// (identified by group, version, and kind) it has created a Client for.
+// All Clients created by the same ClientGenerator share a single ResourceVersion sequence,
+// similar to how a kubernetes API server has a single ResourceVersion sequence for all resources.
+type ClientGenerator struct {
+	mux             sync.Mutex
+	stores          map[schemaKey]*storage
+	resourceVersion atomic.Uint64
+}
+
+type schemaKey struct {
+	group   string
+	version string
+	kind    string
+}
+
+// ClientFor returns a Client for the provided Schema. The returned Client shares its storage with all other
+// Clients returned by this ClientGenerator for a Schema with the same group, version, and kind.
+func (g *ClientGenerator) ClientFor(sch resource.Schema) (resource.Client, error) {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	key := schemaKey{
+		group:   sch.Group(),
+		version: sch.Version(),
+		kind:    sch.Kind(),
+	}
+	store, ok := g.stores[key]
+	if !ok {
+		store = newStorage(sch, &g.resourceVersion)
+		g.stores[key] = store
+	}
+	return &Client{
+		schema: sch,
+		store:  store,
+	}, nil
+}
+
+// Interface compliance compile-time check
+var _ resource.ClientGenerator = &ClientGenerator{}
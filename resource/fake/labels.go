@@ -0,0 +1,164 @@
+package fake
+
+import (
+	"fmt"
+	"strings"
+)
+
+type labelOperator string
+
+const (
+	labelOpEquals       = labelOperator("=")
+	labelOpNotEquals    = labelOperator("!=")
+	labelOpIn           = labelOperator("in")
+	labelOpNotIn        = labelOperator("notin")
+	labelOpExists       = labelOperator("exists")
+	labelOpDoesNotExist = labelOperator("!")
+)
+
+type labelRequirement struct {
+	key      string
+	operator labelOperator
+	values   []string
+}
+
+func (r labelRequirement) matches(labels map[string]string) bool {
+	val, ok := labels[r.key]
+	switch r.operator {
+	case labelOpEquals:
+		return ok && val == r.values[0]
+	case labelOpNotEquals:
+		return !ok || val != r.values[0]
+	case labelOpIn:
+		return ok && contains(r.values, val)
+	case labelOpNotIn:
+		return !ok || !contains(r.values, val)
+	case labelOpExists:
+		return ok
+	case labelOpDoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+// labelSelector is a set of label requirements, all of which must be satisfied for a set of labels to match
+type labelSelector []labelRequirement
+
+func (s labelSelector) matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLabelFilters parses a list of label filters into a labelSelector.
+// Each filter may contain multiple comma-separated requirements, using the kubernetes label selector syntax
+// (`key=value`, `key==value`, `key!=value`, `key in (a,b)`, `key notin (a,b)`, `key`, and `!key`).
+func parseLabelFilters(filters []string) (labelSelector, error) {
+	selector := make(labelSelector, 0)
+	for _, filter := range filters {
+		for _, part := range splitRequirements(filter) {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			req, err := parseLabelRequirement(part)
+			if err != nil {
+				return nil, err
+			}
+			selector = append(selector, req)
+		}
+	}
+	return selector, nil
+}
+
+// splitRequirements splits a filter on commas which are not inside of a parenthesized value set
+func splitRequirements(filter string) []string {
+	parts := make([]string, 0)
+	depth := 0
+	start := 0
+	for i, c := range filter {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, filter[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, filter[start:])
+}
+
+func parseLabelRequirement(req string) (labelRequirement, error) {
+	if strings.HasPrefix(req, "!") {
+		return labelRequirement{
+			key:      strings.TrimSpace(req[1:]),
+			operator: labelOpDoesNotExist,
+		}, nil
+	}
+	if idx := strings.Index(req, "!="); idx > 0 {
+		return labelRequirement{
+			key:      strings.TrimSpace(req[:idx]),
+			operator: labelOpNotEquals,
+			values:   []string{strings.TrimSpace(req[idx+2:])},
+		}, nil
+	}
+	if idx := strings.Index(req, "=="); idx > 0 {
+		return labelRequirement{
+			key:      strings.TrimSpace(req[:idx]),
+			operator: labelOpEquals,
+			values:   []string{strings.TrimSpace(req[idx+2:])},
+		}, nil
+	}
+	if idx := strings.Index(req, "="); idx > 0 {
+		return labelRequirement{
+			key:      strings.TrimSpace(req[:idx]),
+			operator: labelOpEquals,
+			values:   []string{strings.TrimSpace(req[idx+1:])},
+		}, nil
+	}
+	if open := strings.Index(req, "("); open > 0 {
+		if !strings.HasSuffix(req, ")") {
+			return labelRequirement{}, fmt.Errorf("invalid label filter '%s': missing closing parenthesis", req)
+		}
+		fields := strings.Fields(req[:open])
+		if len(fields) != 2 {
+			return labelRequirement{}, fmt.Errorf("invalid label filter '%s'", req)
+		}
+		op := labelOperator(fields[1])
+		if op != labelOpIn && op != labelOpNotIn {
+			return labelRequirement{}, fmt.Errorf("invalid label filter '%s': unknown operator '%s'", req, op)
+		}
+		values := strings.Split(req[open+1:len(req)-1], ",")
+		for i, v := range values {
+			values[i] = strings.TrimSpace(v)
+		}
+		return labelRequirement{
+			key:      fields[0],
+			operator: op,
+			values:   values,
+		}, nil
+	}
+	if strings.ContainsAny(req, " ()") {
+		return labelRequirement{}, fmt.Errorf("invalid label filter '%s'", req)
+	}
+	return labelRequirement{
+		key:      req,
+		operator: labelOpExists,
+	}, nil
+}
+
+func contains(list []string, val string) bool {
+	for _, v := range list {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabelFilters(t *testing.T) {
+	labels := map[string]string{
+		"app":  "grafana",
+		"tier": "backend",
+	}
+	tests := []struct {
+		filters []string
+		matches bool
+		err     bool
+	}{
+		{filters: nil, matches: true},
+		{filters: []string{"app=grafana"}, matches: true},
+		{filters: []string{"app==grafana"}, matches: true},
+		{filters: []string{"app=loki"}, matches: false},
+		{filters: []string{"app!=loki"}, matches: true},
+		{filters: []string{"app", "!env"}, matches: true},
+		{filters: []string{"env"}, matches: false},
+		{filters: []string{"app=grafana,tier=frontend"}, matches: false},
+		{filters: []string{"tier in (frontend, backend)"}, matches: true},
+		{filters: []string{"tier notin (frontend,backend),app"}, matches: false},
+		{filters: []string{"tier in (frontend"}, err: true},
+		{filters: []string{"tier within (frontend)"}, err: true},
+	}
+
+	for _, test := range tests {
+		selector, err := parseLabelFilters(test.filters)
+		if test.err {
+			assert.NotNil(t, err, "filters: %v", test.filters)
+			continue
+		}
+		require.Nil(t, err, "filters: %v", test.filters)
+		assert.Equal(t, test.matches, selector.matches(labels), "filters: %v", test.filters)
+	}
+}
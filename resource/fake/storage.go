@@ -0,0 +1,487 @@
+package fake
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+const (
+	metadataKey = "metadata"
+	specKey     = "spec"
+
+	// maxEventHistory is the number of events retained by a storage for watch requests which start
+	// from a specific resource version. Watches starting from an older resource version are rejected.
+	maxEventHistory = 1000
+)
+
+type storedObject struct {
+	data   []byte
+	labels map[string]string
+}
+
+type storedEvent struct {
+	resourceVersion uint64
+	eventType       string
+	identifier      resource.Identifier
+	object          storedObject
+}
+
+// storage is the in-memory storage for a single Schema. Objects are stored as JSON documents
+// of the form {"metadata":{...},"spec":{...},"<subresource>":{...}}, where metadata contains all CommonMetadata
+// fields along with any CustomMetadata fields. This is also the document that JSON patches and merge patches are applied against.
+type storage struct {
+	schema          resource.Schema
+	resourceVersion *atomic.Uint64
+
+	mux       sync.Mutex
+	objects   map[resource.Identifier]storedObject
+	history   []storedEvent
+	compacted uint64
+	watchers  map[*WatchResponse]struct{}
+	// fieldManagers tracks the field managers which own each applied field of each object
+	fieldManagers map[resource.Identifier]managedFields
+}
+
+func newStorage(sch resource.Schema, resourceVersion *atomic.Uint64) *storage {
+	return &storage{
+		schema:          sch,
+		resourceVersion: resourceVersion,
+		objects:         make(map[resource.Identifier]storedObject),
+		history:         make([]storedEvent, 0),
+		watchers:        make(map[*WatchResponse]struct{}),
+		fieldManagers:   make(map[resource.Identifier]managedFields),
+	}
+}
+
+func (s *storage) get(identifier resource.Identifier) ([]byte, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	stored, ok := s.objects[identifier]
+	if !ok {
+		return nil, newNotFoundError(s.schema.Plural(), identifier)
+	}
+	return stored.data, nil
+}
+
+func (s *storage) create(identifier resource.Identifier, doc map[string]any) ([]byte, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if _, ok := s.objects[identifier]; ok {
+		return nil, newAlreadyExistsError(s.schema.Plural(), identifier)
+	}
+	return s.insert(identifier, doc)
+}
+
+// insert stores a new document, setting all server-controlled metadata.
+// insert must be called while holding the storage lock.
+func (s *storage) insert(identifier resource.Identifier, doc map[string]any) ([]byte, error) {
+	rv := s.resourceVersion.Add(1)
+	meta := metadataOf(doc)
+	meta["uid"] = newUID()
+	meta["resourceVersion"] = strconv.FormatUint(rv, 10)
+	meta["generation"] = 1
+	meta["creationTimestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+	meta["deletionTimestamp"] = nil
+	stored, err := toStoredObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	s.objects[identifier] = stored
+	s.emit(rv, WatchEventTypeAdded, identifier, stored)
+	return stored.data, nil
+}
+
+func (s *storage) update(identifier resource.Identifier, doc map[string]any, options resource.UpdateOptions) (
+	[]byte, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	existing, err := s.getDocument(identifier)
+	if err != nil {
+		return nil, err
+	}
+	if options.ResourceVersion != "" && options.ResourceVersion != metadataOf(existing)["resourceVersion"] {
+		return nil, newConflictError(s.schema.Plural(), identifier)
+	}
+
+	if options.Subresource != "" {
+		// Only the subresource is updated, the rest of the object remains the same
+		updated, err := toGeneric(existing)
+		if err != nil {
+			return nil, err
+		}
+		updatedDoc := updated.(map[string]any)
+		if sr, ok := doc[options.Subresource]; ok && sr != nil {
+			updatedDoc[options.Subresource] = sr
+		} else {
+			delete(updatedDoc, options.Subresource)
+		}
+		return s.commit(identifier, existing, updatedDoc)
+	}
+
+	// Subresources can only be updated via the subresource, so we retain the existing ones
+	for k := range doc {
+		if k != metadataKey && k != specKey {
+			delete(doc, k)
+		}
+	}
+	for k, v := range existing {
+		if k != metadataKey && k != specKey {
+			doc[k] = v
+		}
+	}
+	return s.commit(identifier, existing, doc)
+}
+
+func (s *storage) patch(identifier resource.Identifier, patch resource.PatchRequest, options resource.PatchOptions) (
+	[]byte, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	existing, err := s.getDocument(identifier)
+	if err != nil {
+		return nil, err
+	}
+	// Get a second copy of the document to patch, as patches are applied in-place
+	toPatch, err := s.getDocument(identifier)
+	if err != nil {
+		return nil, err
+	}
+	var patched any
+	switch patch.Type {
+	case "", resource.PatchTypeJSONPatch, resource.PatchTypeMergePatch:
+		patched, err = resource.PatchDocument(toPatch, patch)
+	default:
+		return nil, newBadRequestError(fmt.Errorf("unsupported patch type '%s'", patch.Type))
+	}
+	if err != nil {
+		return nil, newInvalidError(err)
+	}
+	doc, ok := patched.(map[string]any)
+	if !ok {
+		return nil, newInvalidError(fmt.Errorf("patched object must be a JSON object"))
+	}
+	if _, ok := doc[metadataKey].(map[string]any); !ok {
+		return nil, newInvalidError(fmt.Errorf("patched object metadata must be a JSON object"))
+	}
+	// A resourceVersion in the patched object acts as a precondition, as it does in kubernetes
+	if rv, ok := metadataOf(doc)["resourceVersion"]; ok && rv != "" && rv != metadataOf(existing)["resourceVersion"] {
+		return nil, newConflictError(s.schema.Plural(), identifier)
+	}
+	// As with updates, only the targeted subresource (or, for the main object, metadata and spec) is changed
+	retained, err := toGeneric(existing)
+	if err != nil {
+		return nil, err
+	}
+	updated := retained.(map[string]any)
+	if options.Subresource != "" {
+		if sr, ok := doc[options.Subresource]; ok && sr != nil {
+			updated[options.Subresource] = sr
+		} else {
+			delete(updated, options.Subresource)
+		}
+	} else {
+		updated[metadataKey] = doc[metadataKey]
+		if sp, ok := doc[specKey]; ok {
+			updated[specKey] = sp
+		} else {
+			delete(updated, specKey)
+		}
+	}
+	return s.commit(identifier, existing, updated)
+}
+
+func (s *storage) delete(identifier resource.Identifier) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	existing, err := s.getDocument(identifier)
+	if err != nil {
+		return err
+	}
+	meta := metadataOf(existing)
+	if finalizers, ok := meta["finalizers"].([]any); ok && len(finalizers) > 0 {
+		// Objects with finalizers are only marked for deletion, and are removed once all finalizers are removed
+		if meta["deletionTimestamp"] != nil {
+			return nil
+		}
+		meta["deletionTimestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+		rv := s.resourceVersion.Add(1)
+		meta["resourceVersion"] = strconv.FormatUint(rv, 10)
+		stored, err := toStoredObject(existing)
+		if err != nil {
+			return err
+		}
+		s.objects[identifier] = stored
+		s.emit(rv, WatchEventTypeModified, identifier, stored)
+		return nil
+	}
+	_, err = s.remove(identifier, existing)
+	return err
+}
+
+// commit stores an updated version of an existing document, setting all server-controlled metadata.
+// If the updated document has a deletion timestamp and no finalizers, it is removed instead.
+// commit must be called while holding the storage lock.
+func (s *storage) commit(identifier resource.Identifier, existing, updated map[string]any) ([]byte, error) {
+	oldMeta := metadataOf(existing)
+	meta := metadataOf(updated)
+	meta["uid"] = oldMeta["uid"]
+	meta["creationTimestamp"] = oldMeta["creationTimestamp"]
+	meta["deletionTimestamp"] = oldMeta["deletionTimestamp"]
+	meta["generation"] = oldMeta["generation"]
+	oldSpec, _ := json.Marshal(existing[specKey])
+	newSpec, _ := json.Marshal(updated[specKey])
+	if !bytes.Equal(oldSpec, newSpec) {
+		if gen, ok := oldMeta["generation"].(float64); ok {
+			meta["generation"] = gen + 1
+		}
+	}
+	if finalizers, ok := meta["finalizers"].([]any); meta["deletionTimestamp"] != nil && (!ok || len(finalizers) == 0) {
+		return s.remove(identifier, updated)
+	}
+	rv := s.resourceVersion.Add(1)
+	meta["resourceVersion"] = strconv.FormatUint(rv, 10)
+	stored, err := toStoredObject(updated)
+	if err != nil {
+		return nil, err
+	}
+	s.objects[identifier] = stored
+	s.emit(rv, WatchEventTypeModified, identifier, stored)
+	return stored.data, nil
+}
+
+// remove removes an object from storage, and must be called while holding the storage lock
+func (s *storage) remove(identifier resource.Identifier, doc map[string]any) ([]byte, error) {
+	rv := s.resourceVersion.Add(1)
+	metadataOf(doc)["resourceVersion"] = strconv.FormatUint(rv, 10)
+	stored, err := toStoredObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.objects, identifier)
+	delete(s.fieldManagers, identifier)
+	s.emit(rv, WatchEventTypeDeleted, identifier, stored)
+	return stored.data, nil
+}
+
+type listResult struct {
+	identifiers []resource.Identifier
+	items       [][]byte
+	metadata    resource.ListMetadata
+}
+
+func (s *storage) list(namespace string, options resource.ListOptions) (*listResult, error) {
+	selector, err := parseLabelFilters(options.LabelFilters)
+	if err != nil {
+		return nil, newBadRequestError(err)
+	}
+	var after *resource.Identifier
+	if options.Continue != "" {
+		after, err = decodeContinue(options.Continue)
+		if err != nil {
+			return nil, newBadRequestError(err)
+		}
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	result := &listResult{
+		identifiers: make([]resource.Identifier, 0),
+		items:       make([][]byte, 0),
+		metadata: resource.ListMetadata{
+			ResourceVersion: strconv.FormatUint(s.resourceVersion.Load(), 10),
+		},
+	}
+	remaining := int64(0)
+	for _, id := range s.sortedIdentifiers() {
+		if namespace != resource.NamespaceAll && id.Namespace != namespace {
+			continue
+		}
+		if after != nil && !identifierLess(*after, id) {
+			continue
+		}
+		stored := s.objects[id]
+		if !selector.matches(stored.labels) {
+			continue
+		}
+		if options.Limit > 0 && len(result.items) >= options.Limit {
+			remaining++
+			continue
+		}
+		result.identifiers = append(result.identifiers, id)
+		result.items = append(result.items, stored.data)
+	}
+	if remaining > 0 {
+		result.metadata.Continue = encodeContinue(result.identifiers[len(result.identifiers)-1])
+		result.metadata.RemainingItemCount = &remaining
+	}
+	return result, nil
+}
+
+func (s *storage) watch(namespace string, options resource.WatchOptions) (*WatchResponse, error) {
+	selector, err := parseLabelFilters(options.LabelFilters)
+	if err != nil {
+		return nil, newBadRequestError(err)
+	}
+	var since uint64
+	if options.ResourceVersion != "" {
+		since, err = strconv.ParseUint(options.ResourceVersion, 10, 64)
+		if err != nil {
+			return nil, newBadRequestError(fmt.Errorf("invalid resource version '%s'", options.ResourceVersion))
+		}
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if since > 0 && since < s.compacted {
+		return nil, NewServerResponseError(
+			fmt.Errorf("too old resource version: %d (%d)", since, s.compacted), http.StatusGone)
+	}
+	w := newWatchResponse(namespace, selector, options.EventBufferSize, s.stopWatch)
+	if since == 0 {
+		// Like kubernetes, a watch without a resource version starts with synthetic ADDED events for all objects
+		for _, id := range s.sortedIdentifiers() {
+			stored := s.objects[id]
+			if !w.matches(id, stored.labels) {
+				continue
+			}
+			s.notify(w, WatchEventTypeAdded, id, stored)
+		}
+	} else {
+		for _, evt := range s.history {
+			if evt.resourceVersion > since && w.matches(evt.identifier, evt.object.labels) {
+				s.notify(w, evt.eventType, evt.identifier, evt.object)
+			}
+		}
+	}
+	s.watchers[w] = struct{}{}
+	return w, nil
+}
+
+func (s *storage) stopWatch(w *WatchResponse) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.watchers, w)
+}
+
+// emit records an event and sends it to all interested watchers. It must be called while holding the storage lock.
+func (s *storage) emit(rv uint64, eventType string, identifier resource.Identifier, stored storedObject) {
+	s.history = append(s.history, storedEvent{
+		resourceVersion: rv,
+		eventType:       eventType,
+		identifier:      identifier,
+		object:          stored,
+	})
+	if len(s.history) > maxEventHistory {
+		s.compacted = s.history[0].resourceVersion
+		s.history = s.history[1:]
+	}
+	for w := range s.watchers {
+		if w.matches(identifier, stored.labels) {
+			s.notify(w, eventType, identifier, stored)
+		}
+	}
+}
+
+func (s *storage) notify(w *WatchResponse, eventType string, identifier resource.Identifier, stored storedObject) {
+	obj := s.schema.ZeroValue()
+	if err := documentToObject(stored.data, identifier, s.schema, obj); err != nil {
+		// Documents are always created from valid objects, so this should not happen
+		return
+	}
+	w.push(resource.WatchEvent{
+		EventType: eventType,
+		Object:    obj,
+	})
+}
+
+// getDocument returns a freshly-unmarshaled copy of a stored document.
+// It must be called while holding the storage lock.
+func (s *storage) getDocument(identifier resource.Identifier) (map[string]any, error) {
+	stored, ok := s.objects[identifier]
+	if !ok {
+		return nil, newNotFoundError(s.schema.Plural(), identifier)
+	}
+	doc := make(map[string]any)
+	err := json.Unmarshal(stored.data, &doc)
+	return doc, err
+}
+
+func (s *storage) sortedIdentifiers() []resource.Identifier {
+	ids := make([]resource.Identifier, 0, len(s.objects))
+	for id := range s.objects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return identifierLess(ids[i], ids[j])
+	})
+	return ids
+}
+
+func identifierLess(a, b resource.Identifier) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+func encodeContinue(last resource.Identifier) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(last.Namespace + "/" + last.Name))
+}
+
+func decodeContinue(token string) (*resource.Identifier, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid continue token")
+	}
+	idx := bytes.IndexByte(decoded, '/')
+	if idx < 0 {
+		return nil, fmt.Errorf("invalid continue token")
+	}
+	return &resource.Identifier{
+		Namespace: string(decoded[:idx]),
+		Name:      string(decoded[idx+1:]),
+	}, nil
+}
+
+func toStoredObject(doc map[string]any) (storedObject, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return storedObject{}, err
+	}
+	labels := make(map[string]string)
+	if l, ok := metadataOf(doc)["labels"].(map[string]any); ok {
+		for k, v := range l {
+			labels[k] = fmt.Sprint(v)
+		}
+	}
+	return storedObject{
+		data:   data,
+		labels: labels,
+	}, nil
+}
+
+// metadataOf returns the metadata map of a document, creating it if it does not exist
+func metadataOf(doc map[string]any) map[string]any {
+	meta, ok := doc[metadataKey].(map[string]any)
+	if !ok {
+		meta = make(map[string]any)
+		doc[metadataKey] = meta
+	}
+	return meta
+}
+
+func newUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,109 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+func TestStore(t *testing.T) {
+	group := resource.NewSimpleSchemaGroup("test.grafana.com", "v1")
+	sch := group.AddSchema(&resource.SimpleObject[testSpec]{}, resource.WithKind("Test"))
+	store := resource.NewStore(NewClientGenerator(), group)
+	ctx := context.Background()
+
+	obj := newTestObject("bar", map[string]string{"a": "b"})
+	obj.SetStaticMetadata(resource.StaticMetadata{Kind: sch.Kind(), Namespace: "ns", Name: "foo"})
+	added, err := store.Add(ctx, obj)
+	require.Nil(t, err)
+
+	upd := added.Copy().(*resource.SimpleObject[testSpec])
+	upd.Spec.Bar = 1
+	updated, err := store.Update(ctx, upd)
+	require.Nil(t, err)
+	assert.Equal(t, testSpec{Foo: "bar", Bar: 1}, updated.SpecObject())
+
+	// Update with the now-stale object fails
+	_, err = store.Update(ctx, upd)
+	assertStatusCode(t, http.StatusConflict, err)
+
+	upserted, err := store.Upsert(ctx, updated)
+	require.Nil(t, err)
+	assert.Equal(t, updated.SpecObject(), upserted.SpecObject())
+
+	_, err = store.UpdateSubresource(ctx, sch.Kind(), added.StaticMetadata().Identifier(), resource.SubresourceStatus,
+		map[string]string{"state": "ok"})
+	require.Nil(t, err)
+
+	list, err := store.List(ctx, sch.Kind(), "ns", resource.ListOptions{LabelFilters: []string{"a=b"}})
+	require.Nil(t, err)
+	require.Len(t, list.ListItems(), 1)
+	assert.Contains(t, list.ListItems()[0].Subresources(), "status")
+
+	require.Nil(t, store.Delete(ctx, sch.Kind(), added.StaticMetadata().Identifier()))
+	assert.Nil(t, store.ForceDelete(ctx, sch.Kind(), added.StaticMetadata().Identifier()))
+}
+
+func TestTypedStore(t *testing.T) {
+	store, err := resource.NewTypedStore[*resource.SimpleObject[testSpec]](testSchema, NewClientGenerator())
+	require.Nil(t, err)
+	ctx := context.Background()
+	id := resource.Identifier{Namespace: "ns", Name: "foo"}
+
+	obj := newTestObject("bar", nil)
+	obj.SetStaticMetadata(resource.StaticMetadata{Namespace: id.Namespace, Name: id.Name})
+	added, err := store.Add(ctx, obj)
+	require.Nil(t, err)
+	assert.Equal(t, "bar", added.Spec.Foo)
+
+	added.Spec.Foo = "baz"
+	updated, err := store.Upsert(ctx, id, added)
+	require.Nil(t, err)
+	assert.Equal(t, "baz", updated.Spec.Foo)
+
+	got, err := store.Get(ctx, id)
+	require.Nil(t, err)
+	assert.Equal(t, updated, got)
+
+	list, err := store.List(ctx, "ns")
+	require.Nil(t, err)
+	assert.Equal(t, []*resource.SimpleObject[testSpec]{got}, list.Items)
+
+	require.Nil(t, store.Delete(ctx, id))
+	_, err = store.Get(ctx, id)
+	assertStatusCode(t, http.StatusNotFound, err)
+}
+
+func TestSimpleStore(t *testing.T) {
+	store, err := resource.NewSimpleStore[testSpec](testSchema, NewClientGenerator())
+	require.Nil(t, err)
+	ctx := context.Background()
+	id := resource.Identifier{Namespace: "ns", Name: "foo"}
+
+	added, err := store.Add(ctx, id, testSpec{Foo: "bar"}, resource.WithLabel("a", "b"))
+	require.Nil(t, err)
+	assert.Equal(t, testSpec{Foo: "bar"}, added.Spec)
+
+	_, err = store.Update(ctx, id, testSpec{Foo: "baz"}, resource.WithResourceVersion("1234"))
+	assertStatusCode(t, http.StatusConflict, err)
+
+	updated, err := store.Update(ctx, id, testSpec{Foo: "baz"}, resource.WithResourceVersion(added.CommonMetadata.ResourceVersion))
+	require.Nil(t, err)
+	assert.Equal(t, testSpec{Foo: "baz"}, updated.Spec)
+	assert.Equal(t, map[string]string{"a": "b"}, updated.CommonMetadata.Labels)
+
+	list, err := store.List(ctx, "ns", "a=b")
+	require.Nil(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, updated.Spec, list[0].Spec)
+
+	require.Nil(t, store.Delete(ctx, id))
+	list, err = store.List(ctx, "ns")
+	require.Nil(t, err)
+	assert.Len(t, list, 0)
+}
@@ -0,0 +1,108 @@
+package fake
+
+import (
+	"sync"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// Watch event types, matching those used by kubernetes
+const (
+	WatchEventTypeAdded    = "ADDED"
+	WatchEventTypeModified = "MODIFIED"
+	WatchEventTypeDeleted  = "DELETED"
+)
+
+// WatchResponse implements resource.WatchResponse for the in-memory Client.
+// Events are queued internally as they happen, so a slow consumer of WatchEvents() never blocks writes to the store.
+type WatchResponse struct {
+	namespace string
+	selector  labelSelector
+
+	mux      sync.Mutex
+	queue    []resource.WatchEvent
+	signal   chan struct{}
+	ch       chan resource.WatchEvent
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	onStop   func(*WatchResponse)
+}
+
+func newWatchResponse(namespace string, selector labelSelector, bufferSize int,
+	onStop func(*WatchResponse)) *WatchResponse {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	w := &WatchResponse{
+		namespace: namespace,
+		selector:  selector,
+		queue:     make([]resource.WatchEvent, 0),
+		signal:    make(chan struct{}, 1),
+		ch:        make(chan resource.WatchEvent, bufferSize),
+		stopCh:    make(chan struct{}),
+		onStop:    onStop,
+	}
+	go w.start()
+	return w
+}
+
+// Stop stops the watch. The channel returned by WatchEvents() is closed once the watch has stopped.
+// Calling Stop more than once has no effect.
+func (w *WatchResponse) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+		if w.onStop != nil {
+			w.onStop(w)
+		}
+	})
+}
+
+// WatchEvents returns a channel that receives watch events.
+// All calls to this method will return the same channel.
+func (w *WatchResponse) WatchEvents() <-chan resource.WatchEvent {
+	return w.ch
+}
+
+func (w *WatchResponse) matches(identifier resource.Identifier, labels map[string]string) bool {
+	if w.namespace != resource.NamespaceAll && w.namespace != identifier.Namespace {
+		return false
+	}
+	return w.selector.matches(labels)
+}
+
+func (w *WatchResponse) push(evt resource.WatchEvent) {
+	w.mux.Lock()
+	w.queue = append(w.queue, evt)
+	w.mux.Unlock()
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (w *WatchResponse) start() {
+	defer close(w.ch)
+	for {
+		w.mux.Lock()
+		if len(w.queue) == 0 {
+			w.mux.Unlock()
+			select {
+			case <-w.signal:
+				continue
+			case <-w.stopCh:
+				return
+			}
+		}
+		evt := w.queue[0]
+		w.queue = w.queue[1:]
+		w.mux.Unlock()
+		select {
+		case w.ch <- evt:
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Interface compliance compile-time check
+var _ resource.WatchResponse = &WatchResponse{}
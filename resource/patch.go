@@ -0,0 +1,239 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// NewMergePatchFromDiff creates a JSON merge patch PatchRequest which sets the fields of `field` (such as "spec",
+// or a subresource name) that differ between original and modified, and removes the fields that are present in original
+// but absent from modified. Fields which are the same in both are not included in the patch.
+// If field is a subresource, the patch must be made with the subresource set in PatchOptions.
+//
+// Lists are replaced in their entirety if they differ, as per JSON merge patch semantics (RFC7386).
+func NewMergePatchFromDiff[T any](field string, original, modified T) (PatchRequest, error) {
+	if field == "" {
+		return PatchRequest{}, fmt.Errorf("field cannot be empty")
+	}
+	origGeneric, err := toGenericJSON(original)
+	if err != nil {
+		return PatchRequest{}, fmt.Errorf("unable to marshal original: %w", err)
+	}
+	modGeneric, err := toGenericJSON(modified)
+	if err != nil {
+		return PatchRequest{}, fmt.Errorf("unable to marshal modified: %w", err)
+	}
+	diff, changed := mergePatchDiff(origGeneric, modGeneric)
+	patch := make(map[string]any)
+	if changed {
+		patch[field] = diff
+	}
+	doc, err := json.Marshal(patch)
+	if err != nil {
+		return PatchRequest{}, err
+	}
+	return PatchRequest{
+		Type:       PatchTypeMergePatch,
+		MergePatch: doc,
+	}, nil
+}
+
+// mergePatchDiff returns the merge patch which turns original into modified, and whether there is any difference.
+func mergePatchDiff(original, modified any) (any, bool) {
+	origObj, origIsObj := original.(map[string]any)
+	modObj, modIsObj := modified.(map[string]any)
+	if !origIsObj || !modIsObj {
+		// A non-object value is replaced as a whole
+		return modified, !reflect.DeepEqual(original, modified)
+	}
+	diff := make(map[string]any)
+	for k, mv := range modObj {
+		ov, ok := origObj[k]
+		if !ok {
+			diff[k] = mv
+			continue
+		}
+		if d, changed := mergePatchDiff(ov, mv); changed {
+			diff[k] = d
+		}
+	}
+	for k := range origObj {
+		if _, ok := modObj[k]; !ok {
+			// A null value removes the field
+			diff[k] = nil
+		}
+	}
+	return diff, len(diff) > 0
+}
+
+func toGenericJSON(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	err = json.Unmarshal(b, &generic)
+	return generic, err
+}
+
+// NewPatchFromDiff compares original and modified, and returns a JSON Patch PatchRequest with the operations
+// required to turn original into modified. The spec, subresources, CommonMetadata Labels and Finalizers,
+// and CustomMetadata of the objects are compared; all other metadata is ignored.
+// Objects are compared field-by-field, but lists are replaced in their entirety if they differ.
+//
+// If original has a ResourceVersion, the first operation is a "test" of the resourceVersion,
+// so the patch will fail if the object has been changed in storage since original was retrieved.
+// If there are no differences between original and modified, the returned PatchRequest has no operations.
+//
+// As with any patch, operations on a subresource are only applied when the patch is made to that subresource
+// (using the Subresource in PatchOptions), and all other operations are only applied when it is not.
+func NewPatchFromDiff(original, modified Object) (PatchRequest, error) {
+	if original == nil || modified == nil {
+		return PatchRequest{}, fmt.Errorf("original and modified cannot be nil")
+	}
+	ops := make([]PatchOperation, 0)
+
+	// Metadata
+	ops = append(ops, diffLabels(original.CommonMetadata().Labels, modified.CommonMetadata().Labels)...)
+	origFinalizers, modFinalizers := original.CommonMetadata().Finalizers, modified.CommonMetadata().Finalizers
+	if len(origFinalizers) == 0 && len(modFinalizers) > 0 {
+		ops = append(ops, PatchOperation{Operation: PatchOpAdd, Path: "/metadata/finalizers", Value: modFinalizers})
+	} else if len(origFinalizers) > 0 && len(modFinalizers) == 0 {
+		ops = append(ops, PatchOperation{Operation: PatchOpRemove, Path: "/metadata/finalizers"})
+	} else if !reflect.DeepEqual(origFinalizers, modFinalizers) {
+		ops = append(ops, PatchOperation{Operation: PatchOpReplace, Path: "/metadata/finalizers", Value: modFinalizers})
+	}
+	origCustom, modCustom := customMetadataFields(original), customMetadataFields(modified)
+	// Custom metadata fields are compared as a whole, as they may be stored as flat strings
+	customOps, err := diffJSONPatch("/metadata", origCustom, modCustom, false)
+	if err != nil {
+		return PatchRequest{}, fmt.Errorf("unable to compare custom metadata: %w", err)
+	}
+	ops = append(ops, customOps...)
+
+	// Spec
+	specOps, err := diffJSONPatch("", map[string]any{"spec": original.SpecObject()}, map[string]any{"spec": modified.SpecObject()}, true)
+	if err != nil {
+		return PatchRequest{}, fmt.Errorf("unable to compare spec: %w", err)
+	}
+	ops = append(ops, specOps...)
+
+	// Subresources
+	subresourceOps, err := diffJSONPatch("", original.Subresources(), modified.Subresources(), true)
+	if err != nil {
+		return PatchRequest{}, fmt.Errorf("unable to compare subresources: %w", err)
+	}
+	ops = append(ops, subresourceOps...)
+
+	if len(ops) > 0 && original.CommonMetadata().ResourceVersion != "" {
+		ops = append([]PatchOperation{{
+			Operation: PatchOpTest,
+			Path:      "/metadata/resourceVersion",
+			Value:     original.CommonMetadata().ResourceVersion,
+		}}, ops...)
+	}
+	return PatchRequest{
+		Type:       PatchTypeJSONPatch,
+		Operations: ops,
+	}, nil
+}
+
+// diffLabels returns the patch operations to turn the original labels into the modified labels.
+// Individual labels are only added or removed if the labels map exists in original, as an add requires the parent to exist.
+func diffLabels(original, modified map[string]string) []PatchOperation {
+	if len(original) == 0 && len(modified) == 0 {
+		return nil
+	}
+	if len(original) == 0 {
+		return []PatchOperation{{Operation: PatchOpAdd, Path: "/metadata/labels", Value: modified}}
+	}
+	if len(modified) == 0 {
+		return []PatchOperation{{Operation: PatchOpRemove, Path: "/metadata/labels"}}
+	}
+	ops := make([]PatchOperation, 0)
+	for _, k := range sortedKeys(original) {
+		if _, ok := modified[k]; !ok {
+			ops = append(ops, PatchOperation{Operation: PatchOpRemove, Path: "/metadata/labels/" + EscapePointerToken(k)})
+		}
+	}
+	for _, k := range sortedKeys(modified) {
+		if ov, ok := original[k]; !ok {
+			ops = append(ops, PatchOperation{Operation: PatchOpAdd, Path: "/metadata/labels/" + EscapePointerToken(k), Value: modified[k]})
+		} else if ov != modified[k] {
+			ops = append(ops, PatchOperation{Operation: PatchOpReplace, Path: "/metadata/labels/" + EscapePointerToken(k), Value: modified[k]})
+		}
+	}
+	return ops
+}
+
+func customMetadataFields(obj Object) map[string]any {
+	if obj.CustomMetadata() == nil {
+		return map[string]any{}
+	}
+	return obj.CustomMetadata().MapFields()
+}
+
+// diffJSONPatch returns the JSON Patch operations to turn original into modified, where both are objects whose
+// keys are directly under the JSON pointer prefix. Values are compared by their JSON representations.
+// If recurse is false, differing values are always replaced as a whole, rather than compared field-by-field.
+func diffJSONPatch[T any](prefix string, original, modified map[string]T, recurse bool) ([]PatchOperation, error) {
+	origGeneric := make(map[string]any, len(original))
+	for k, v := range original {
+		g, err := toGenericJSON(v)
+		if err != nil {
+			return nil, err
+		}
+		origGeneric[k] = g
+	}
+	modGeneric := make(map[string]any, len(modified))
+	for k, v := range modified {
+		g, err := toGenericJSON(v)
+		if err != nil {
+			return nil, err
+		}
+		modGeneric[k] = g
+	}
+	return diffObjects(prefix, origGeneric, modGeneric, recurse), nil
+}
+
+func diffObjects(prefix string, original, modified map[string]any, recurse bool) []PatchOperation {
+	ops := make([]PatchOperation, 0)
+	for _, k := range sortedKeys(original) {
+		if _, ok := modified[k]; !ok {
+			ops = append(ops, PatchOperation{Operation: PatchOpRemove, Path: prefix + "/" + EscapePointerToken(k)})
+		}
+	}
+	for _, k := range sortedKeys(modified) {
+		path := prefix + "/" + EscapePointerToken(k)
+		ov, ok := original[k]
+		if !ok {
+			ops = append(ops, PatchOperation{Operation: PatchOpAdd, Path: path, Value: modified[k]})
+			continue
+		}
+		origObj, origIsObj := ov.(map[string]any)
+		modObj, modIsObj := modified[k].(map[string]any)
+		if recurse && origIsObj && modIsObj {
+			ops = append(ops, diffObjects(path, origObj, modObj, recurse)...)
+		} else if !reflect.DeepEqual(ov, modified[k]) {
+			ops = append(ops, PatchOperation{Operation: PatchOpReplace, Path: path, Value: modified[k]})
+		}
+	}
+	return ops
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// EscapePointerToken escapes a key for use as a reference token in an RFC6901 JSON pointer, such as a PatchOperation Path
+func EscapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
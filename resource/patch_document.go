@@ -0,0 +1,341 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PatchDocument applies patch to doc, which is a generic JSON value (as produced by json.Unmarshal into an `any`),
+// and returns the patched document. The patch is applied with RFC6902 JSON Patch semantics if its Type is
+// PatchTypeJSONPatch (or empty), and RFC7386 JSON Merge Patch semantics if its Type is PatchTypeMergePatch.
+// doc is modified in-place where possible, so it should be discarded if an error is returned.
+func PatchDocument(doc any, patch PatchRequest) (any, error) {
+	switch patch.Type {
+	case "", PatchTypeJSONPatch:
+		return applyJSONPatch(doc, patch)
+	case PatchTypeMergePatch:
+		return applyMergePatch(doc, patch)
+	default:
+		return nil, fmt.Errorf("unsupported patch type '%s'", patch.Type)
+	}
+}
+
+// ApplyPatch applies patch to a copy of obj, and returns the patched copy, without making any request to storage.
+// As with a Client's Patch, the patch is applied to a document of the form
+// {"metadata":{...},"spec":{...},"<subresource>":{...}}, where metadata keys are the JSON keys of CommonMetadata
+// and CustomMetadata. If options.Subresource is set, only changes to that subresource are kept,
+// otherwise only changes to the metadata and spec are kept.
+// CustomMetadata is only changed if obj's Unmarshal method sets it from ObjectBytes.Metadata.
+func ApplyPatch(obj Object, patch PatchRequest, options PatchOptions) (Object, error) {
+	original, err := ObjectDocument(obj)
+	if err != nil {
+		return nil, err
+	}
+	// Get a second copy of the document to patch, as patches are applied in-place
+	toPatch, err := ObjectDocument(obj)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := PatchDocument(toPatch, patch)
+	if err != nil {
+		return nil, err
+	}
+	doc, ok := patched.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("patched object must be a JSON object")
+	}
+	if options.Subresource != "" {
+		original[options.Subresource] = doc[options.Subresource]
+	} else {
+		original["metadata"] = doc["metadata"]
+		original["spec"] = doc["spec"]
+	}
+
+	raw := make(map[string][]byte, len(original))
+	for k, v := range original {
+		if raw[k], err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	bytes := ObjectBytes{
+		Spec:         raw["spec"],
+		Metadata:     raw["metadata"],
+		Subresources: make(map[string][]byte),
+	}
+	for k, v := range raw {
+		if k != "metadata" && k != "spec" {
+			bytes.Subresources[k] = v
+		}
+	}
+	cmd := CommonMetadata{}
+	if err = json.Unmarshal(bytes.Metadata, &cmd); err != nil {
+		return nil, fmt.Errorf("patched object metadata is invalid: %w", err)
+	}
+
+	updated := newObjectOfType(obj)
+	if err = updated.Unmarshal(bytes, UnmarshalConfig{
+		WireFormat:  WireFormatJSON,
+		VersionHint: obj.StaticMetadata().Version,
+	}); err != nil {
+		return nil, err
+	}
+	updated.SetStaticMetadata(obj.StaticMetadata())
+	updated.SetCommonMetadata(cmd)
+	return updated, nil
+}
+
+// newObjectOfType returns a new, empty object of the same type as obj.
+// A new object is used rather than obj.Copy(), as Copy may be shallow,
+// and unmarshaling into a shallow copy can modify maps and slices shared with obj.
+func newObjectOfType(obj Object) Object {
+	typ := reflect.TypeOf(obj)
+	if typ.Kind() == reflect.Pointer && typ.Elem().Kind() == reflect.Struct {
+		if cast, ok := reflect.New(typ.Elem()).Interface().(Object); ok {
+			return cast
+		}
+	}
+	return obj.Copy()
+}
+
+// ObjectDocument converts obj into the generic JSON document that patches are applied to by ApplyPatch,
+// of the form {"metadata":{...},"spec":{...},"<subresource>":{...}}, where metadata keys are the JSON keys
+// of CommonMetadata and CustomMetadata.
+func ObjectDocument(obj Object) (map[string]any, error) {
+	meta, err := toGenericJSON(obj.CommonMetadata())
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal metadata: %w", err)
+	}
+	metaMap, _ := meta.(map[string]any)
+	if metaMap == nil {
+		metaMap = make(map[string]any)
+	}
+	for k, v := range customMetadataFields(obj) {
+		if metaMap[k], err = toGenericJSON(v); err != nil {
+			return nil, fmt.Errorf("unable to marshal custom metadata field '%s': %w", k, err)
+		}
+	}
+	spec, err := toGenericJSON(obj.SpecObject())
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal spec: %w", err)
+	}
+	doc := map[string]any{
+		"metadata": metaMap,
+		"spec":     spec,
+	}
+	for k, v := range obj.Subresources() {
+		if doc[k], err = toGenericJSON(v); err != nil {
+			return nil, fmt.Errorf("unable to marshal subresource '%s': %w", k, err)
+		}
+	}
+	return doc, nil
+}
+
+// applyJSONPatch applies the RFC6902 operations in patch to doc, returning the patched document.
+// doc is expected to be a generic JSON value (as produced by json.Unmarshal into an `any`), and is modified in-place
+// where possible. If any operation fails, the returned error describes the failing operation,
+// and the contents of doc should be discarded.
+func applyJSONPatch(doc any, patch PatchRequest) (any, error) {
+	var err error
+	for idx, op := range patch.Operations {
+		var value any
+		if op.Operation == PatchOpAdd || op.Operation == PatchOpReplace ||
+			op.Operation == PatchOpTest {
+			value, err = toGenericJSON(op.Value)
+			if err != nil {
+				return nil, fmt.Errorf("operation %d: unable to convert value: %w", idx, err)
+			}
+		}
+		path, err := parsePointer(op.Path)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", idx, err)
+		}
+		switch op.Operation {
+		case PatchOpAdd:
+			doc, err = addValue(doc, path, value)
+		case PatchOpRemove:
+			doc, _, err = removeValue(doc, path)
+		case PatchOpReplace:
+			if doc, _, err = removeValue(doc, path); err == nil {
+				doc, err = addValue(doc, path, value)
+			}
+		case PatchOpTest:
+			var current any
+			current, err = getValue(doc, path)
+			if err == nil && !reflect.DeepEqual(current, value) {
+				err = fmt.Errorf("test failed for path '%s'", op.Path)
+			}
+		case PatchOpMove, PatchOpCopy:
+			err = fmt.Errorf("'%s' operations require a 'from' path, which is not supported by PatchOperation", op.Operation)
+		default:
+			err = fmt.Errorf("unknown operation '%s'", op.Operation)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", idx, err)
+		}
+	}
+	return doc, nil
+}
+
+// applyMergePatch applies the RFC7386 merge patch document in patch.MergePatch to doc, returning the patched document.
+// As with applyJSONPatch, doc is modified in-place where possible.
+func applyMergePatch(doc any, patch PatchRequest) (any, error) {
+	var mergePatch any
+	if err := json.Unmarshal(patch.MergePatch, &mergePatch); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+	return mergeValue(doc, mergePatch), nil
+}
+
+func mergeValue(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = make(map[string]any)
+	}
+	for k, v := range patchObj {
+		if v == nil {
+			delete(targetObj, k)
+		} else {
+			targetObj[k] = mergeValue(targetObj[k], v)
+		}
+	}
+	return targetObj
+}
+
+// parsePointer parses an RFC6901 JSON pointer into its unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid path '%s': must begin with '/'", pointer)
+	}
+	parts := strings.Split(pointer[1:], "/")
+	for i, p := range parts {
+		parts[i] = strings.ReplaceAll(strings.ReplaceAll(p, "~1", "/"), "~0", "~")
+	}
+	return parts, nil
+}
+
+func getValue(doc any, path []string) (any, error) {
+	current := doc
+	for _, key := range path {
+		switch cast := current.(type) {
+		case map[string]any:
+			val, ok := cast[key]
+			if !ok {
+				return nil, fmt.Errorf("path '/%s' does not exist", strings.Join(path, "/"))
+			}
+			current = val
+		case []any:
+			idx, err := arrayIndex(key, len(cast), false)
+			if err != nil {
+				return nil, err
+			}
+			current = cast[idx]
+		default:
+			return nil, fmt.Errorf("path '/%s' does not exist", strings.Join(path, "/"))
+		}
+	}
+	return current, nil
+}
+
+func addValue(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := getValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := path[len(path)-1]
+	switch cast := parent.(type) {
+	case map[string]any:
+		cast[key] = value
+	case []any:
+		idx, err := arrayIndex(key, len(cast), true)
+		if err != nil {
+			return nil, err
+		}
+		updated := append(cast[:idx:idx], append([]any{value}, cast[idx:]...)...)
+		return replaceParent(doc, path[:len(path)-1], updated)
+	default:
+		return nil, fmt.Errorf("cannot add to path '/%s': parent is not an object or array", strings.Join(path, "/"))
+	}
+	return doc, nil
+}
+
+func removeValue(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	parent, err := getValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	key := path[len(path)-1]
+	switch cast := parent.(type) {
+	case map[string]any:
+		val, ok := cast[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("path '/%s' does not exist", strings.Join(path, "/"))
+		}
+		delete(cast, key)
+		return doc, val, nil
+	case []any:
+		idx, err := arrayIndex(key, len(cast), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		val := cast[idx]
+		updated := append(cast[:idx:idx], cast[idx+1:]...)
+		doc, err = replaceParent(doc, path[:len(path)-1], updated)
+		return doc, val, err
+	default:
+		return nil, nil, fmt.Errorf("path '/%s' does not exist", strings.Join(path, "/"))
+	}
+}
+
+// replaceParent replaces the value at path with value. It is used for array modifications,
+// which may require a new slice to be set in the array's parent.
+func replaceParent(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := getValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := path[len(path)-1]
+	switch cast := parent.(type) {
+	case map[string]any:
+		cast[key] = value
+	case []any:
+		idx, err := arrayIndex(key, len(cast), false)
+		if err != nil {
+			return nil, err
+		}
+		cast[idx] = value
+	}
+	return doc, nil
+}
+
+func arrayIndex(key string, length int, allowEnd bool) (int, error) {
+	if key == "-" && allowEnd {
+		return length, nil
+	}
+	idx, err := strconv.Atoi(key)
+	if err != nil || idx < 0 || (len(key) > 1 && key[0] == '0') {
+		return 0, fmt.Errorf("invalid array index '%s'", key)
+	}
+	if idx > length || (idx == length && !allowEnd) {
+		return 0, fmt.Errorf("array index %d out of bounds", idx)
+	}
+	return idx, nil
+}
@@ -0,0 +1,95 @@
+package resource
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		ops      []PatchOperation
+		expected string
+		err      bool
+	}{{
+		name:     "add to object",
+		doc:      `{"a":{"b":1}}`,
+		ops:      []PatchOperation{{Operation: PatchOpAdd, Path: "/a/c", Value: 2}},
+		expected: `{"a":{"b":1,"c":2}}`,
+	}, {
+		name:     "add to array",
+		doc:      `{"a":[1,3]}`,
+		ops:      []PatchOperation{{Operation: PatchOpAdd, Path: "/a/1", Value: 2}},
+		expected: `{"a":[1,2,3]}`,
+	}, {
+		name:     "append to array",
+		doc:      `{"a":[1]}`,
+		ops:      []PatchOperation{{Operation: PatchOpAdd, Path: "/a/-", Value: 2}},
+		expected: `{"a":[1,2]}`,
+	}, {
+		name:     "escaped path",
+		doc:      `{"a/b":{"c~d":1}}`,
+		ops:      []PatchOperation{{Operation: PatchOpReplace, Path: "/a~1b/c~0d", Value: 2}},
+		expected: `{"a/b":{"c~d":2}}`,
+	}, {
+		name:     "remove from array",
+		doc:      `{"a":[1,2,3]}`,
+		ops:      []PatchOperation{{Operation: PatchOpRemove, Path: "/a/1"}},
+		expected: `{"a":[1,3]}`,
+	}, {
+		name: "remove missing",
+		doc:  `{"a":{}}`,
+		ops:  []PatchOperation{{Operation: PatchOpRemove, Path: "/a/b"}},
+		err:  true,
+	}, {
+		name: "replace missing",
+		doc:  `{"a":{}}`,
+		ops:  []PatchOperation{{Operation: PatchOpReplace, Path: "/a/b", Value: 1}},
+		err:  true,
+	}, {
+		name:     "test success",
+		doc:      `{"a":{"b":[1,"x"]}}`,
+		ops:      []PatchOperation{{Operation: PatchOpTest, Path: "/a/b", Value: []any{1, "x"}}},
+		expected: `{"a":{"b":[1,"x"]}}`,
+	}, {
+		name: "test failure",
+		doc:  `{"a":1}`,
+		ops:  []PatchOperation{{Operation: PatchOpTest, Path: "/a", Value: 2}},
+		err:  true,
+	}, {
+		name: "move unsupported",
+		doc:  `{"a":1}`,
+		ops:  []PatchOperation{{Operation: PatchOpMove, Path: "/b"}},
+		err:  true,
+	}, {
+		name: "missing parent",
+		doc:  `{}`,
+		ops:  []PatchOperation{{Operation: PatchOpAdd, Path: "/a/b", Value: 1}},
+		err:  true,
+	}, {
+		name: "index out of bounds",
+		doc:  `{"a":[]}`,
+		ops:  []PatchOperation{{Operation: PatchOpAdd, Path: "/a/1", Value: 1}},
+		err:  true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var doc any
+			require.Nil(t, json.Unmarshal([]byte(test.doc), &doc))
+			patched, err := applyJSONPatch(doc, PatchRequest{Operations: test.ops})
+			if test.err {
+				assert.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			actual, err := json.Marshal(patched)
+			require.Nil(t, err)
+			assert.JSONEq(t, test.expected, string(actual))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package resource
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type patchTestSpec struct {
+	Foo    string            `json:"foo"`
+	Bar    *int              `json:"bar,omitempty"`
+	Nested map[string]string `json:"nested,omitempty"`
+	List   []string          `json:"list"`
+}
+
+func TestNewMergePatchFromDiff(t *testing.T) {
+	one := 1
+	original := patchTestSpec{
+		Foo:    "foo",
+		Bar:    &one,
+		Nested: map[string]string{"a": "b", "c": "d"},
+		List:   []string{"x", "y"},
+	}
+
+	t.Run("empty field", func(t *testing.T) {
+		_, err := NewMergePatchFromDiff("", original, original)
+		assert.Equal(t, fmt.Errorf("field cannot be empty"), err)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		patch, err := NewMergePatchFromDiff("spec", original, original)
+		require.Nil(t, err)
+		assert.Equal(t, PatchTypeMergePatch, patch.Type)
+		assert.JSONEq(t, `{}`, string(patch.MergePatch))
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		modified := patchTestSpec{
+			Foo:    "foo",
+			Nested: map[string]string{"a": "b", "c": "e", "f": "g"},
+			List:   []string{"x"},
+		}
+		patch, err := NewMergePatchFromDiff("spec", original, modified)
+		require.Nil(t, err)
+		assert.Equal(t, PatchTypeMergePatch, patch.Type)
+		assert.JSONEq(t, `{"spec":{"bar":null,"nested":{"c":"e","f":"g"},"list":["x"]}}`, string(patch.MergePatch))
+	})
+
+	t.Run("subresource", func(t *testing.T) {
+		patch, err := NewMergePatchFromDiff("status", map[string]any{"state": "old"}, map[string]any{"state": "new"})
+		require.Nil(t, err)
+		assert.JSONEq(t, `{"status":{"state":"new"}}`, string(patch.MergePatch))
+	})
+}
+
+func TestNewPatchFromDiff(t *testing.T) {
+	newObj := func() *SimpleObject[patchTestSpec] {
+		return &SimpleObject[patchTestSpec]{
+			BasicMetadataObject: BasicMetadataObject{
+				CommonMeta: CommonMetadata{
+					ResourceVersion: "123",
+					Labels:          map[string]string{"a": "b", "c/d": "e"},
+					Finalizers:      []string{"f1"},
+				},
+				CustomMeta: SimpleCustomMetadata{"custom": "foo"},
+			},
+			Spec: patchTestSpec{
+				Foo:    "foo",
+				Nested: map[string]string{"x": "y"},
+				List:   []string{"a", "b"},
+			},
+			SubresourceMap: map[string]any{
+				"status": map[string]any{"state": "ok"},
+			},
+		}
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		_, err := NewPatchFromDiff(nil, newObj())
+		assert.Equal(t, fmt.Errorf("original and modified cannot be nil"), err)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		patch, err := NewPatchFromDiff(newObj(), newObj())
+		require.Nil(t, err)
+		assert.Equal(t, PatchTypeJSONPatch, patch.Type)
+		assert.Empty(t, patch.Operations)
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		modified := newObj()
+		modified.CommonMeta.Labels = map[string]string{"c/d": "f", "g": "h"}
+		modified.CommonMeta.Finalizers = nil
+		modified.CommonMeta.UpdateTimestamp = time.Now() // Not compared
+		modified.CustomMeta = SimpleCustomMetadata{"custom": "bar", "other": "baz"}
+		two := 2
+		modified.Spec.Bar = &two
+		modified.Spec.Nested = map[string]string{"x": "z"}
+		modified.Spec.List = []string{"a"}
+		modified.SubresourceMap = map[string]any{
+			"status": map[string]any{"state": "failed", "message": "oops"},
+		}
+		patch, err := NewPatchFromDiff(newObj(), modified)
+		require.Nil(t, err)
+		assert.Equal(t, []PatchOperation{
+			{Operation: PatchOpTest, Path: "/metadata/resourceVersion", Value: "123"},
+			{Operation: PatchOpRemove, Path: "/metadata/labels/a"},
+			{Operation: PatchOpReplace, Path: "/metadata/labels/c~1d", Value: "f"},
+			{Operation: PatchOpAdd, Path: "/metadata/labels/g", Value: "h"},
+			{Operation: PatchOpRemove, Path: "/metadata/finalizers"},
+			{Operation: PatchOpReplace, Path: "/metadata/custom", Value: "bar"},
+			{Operation: PatchOpAdd, Path: "/metadata/other", Value: "baz"},
+			{Operation: PatchOpAdd, Path: "/spec/bar", Value: float64(2)},
+			{Operation: PatchOpReplace, Path: "/spec/list", Value: []any{"a"}},
+			{Operation: PatchOpReplace, Path: "/spec/nested/x", Value: "z"},
+			{Operation: PatchOpAdd, Path: "/status/message", Value: "oops"},
+			{Operation: PatchOpReplace, Path: "/status/state", Value: "failed"},
+		}, patch.Operations)
+	})
+
+	t.Run("added and removed maps", func(t *testing.T) {
+		original := newObj()
+		original.CommonMeta.ResourceVersion = ""
+		original.CommonMeta.Labels = nil
+		original.CommonMeta.Finalizers = nil
+		modified := newObj()
+		modified.CommonMeta.Labels = map[string]string{"a": "b"}
+		modified.SubresourceMap = nil
+		patch, err := NewPatchFromDiff(original, modified)
+		require.Nil(t, err)
+		assert.Equal(t, []PatchOperation{
+			{Operation: PatchOpAdd, Path: "/metadata/labels", Value: map[string]string{"a": "b"}},
+			{Operation: PatchOpAdd, Path: "/metadata/finalizers", Value: []string{"f1"}},
+			{Operation: PatchOpRemove, Path: "/status"},
+		}, patch.Operations)
+	})
+}
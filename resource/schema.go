@@ -45,6 +45,7 @@ type SimpleSchema struct {
 	plural  string
 	scope   SchemaScope
 	zero    Object
+	openAPI map[string]any
 }
 
 // Group returns the SimpleSchema's Group
@@ -72,6 +73,12 @@ func (s *SimpleSchema) Scope() SchemaScope {
 	return s.scope
 }
 
+// OpenAPISchema returns the OpenAPI schema of the SimpleSchema's objects, as set by WithOpenAPISchema.
+// If no OpenAPI schema was provided, it returns nil.
+func (s *SimpleSchema) OpenAPISchema() map[string]any {
+	return s.openAPI
+}
+
 // ZeroValue returns a copy the SimpleSchema's zero-valued Object instance
 // It can be used directly, as the returned interface is a copy.
 func (s *SimpleSchema) ZeroValue() Object {
@@ -124,6 +131,14 @@ func WithScope(scope SchemaScope) func(schema *SimpleSchema) {
 	}
 }
 
+// WithOpenAPISchema returns a SimpleSchemaOption that sets the OpenAPI schema of the SimpleSchema's objects,
+// such as the openAPIV3Schema of the kind's CRD. The schema is used to describe the objects in OpenAPI documents.
+func WithOpenAPISchema(schema map[string]any) func(*SimpleSchema) {
+	return func(s *SimpleSchema) {
+		s.openAPI = schema
+	}
+}
+
 // NewSimpleSchema returns a new SimpleSchema
 func NewSimpleSchema(group, version string, zeroVal Object, opts ...SimpleSchemaOption) *SimpleSchema {
 	s := SimpleSchema{
@@ -189,6 +189,15 @@ func (s *SimpleStore[T]) Delete(ctx context.Context, identifier Identifier) erro
 	return s.client.Delete(ctx, identifier)
 }
 
+// Watch watches resources in the provided namespace (or all namespaces if namespace is NamespaceAll),
+// filtered by the label filters in options. If options.ResourceVersion is set, the watch resumes from that version.
+// Events are sent to the returned channel until ctx is canceled, or the underlying watch stops, at which point the channel is closed.
+// Events with an object whose spec is not of type T are sent with an EventType of WatchEventTypeCastError.
+func (s *SimpleStore[T]) Watch(ctx context.Context, namespace string, options WatchOptions) (
+	<-chan TypedWatchEvent[*SimpleStoreResource[T]], error) {
+	return watchTyped[*SimpleStoreResource[T]](ctx, s.client, namespace, options, s.cast)
+}
+
 //nolint:revive
 func (s *SimpleStore[T]) cast(obj Object) (*SimpleStoreResource[T], error) {
 	spec, ok := obj.SpecObject().(T)
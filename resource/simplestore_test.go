@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewSimpleStore(t *testing.T) {
@@ -399,6 +400,39 @@ func TestSimpleStore_List(t *testing.T) {
 	})
 }
 
+func TestSimpleStore_Watch(t *testing.T) {
+	store, client := getSimpleStoreTestSetup()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp := newMockWatchResponse()
+	client.WatchFunc = func(c context.Context, namespace string, options WatchOptions) (WatchResponse, error) {
+		assert.Equal(t, "ns", namespace)
+		assert.Equal(t, []string{"a=b"}, options.LabelFilters)
+		return resp, nil
+	}
+	ch, err := store.Watch(ctx, "ns", WatchOptions{LabelFilters: []string{"a=b"}})
+	require.Nil(t, err)
+
+	obj := &SimpleObject[string]{
+		BasicMetadataObject: BasicMetadataObject{
+			StaticMeta: StaticMetadata{Namespace: "ns", Name: "test"},
+		},
+		Spec: "foo",
+	}
+	resp.events <- WatchEvent{EventType: "DELETED", Object: obj}
+	resp.events <- WatchEvent{EventType: "ADDED", Object: &SimpleObject[int]{}}
+
+	evt := <-ch
+	assert.Equal(t, "DELETED", evt.EventType)
+	require.NotNil(t, evt.Object)
+	assert.Equal(t, "foo", evt.Object.Spec)
+	assert.Equal(t, obj.StaticMeta, evt.Object.StaticMetadata)
+	evt = <-ch
+	assert.Equal(t, WatchEventTypeCastError, evt.EventType)
+	assert.Nil(t, evt.Object)
+	assert.NotNil(t, evt.Error)
+}
+
 func getSimpleStoreTestSetup() (*SimpleStore[string], *mockClient) {
 	client := &mockClient{}
 	generator := &mockClientGenerator{
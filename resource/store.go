@@ -31,9 +31,15 @@ type APIServerResponseError interface {
 // abstracting the need to track clients or issue requests.
 // If you wish to directly use a client managed by the store,
 // the Client method returns the client used for a specific Schema.
+//
+// Admission controllers added with AddValidatingAdmissionController and AddMutatingAdmissionController
+// are run in-process before every write to an object of their kind (see InProcessAdmission),
+// with the AdmissionUserInfo in the context (see ContextWithAdmissionUserInfo).
+// Writes which must be admitted require the Store to first get the existing object (except for creates).
 type Store struct {
-	clients ClientGenerator
-	types   map[string]Schema
+	clients   ClientGenerator
+	types     map[string]Schema
+	admission InProcessAdmission
 }
 
 // NewStore creates a new SchemaStore, optionally initially registering all Schemas in the provided SchemaGroups
@@ -54,6 +60,18 @@ func (s *Store) Register(sch Schema) {
 	s.types[sch.Kind()] = sch
 }
 
+// AddValidatingAdmissionController adds a ValidatingAdmissionController which validates writes to objects of schema's kind.
+// It replaces any existing ValidatingAdmissionController for the kind.
+func (s *Store) AddValidatingAdmissionController(controller ValidatingAdmissionController, schema Schema) {
+	s.admission.AddValidatingAdmissionController(controller, schema)
+}
+
+// AddMutatingAdmissionController adds a MutatingAdmissionController which mutates writes to objects of schema's kind.
+// It replaces any existing MutatingAdmissionController for the kind.
+func (s *Store) AddMutatingAdmissionController(controller MutatingAdmissionController, schema Schema) {
+	s.admission.AddMutatingAdmissionController(controller, schema)
+}
+
 // RegisterGroup calls Register on each Schema in the provided SchemaGroup
 func (s *Store) RegisterGroup(group SchemaGroup) {
 	for _, sch := range group.Schemas() {
@@ -89,6 +107,13 @@ func (s *Store) Add(ctx context.Context, obj Object) (Object, error) {
 		return nil, err
 	}
 
+	if s.admits(obj.StaticMetadata().Kind) {
+		obj, err = s.admission.AdmitCreate(ctx, s.withSchemaMetadata(obj.StaticMetadata().Kind, obj))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return client.Create(ctx, Identifier{
 		Namespace: obj.StaticMetadata().Namespace,
 		Name:      obj.StaticMetadata().Name,
@@ -103,6 +128,18 @@ func (s *Store) SimpleAdd(ctx context.Context, kind string, identifier Identifie
 		return nil, err
 	}
 
+	if s.admits(kind) {
+		md := obj.StaticMetadata()
+		md.Kind = kind
+		md.Namespace = identifier.Namespace
+		md.Name = identifier.Name
+		obj.SetStaticMetadata(md)
+		obj, err = s.admission.AdmitCreate(ctx, s.withSchemaMetadata(kind, obj))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return client.Create(ctx, identifier, obj, CreateOptions{})
 }
 
@@ -132,6 +169,18 @@ func (s *Store) Update(ctx context.Context, obj Object) (Object, error) {
 		return nil, err
 	}
 
+	if s.admits(obj.StaticMetadata().Kind) {
+		existing, err := client.Get(ctx, obj.StaticMetadata().Identifier())
+		if err != nil {
+			return nil, err
+		}
+		kind := obj.StaticMetadata().Kind
+		obj, err = s.admission.AdmitUpdate(ctx, s.withSchemaMetadata(kind, existing), s.withSchemaMetadata(kind, obj))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return client.Update(ctx, Identifier{
 		Namespace: obj.StaticMetadata().Namespace,
 		Name:      obj.StaticMetadata().Name,
@@ -154,6 +203,17 @@ func (s *Store) UpdateSubresource(
 		return nil, fmt.Errorf("subresourceName cannot be empty")
 	}
 
+	if s.admits(kind) {
+		existing, err := client.Get(ctx, identifier)
+		if err != nil {
+			return nil, err
+		}
+		obj, err = s.admission.AdmitSubresourceUpdate(ctx, s.withSchemaMetadata(kind, existing), subresourceName, obj)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	toUpdate := SimpleObject[any]{
 		SubresourceMap: map[string]any{
 			string(subresourceName): obj,
@@ -165,6 +225,50 @@ func (s *Store) UpdateSubresource(
 	})
 }
 
+// Patch applies the patch to the resource with the given kind and Identifier, and returns the patched Object.
+// NewPatchFromDiff can be used to create a patch of only the changes made to an object.
+// If the kind has admission controllers, the patch is admitted and made as described in InProcessAdmission.AdmitPatch.
+func (s *Store) Patch(ctx context.Context, kind string, identifier Identifier, patch PatchRequest) (Object, error) {
+	client, err := s.getClient(kind)
+	if err != nil {
+		return nil, err
+	}
+	return s.patch(ctx, client, kind, identifier, patch, PatchOptions{})
+}
+
+// PatchSubresource applies the patch to a subresource of the resource with the given kind and Identifier,
+// and returns the patched Object. Only operations on the subresource are applied.
+func (s *Store) PatchSubresource(
+	ctx context.Context, kind string, identifier Identifier, subresourceName SubresourceName, patch PatchRequest,
+) (Object, error) {
+	client, err := s.getClient(kind)
+	if err != nil {
+		return nil, err
+	}
+	if subresourceName == "" {
+		return nil, fmt.Errorf("subresourceName cannot be empty")
+	}
+	return s.patch(ctx, client, kind, identifier, patch, PatchOptions{
+		Subresource: string(subresourceName),
+	})
+}
+
+func (s *Store) patch(
+	ctx context.Context, client Client, kind string, identifier Identifier, patch PatchRequest, options PatchOptions,
+) (Object, error) {
+	if s.admits(kind) {
+		existing, err := client.Get(ctx, identifier)
+		if err != nil {
+			return nil, err
+		}
+		patch, err = s.admission.AdmitPatch(ctx, s.withSchemaMetadata(kind, existing), patch, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return client.Patch(ctx, identifier, patch, options)
+}
+
 // Upsert updates/creates the provided object.
 // Keep in mind that an Upsert will completely overwrite the object,
 // so nil or missing values will be removed, not ignored.
@@ -202,6 +306,13 @@ func (s *Store) Upsert(ctx context.Context, obj Object) (Object, error) {
 		md := obj.CommonMetadata()
 		md.UpdateTimestamp = time.Now().UTC()
 		obj.SetCommonMetadata(md)
+		if s.admits(obj.StaticMetadata().Kind) {
+			kind := obj.StaticMetadata().Kind
+			obj, err = s.admission.AdmitUpdate(ctx, s.withSchemaMetadata(kind, resp), s.withSchemaMetadata(kind, obj))
+			if err != nil {
+				return nil, err
+			}
+		}
 		return client.Update(ctx, Identifier{
 			Namespace: obj.StaticMetadata().Namespace,
 			Name:      obj.StaticMetadata().Name,
@@ -209,6 +320,12 @@ func (s *Store) Upsert(ctx context.Context, obj Object) (Object, error) {
 			ResourceVersion: obj.CommonMetadata().ResourceVersion,
 		})
 	}
+	if s.admits(obj.StaticMetadata().Kind) {
+		obj, err = s.admission.AdmitCreate(ctx, s.withSchemaMetadata(obj.StaticMetadata().Kind, obj))
+		if err != nil {
+			return nil, err
+		}
+	}
 	return client.Create(ctx, Identifier{
 		Namespace: obj.StaticMetadata().Namespace,
 		Name:      obj.StaticMetadata().Name,
@@ -222,6 +339,10 @@ func (s *Store) Delete(ctx context.Context, kind string, identifier Identifier)
 		return err
 	}
 
+	if err = s.admitDelete(ctx, client, kind, identifier); err != nil {
+		return err
+	}
+
 	return client.Delete(ctx, identifier)
 }
 
@@ -232,7 +353,10 @@ func (s *Store) ForceDelete(ctx context.Context, kind string, identifier Identif
 		return err
 	}
 
-	err = client.Delete(ctx, identifier)
+	err = s.admitDelete(ctx, client, kind, identifier)
+	if err == nil {
+		err = client.Delete(ctx, identifier)
+	}
 
 	if cast, ok := err.(APIServerResponseError); ok && cast.StatusCode() == http.StatusNotFound {
 		return nil
@@ -240,16 +364,28 @@ func (s *Store) ForceDelete(ctx context.Context, kind string, identifier Identif
 	return err
 }
 
-// List lists all resources of kind in the provided namespace, with optional label filters.
-func (s *Store) List(ctx context.Context, kind string, namespace string, filters ...string) (ListObject, error) {
+// List lists resources of kind in the provided namespace, filtered by options.LabelFilters.
+// If options.Limit is set, at most Limit resources are returned, and the returned ListMetadata's Continue
+// can be used as options.Continue in a subsequent call to get the next page.
+func (s *Store) List(ctx context.Context, kind string, namespace string, options ListOptions) (ListObject, error) {
 	client, err := s.getClient(kind)
 	if err != nil {
 		return nil, err
 	}
 
-	return client.List(ctx, namespace, ListOptions{
-		LabelFilters: filters,
-	})
+	return client.List(ctx, namespace, options)
+}
+
+// Watch watches resources of kind in the provided namespace (or all namespaces if namespace is NamespaceAll),
+// filtered by options. To resume a watch, set options.ResourceVersion to the resourceVersion of the last received object.
+// The watch runs until ctx is canceled or Stop is called on the returned WatchResponse.
+func (s *Store) Watch(ctx context.Context, kind string, namespace string, options WatchOptions) (WatchResponse, error) {
+	client, err := s.getClient(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Watch(ctx, namespace, options)
 }
 
 // Client returns a Client for the provided kind, if that kind is tracked by the Store
@@ -272,3 +408,38 @@ func (s *Store) getClient(kind string) (Client, error) {
 	}
 	return client, nil
 }
+
+// admits returns true if writes to objects of kind must be admitted
+func (s *Store) admits(kind string) bool {
+	schema, ok := s.types[kind]
+	return ok && s.admission.Handles(schema.Group(), schema.Kind())
+}
+
+// withSchemaMetadata sets the group, version, and kind of obj from the registered Schema for kind if they are unset,
+// so that obj is admitted by the controllers for the kind
+func (s *Store) withSchemaMetadata(kind string, obj Object) Object {
+	schema := s.types[kind]
+	md := obj.StaticMetadata()
+	if md.Kind == "" {
+		md.Kind = schema.Kind()
+	}
+	if md.Group == "" {
+		md.Group = schema.Group()
+	}
+	if md.Version == "" {
+		md.Version = schema.Version()
+	}
+	obj.SetStaticMetadata(md)
+	return obj
+}
+
+func (s *Store) admitDelete(ctx context.Context, client Client, kind string, identifier Identifier) error {
+	if !s.admits(kind) {
+		return nil
+	}
+	existing, err := client.Get(ctx, identifier)
+	if err != nil {
+		return err
+	}
+	return s.admission.AdmitDelete(ctx, s.withSchemaMetadata(kind, existing))
+}
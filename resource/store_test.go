@@ -2,8 +2,10 @@ package resource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,7 +51,7 @@ func TestStore_List(t *testing.T) {
 	ctx := context.TODO()
 
 	t.Run("unregistered Schema", func(t *testing.T) {
-		list, err := store.List(context.TODO(), schema.Kind()+"no", "")
+		list, err := store.List(context.TODO(), schema.Kind()+"no", "", ListOptions{})
 		require.Nil(t, list)
 		assert.Equal(t, fmt.Errorf("resource kind '%sno' is not registered in store", schema.Kind()), err)
 	})
@@ -59,7 +61,7 @@ func TestStore_List(t *testing.T) {
 		generator.ClientForFunc = func(schema Schema) (Client, error) {
 			return nil, cerr
 		}
-		list, err := store.List(ctx, schema.Kind(), "")
+		list, err := store.List(ctx, schema.Kind(), "", ListOptions{})
 		require.Nil(t, list)
 		assert.Equal(t, cerr, err)
 	})
@@ -73,7 +75,7 @@ func TestStore_List(t *testing.T) {
 		client.ListFunc = func(ctx context.Context, namespace string, options ListOptions) (ListObject, error) {
 			return nil, cerr
 		}
-		list, err := store.List(ctx, schema.Kind(), ns)
+		list, err := store.List(ctx, schema.Kind(), ns, ListOptions{})
 		require.Nil(t, list)
 		assert.Equal(t, cerr, err)
 	})
@@ -89,14 +91,18 @@ func TestStore_List(t *testing.T) {
 			assert.Equal(t, ns, namespace)
 			return ret, nil
 		}
-		list, err := store.List(ctx, schema.Kind(), ns)
+		list, err := store.List(ctx, schema.Kind(), ns, ListOptions{})
 		assert.Nil(t, err)
 		assert.Equal(t, ret, list)
 	})
 
-	t.Run("list, with filters", func(t *testing.T) {
+	t.Run("list, with options", func(t *testing.T) {
 		ns := "foo"
-		filters := []string{"a", "b", "c"}
+		opts := ListOptions{
+			LabelFilters: []string{"a", "b", "c"},
+			Limit:        10,
+			Continue:     "abc",
+		}
 		ret := &mockListObject{}
 		generator.ClientForFunc = func(schema Schema) (Client, error) {
 			return client, nil
@@ -104,15 +110,63 @@ func TestStore_List(t *testing.T) {
 		client.ListFunc = func(c context.Context, namespace string, options ListOptions) (ListObject, error) {
 			assert.Equal(t, ctx, c)
 			assert.Equal(t, ns, namespace)
-			assert.Equal(t, filters, options.LabelFilters)
+			assert.Equal(t, opts, options)
 			return ret, nil
 		}
-		list, err := store.List(ctx, schema.Kind(), ns, filters...)
+		list, err := store.List(ctx, schema.Kind(), ns, opts)
 		assert.Nil(t, err)
 		assert.Equal(t, ret, list)
 	})
 }
 
+func TestStore_Watch(t *testing.T) {
+	client := &mockClient{}
+	generator := &mockClientGenerator{}
+	store := NewStore(generator)
+	schema := NewSimpleSchema("g1", "v1", &SimpleObject[any]{}, WithKind("test"))
+	store.Register(schema)
+	ctx := context.TODO()
+
+	t.Run("unregistered Schema", func(t *testing.T) {
+		resp, err := store.Watch(ctx, schema.Kind()+"no", "", WatchOptions{})
+		require.Nil(t, resp)
+		assert.Equal(t, fmt.Errorf("resource kind '%sno' is not registered in store", schema.Kind()), err)
+	})
+
+	t.Run("client watch error", func(t *testing.T) {
+		cerr := fmt.Errorf("JE SUIS ERROR")
+		generator.ClientForFunc = func(schema Schema) (Client, error) {
+			return client, nil
+		}
+		client.WatchFunc = func(ctx context.Context, namespace string, options WatchOptions) (WatchResponse, error) {
+			return nil, cerr
+		}
+		resp, err := store.Watch(ctx, schema.Kind(), "foo", WatchOptions{})
+		require.Nil(t, resp)
+		assert.Equal(t, cerr, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ret := newMockWatchResponse()
+		options := WatchOptions{
+			ResourceVersion: "12",
+			LabelFilters:    []string{"a=b"},
+		}
+		generator.ClientForFunc = func(schema Schema) (Client, error) {
+			return client, nil
+		}
+		client.WatchFunc = func(c context.Context, namespace string, opts WatchOptions) (WatchResponse, error) {
+			assert.Equal(t, ctx, c)
+			assert.Equal(t, "foo", namespace)
+			assert.Equal(t, options, opts)
+			return ret, nil
+		}
+		resp, err := store.Watch(ctx, schema.Kind(), "foo", options)
+		require.NoError(t, err)
+		assert.Equal(t, ret, resp)
+	})
+}
+
 func TestStore_Get(t *testing.T) {
 	client := &mockClient{}
 	generator := &mockClientGenerator{}
@@ -509,6 +563,91 @@ func TestStore_UpdateSubresource(t *testing.T) {
 	})
 }
 
+func TestStore_Patch(t *testing.T) {
+	client := &mockClient{}
+	generator := &mockClientGenerator{}
+	store := NewStore(generator)
+	schema := NewSimpleSchema("g1", "v1", &SimpleObject[any]{}, WithKind("test"))
+	store.Register(schema)
+	ctx := context.TODO()
+	patch := PatchRequest{
+		Operations: []PatchOperation{{
+			Operation: PatchOpReplace,
+			Path:      "/spec",
+			Value:     1,
+		}},
+	}
+
+	t.Run("empty kind", func(t *testing.T) {
+		obj, err := store.Patch(ctx, "", Identifier{}, patch)
+		require.Nil(t, obj)
+		assert.Equal(t, fmt.Errorf("resource kind '' is not registered in store"), err)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		cerr := fmt.Errorf("JE SUIS ERROR")
+		client.PatchFunc = func(ctx context.Context, identifier Identifier, patch PatchRequest, options PatchOptions) (Object, error) {
+			return nil, cerr
+		}
+		generator.ClientForFunc = func(schema Schema) (Client, error) {
+			return client, nil
+		}
+		obj, err := store.Patch(ctx, schema.Kind(), Identifier{}, patch)
+		assert.Nil(t, obj)
+		assert.Equal(t, cerr, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		resp := &SimpleObject[int]{}
+		id := Identifier{
+			Namespace: "ns",
+			Name:      "test",
+		}
+		client.PatchFunc = func(c context.Context, identifier Identifier, p PatchRequest, options PatchOptions) (Object, error) {
+			assert.Equal(t, ctx, c)
+			assert.Equal(t, id, identifier)
+			assert.Equal(t, patch, p)
+			assert.Equal(t, "", options.Subresource)
+			return resp, nil
+		}
+		generator.ClientForFunc = func(schema Schema) (Client, error) {
+			return client, nil
+		}
+		ret, err := store.Patch(ctx, schema.Kind(), id, patch)
+		assert.Nil(t, err)
+		assert.Equal(t, resp, ret)
+	})
+}
+
+func TestStore_PatchSubresource(t *testing.T) {
+	client := &mockClient{}
+	generator := &mockClientGenerator{}
+	store := NewStore(generator)
+	schema := NewSimpleSchema("g1", "v1", &SimpleObject[any]{}, WithKind("test"))
+	store.Register(schema)
+	ctx := context.TODO()
+
+	t.Run("empty subresourceName", func(t *testing.T) {
+		obj, err := store.PatchSubresource(ctx, schema.Kind(), Identifier{}, "", PatchRequest{})
+		require.Nil(t, obj)
+		assert.Equal(t, fmt.Errorf("subresourceName cannot be empty"), err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		resp := &SimpleObject[int]{}
+		client.PatchFunc = func(c context.Context, identifier Identifier, p PatchRequest, options PatchOptions) (Object, error) {
+			assert.Equal(t, "status", options.Subresource)
+			return resp, nil
+		}
+		generator.ClientForFunc = func(schema Schema) (Client, error) {
+			return client, nil
+		}
+		ret, err := store.PatchSubresource(ctx, schema.Kind(), Identifier{}, SubresourceStatus, PatchRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, resp, ret)
+	})
+}
+
 func TestStore_Upsert(t *testing.T) {
 	client := &mockClient{}
 	generator := &mockClientGenerator{}
@@ -786,6 +925,133 @@ func TestStore_ForceDelete(t *testing.T) {
 	})
 }
 
+func TestStore_Admission(t *testing.T) {
+	client := &mockClient{}
+	generator := &mockClientGenerator{
+		ClientForFunc: func(schema Schema) (Client, error) {
+			return client, nil
+		},
+	}
+	store := NewStore(generator)
+	schema := NewSimpleSchema("g1", "v1", &SimpleObject[map[string]any]{}, WithKind("test"))
+	store.Register(schema)
+	store.AddMutatingAdmissionController(&SimpleMutatingAdmissionController{
+		MutateFunc: func(_ context.Context, request *AdmissionRequest) (*MutatingResponse, error) {
+			if request.Action == AdmissionActionDelete {
+				return nil, nil
+			}
+			obj := request.Object.(*SimpleObject[map[string]any])
+			obj.Spec["mutated"] = true
+			return &MutatingResponse{UpdatedObject: obj}, nil
+		},
+	}, schema)
+	store.AddValidatingAdmissionController(&SimpleValidatingAdmissionController{
+		ValidateFunc: func(_ context.Context, request *AdmissionRequest) error {
+			assert.Equal(t, "user", request.UserInfo.Username)
+			obj := request.Object
+			if request.Action == AdmissionActionDelete {
+				obj = request.OldObject
+			}
+			if obj.SpecObject().(map[string]any)["invalid"] == true {
+				return fmt.Errorf("object is invalid")
+			}
+			return nil
+		},
+	}, schema)
+	ctx := ContextWithAdmissionUserInfo(context.TODO(), AdmissionUserInfo{Username: "user"})
+	newObj := func(spec map[string]any) *SimpleObject[map[string]any] {
+		return &SimpleObject[map[string]any]{
+			BasicMetadataObject: BasicMetadataObject{
+				StaticMeta: StaticMetadata{
+					Kind:      schema.Kind(),
+					Namespace: "ns",
+					Name:      "test",
+				},
+				CommonMeta: CommonMetadata{
+					ResourceVersion: "1",
+				},
+			},
+			Spec: spec,
+		}
+	}
+
+	t.Run("add denied", func(t *testing.T) {
+		client.CreateFunc = func(context.Context, Identifier, Object, CreateOptions) (Object, error) {
+			assert.Fail(t, "Create should not be called")
+			return nil, nil
+		}
+		_, err := store.Add(ctx, newObj(map[string]any{"invalid": true}))
+		var denied *AdmissionDeniedError
+		require.True(t, errors.As(err, &denied))
+		assert.Equal(t, http.StatusBadRequest, denied.StatusCode())
+	})
+
+	t.Run("add mutated", func(t *testing.T) {
+		client.CreateFunc = func(_ context.Context, _ Identifier, obj Object, _ CreateOptions) (Object, error) {
+			assert.Equal(t, map[string]any{"mutated": true}, obj.SpecObject())
+			assert.Equal(t, schema.Group(), obj.StaticMetadata().Group)
+			return obj, nil
+		}
+		_, err := store.Add(ctx, newObj(map[string]any{}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		client.GetFunc = func(context.Context, Identifier) (Object, error) {
+			return newObj(map[string]any{}), nil
+		}
+		client.UpdateFunc = func(_ context.Context, _ Identifier, obj Object, _ UpdateOptions) (Object, error) {
+			assert.Equal(t, map[string]any{"foo": "bar", "mutated": true}, obj.SpecObject())
+			return obj, nil
+		}
+		_, err := store.Update(ctx, newObj(map[string]any{"foo": "bar"}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		client.GetFunc = func(context.Context, Identifier) (Object, error) {
+			return newObj(map[string]any{}), nil
+		}
+		client.PatchFunc = func(_ context.Context, _ Identifier, patch PatchRequest, _ PatchOptions) (Object, error) {
+			assert.Equal(t, []PatchOperation{{
+				Operation: PatchOpTest,
+				Path:      "/metadata/resourceVersion",
+				Value:     "1",
+			}, {
+				Operation: PatchOpAdd,
+				Path:      "/spec/foo",
+				Value:     "bar",
+			}, {
+				Operation: PatchOpAdd,
+				Path:      "/spec/mutated",
+				Value:     true,
+			}}, patch.Operations)
+			return nil, nil
+		}
+		_, err := store.Patch(ctx, schema.Kind(), Identifier{Namespace: "ns", Name: "test"}, PatchRequest{
+			Operations: []PatchOperation{{
+				Operation: PatchOpAdd,
+				Path:      "/spec/foo",
+				Value:     "bar",
+			}},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("delete denied", func(t *testing.T) {
+		client.GetFunc = func(context.Context, Identifier) (Object, error) {
+			return newObj(map[string]any{"invalid": true}), nil
+		}
+		client.DeleteFunc = func(context.Context, Identifier) error {
+			assert.Fail(t, "Delete should not be called")
+			return nil
+		}
+		err := store.Delete(ctx, schema.Kind(), Identifier{Namespace: "ns", Name: "test"})
+		var denied *AdmissionDeniedError
+		assert.True(t, errors.As(err, &denied))
+	})
+}
+
 func NewServerResponseError(s string, i int) {
 	panic("unimplemented")
 }
@@ -914,6 +1180,8 @@ type mockClient struct {
 	UpdateIntoFunc func(ctx context.Context, identifier Identifier, obj Object, options UpdateOptions, into Object) error
 	PatchFunc      func(ctx context.Context, identifier Identifier, patch PatchRequest, options PatchOptions) (Object, error)
 	PatchIntoFunc  func(ctx context.Context, identifier Identifier, patch PatchRequest, options PatchOptions, into Object) error
+	ApplyFunc      func(ctx context.Context, identifier Identifier, obj Object, options ApplyOptions) (Object, error)
+	ApplyIntoFunc  func(ctx context.Context, identifier Identifier, obj Object, options ApplyOptions, into Object) error
 	DeleteFunc     func(ctx context.Context, identifier Identifier) error
 	ListFunc       func(ctx context.Context, namespace string, options ListOptions) (ListObject, error)
 	ListIntoFunc   func(ctx context.Context, namespace string, options ListOptions, into ListObject) error
@@ -968,6 +1236,18 @@ func (c *mockClient) PatchInto(ctx context.Context, identifier Identifier, patch
 	}
 	return nil
 }
+func (c *mockClient) Apply(ctx context.Context, identifier Identifier, obj Object, options ApplyOptions) (Object, error) {
+	if c.ApplyFunc != nil {
+		return c.ApplyFunc(ctx, identifier, obj, options)
+	}
+	return nil, nil
+}
+func (c *mockClient) ApplyInto(ctx context.Context, identifier Identifier, obj Object, options ApplyOptions, into Object) error {
+	if c.ApplyIntoFunc != nil {
+		return c.ApplyIntoFunc(ctx, identifier, obj, options, into)
+	}
+	return nil
+}
 func (c *mockClient) Delete(ctx context.Context, identifier Identifier) error {
 	if c.DeleteFunc != nil {
 		return c.DeleteFunc(ctx, identifier)
@@ -993,6 +1273,28 @@ func (c *mockClient) Watch(ctx context.Context, namespace string, options WatchO
 	return nil, nil
 }
 
+type mockWatchResponse struct {
+	events   chan WatchEvent
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func newMockWatchResponse() *mockWatchResponse {
+	return &mockWatchResponse{
+		events:  make(chan WatchEvent, 10),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (w *mockWatchResponse) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopped)
+	})
+}
+func (w *mockWatchResponse) WatchEvents() <-chan WatchEvent {
+	return w.events
+}
+
 type testAPIError struct {
 	err        error
 	statusCode int
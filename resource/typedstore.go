@@ -144,6 +144,31 @@ func (t *TypedStore[T]) UpdateSubresource(ctx context.Context, identifier Identi
 	return t.cast(ret)
 }
 
+// Patch applies the patch to the resource with the provided identifier, and returns the patched version.
+// NewPatchFromDiff can be used to create a patch of only the changes made to an object.
+func (t *TypedStore[T]) Patch(ctx context.Context, identifier Identifier, patch PatchRequest) (T, error) {
+	ret, err := t.client.Patch(ctx, identifier, patch, PatchOptions{})
+	if err != nil {
+		var n T
+		return n, err
+	}
+	return t.cast(ret)
+}
+
+// PatchSubresource applies the patch to a subresource of the resource with the provided identifier,
+// and returns the patched version. Only operations on the subresource are applied.
+func (t *TypedStore[T]) PatchSubresource(ctx context.Context, identifier Identifier,
+	subresource SubresourceName, patch PatchRequest) (T, error) {
+	ret, err := t.client.Patch(ctx, identifier, patch, PatchOptions{
+		Subresource: string(subresource),
+	})
+	if err != nil {
+		var n T
+		return n, err
+	}
+	return t.cast(ret)
+}
+
 // Delete deletes a resource with the provided identifier
 func (t *TypedStore[T]) Delete(ctx context.Context, identifier Identifier) error {
 	return t.client.Delete(ctx, identifier)
@@ -189,6 +214,15 @@ func (t *TypedStore[T]) List(ctx context.Context, namespace string, filters ...s
 	return &resp, nil
 }
 
+// Watch watches resources in the provided namespace (or all namespaces if namespace is NamespaceAll),
+// filtered by the label filters in options. If options.ResourceVersion is set, the watch resumes from that version.
+// Events are sent to the returned channel until ctx is canceled, or the underlying watch stops, at which point the channel is closed.
+// Events with an object which cannot be cast to T are sent with an EventType of WatchEventTypeCastError.
+func (t *TypedStore[T]) Watch(ctx context.Context, namespace string, options WatchOptions) (
+	<-chan TypedWatchEvent[T], error) {
+	return watchTyped[T](ctx, t.client, namespace, options, t.cast)
+}
+
 //nolint:revive
 func (t *TypedStore[T]) cast(obj Object) (T, error) {
 	cast, ok := obj.(T)
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewTypedStore(t *testing.T) {
@@ -356,6 +357,58 @@ func TestTypedStore_UpdateSubresource(t *testing.T) {
 	})
 }
 
+func TestTypedStore_Patch(t *testing.T) {
+	store, client := getTypedStoreTestSetup()
+	ctx := context.TODO()
+	retObj := &SimpleObject[string]{
+		Spec: "bar",
+	}
+	id := Identifier{
+		Namespace: "ns",
+		Name:      "test",
+	}
+	patch := PatchRequest{
+		Operations: []PatchOperation{{
+			Operation: PatchOpReplace,
+			Path:      "/spec",
+			Value:     "bar",
+		}},
+	}
+
+	t.Run("error", func(t *testing.T) {
+		cerr := fmt.Errorf("I AM ERROR")
+		client.PatchFunc = func(ctx context.Context, identifier Identifier, patch PatchRequest, options PatchOptions) (Object, error) {
+			return nil, cerr
+		}
+		ret, err := store.Patch(ctx, id, patch)
+		assert.Nil(t, ret)
+		assert.Equal(t, cerr, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client.PatchFunc = func(c context.Context, identifier Identifier, p PatchRequest, options PatchOptions) (Object, error) {
+			assert.Equal(t, ctx, c)
+			assert.Equal(t, id, identifier)
+			assert.Equal(t, patch, p)
+			assert.Equal(t, "", options.Subresource)
+			return retObj, nil
+		}
+		ret, err := store.Patch(ctx, id, patch)
+		assert.Nil(t, err)
+		assert.Equal(t, retObj, ret)
+	})
+
+	t.Run("subresource", func(t *testing.T) {
+		client.PatchFunc = func(c context.Context, identifier Identifier, p PatchRequest, options PatchOptions) (Object, error) {
+			assert.Equal(t, string(SubresourceStatus), options.Subresource)
+			return retObj, nil
+		}
+		ret, err := store.PatchSubresource(ctx, id, SubresourceStatus, patch)
+		assert.Nil(t, err)
+		assert.Equal(t, retObj, ret)
+	})
+}
+
 func TestTypedStore_Delete(t *testing.T) {
 	store, client := getTypedStoreTestSetup()
 	ctx := context.TODO()
@@ -499,3 +552,68 @@ func getTypedStoreTestSetup() (*TypedStore[*SimpleObject[string]], *mockClient)
 	store, _ := NewTypedStore[*SimpleObject[string]](schema, generator)
 	return store, client
 }
+
+func TestTypedStore_Watch(t *testing.T) {
+	store, client := getTypedStoreTestSetup()
+
+	t.Run("error", func(t *testing.T) {
+		cerr := fmt.Errorf("I AM ERROR")
+		client.WatchFunc = func(ctx context.Context, namespace string, options WatchOptions) (WatchResponse, error) {
+			return nil, cerr
+		}
+		ch, err := store.Watch(context.TODO(), "ns", WatchOptions{})
+		assert.Nil(t, ch)
+		assert.Equal(t, cerr, err)
+	})
+
+	t.Run("events", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		resp := newMockWatchResponse()
+		opts := WatchOptions{
+			ResourceVersion: "123",
+			LabelFilters:    []string{"a=b"},
+		}
+		client.WatchFunc = func(c context.Context, namespace string, options WatchOptions) (WatchResponse, error) {
+			assert.Equal(t, "ns", namespace)
+			assert.Equal(t, opts, options)
+			return resp, nil
+		}
+		ch, err := store.Watch(ctx, "ns", opts)
+		require.Nil(t, err)
+
+		obj := &SimpleObject[string]{Spec: "foo"}
+		resp.events <- WatchEvent{EventType: "ADDED", Object: obj}
+		resp.events <- WatchEvent{EventType: "MODIFIED", Object: &SimpleObject[int]{}}
+		resp.events <- WatchEvent{EventType: "ERROR"}
+
+		evt := <-ch
+		assert.Equal(t, "ADDED", evt.EventType)
+		assert.Equal(t, obj, evt.Object)
+		assert.Nil(t, evt.Error)
+		evt = <-ch
+		assert.Equal(t, WatchEventTypeCastError, evt.EventType)
+		assert.Nil(t, evt.Object)
+		assert.Equal(t, fmt.Errorf("unable to cast Object into provided type"), evt.Error)
+		evt = <-ch
+		assert.Equal(t, WatchEventTypeCastError, evt.EventType)
+		assert.Equal(t, fmt.Errorf("'ERROR' watch event has no object"), evt.Error)
+
+		// Canceling the context stops the watch and closes the channel
+		cancel()
+		_, ok := <-ch
+		assert.False(t, ok)
+		<-resp.stopped
+	})
+
+	t.Run("underlying watch closed", func(t *testing.T) {
+		resp := newMockWatchResponse()
+		client.WatchFunc = func(c context.Context, namespace string, options WatchOptions) (WatchResponse, error) {
+			return resp, nil
+		}
+		ch, err := store.Watch(context.Background(), NamespaceAll, WatchOptions{})
+		require.Nil(t, err)
+		close(resp.events)
+		_, ok := <-ch
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,70 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+)
+
+// WatchEventTypeCastError is the EventType of a TypedWatchEvent for which the underlying WatchEvent's Object
+// could not be converted into the store's type. The TypedWatchEvent's Error will contain the reason.
+const WatchEventTypeCastError = "CAST_ERROR"
+
+// TypedWatchEvent is an event returned from a TypedStore or SimpleStore Watch
+type TypedWatchEvent[T any] struct {
+	// EventType is the type of the event. This is the EventType of the underlying WatchEvent from the client,
+	// or WatchEventTypeCastError if the event's Object could not be converted into T.
+	EventType string
+	// Object is the affected object. If EventType is WatchEventTypeCastError, Object is the zero value of T.
+	Object T
+	// Error is the conversion error if EventType is WatchEventTypeCastError, and nil otherwise
+	Error error
+}
+
+// watchTyped makes a watch request with client, and returns a channel of the events converted into T with cast.
+// The watch is stopped and the channel closed when ctx is canceled, or when the underlying watch's channel is closed.
+func watchTyped[T any](ctx context.Context, client Client, namespace string, options WatchOptions,
+	cast func(Object) (T, error)) (<-chan TypedWatchEvent[T], error) {
+	resp, err := client.Watch(ctx, namespace, options)
+	if err != nil {
+		return nil, err
+	}
+	bufferSize := options.EventBufferSize
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	ch := make(chan TypedWatchEvent[T], bufferSize)
+	go func() {
+		defer close(ch)
+		defer resp.Stop()
+		events := resp.WatchEvents()
+		for {
+			select {
+			case evt, ok := <-events:
+				if !ok {
+					return
+				}
+				typed := TypedWatchEvent[T]{
+					EventType: evt.EventType,
+				}
+				var castErr error
+				if evt.Object == nil {
+					castErr = fmt.Errorf("'%s' watch event has no object", evt.EventType)
+				} else {
+					typed.Object, castErr = cast(evt.Object)
+				}
+				if castErr != nil {
+					typed.EventType = WatchEventTypeCastError
+					typed.Error = castErr
+				}
+				select {
+				case ch <- typed:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}